@@ -1,20 +1,35 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"log"
+	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/securedlinq/backend/internal/config"
+	"github.com/securedlinq/backend/internal/courier"
+	"github.com/securedlinq/backend/internal/courier/templates"
 	"github.com/securedlinq/backend/internal/database"
+	"github.com/securedlinq/backend/internal/events"
 	"github.com/securedlinq/backend/internal/handler"
+	"github.com/securedlinq/backend/internal/mailer"
 	"github.com/securedlinq/backend/internal/middleware"
+	"github.com/securedlinq/backend/internal/notification"
 	"github.com/securedlinq/backend/internal/repository"
 	"github.com/securedlinq/backend/internal/service"
+	"github.com/securedlinq/backend/internal/service/accesskey"
+	"github.com/securedlinq/backend/internal/webhook"
 	"github.com/securedlinq/backend/pkg/agora"
+	"github.com/securedlinq/backend/pkg/filestore"
 	"github.com/securedlinq/backend/pkg/s3"
+	"github.com/securedlinq/backend/pkg/s3gateway"
+	"github.com/securedlinq/backend/pkg/scanner"
+	"github.com/securedlinq/backend/pkg/sharing"
 )
 
 func main() {
@@ -33,39 +48,208 @@ func main() {
 		log.Fatalf("Failed to connect to database: %v", err)
 	}
 
-	// Run migrations
-	if err := database.AutoMigrate(db); err != nil {
+	// Check the database schema is up to date, applying pending migrations
+	// automatically only if AUTO_MIGRATE is enabled.
+	if err := database.EnsureSchema(db, cfg.Database.AutoMigrate); err != nil {
 		log.Fatalf("Failed to run migrations: %v", err)
 	}
 
 	// Initialize repositories
 	sessionRepo := repository.NewSessionRepository(db)
 	meetingRepo := repository.NewMeetingRepository(db)
+	meetingJoinTokenRepo := repository.NewMeetingJoinTokenRepository(db)
+	meetingJoinSessionRepo := repository.NewMeetingJoinSessionRepository(db)
 	driverRepo := repository.NewDriverRepository(db)
 	loadRepo := repository.NewLoadRepository(db)
 	galleryRepo := repository.NewGalleryRepository(db)
+	participantRepo := repository.NewMeetingParticipantRepository(db)
+	apiTokenRepo := repository.NewAPITokenRepository(db)
+	courierRepo := repository.NewCourierRepository(db)
+	recordingJobRepo := repository.NewRecordingJobRepository(db)
+	accessKeyRepo := repository.NewAccessKeyRepository(db)
+	uploadRepo := repository.NewUploadRepository(db)
+	mediaShareRepo := repository.NewMediaShareRepository(db)
+	notificationPrefRepo := repository.NewNotificationPreferenceRepository(db)
+	webhookRepo := repository.NewWebhookRepository(db)
+	webhookDeliveryRepo := repository.NewWebhookDeliveryRepository(db)
+	rolePermissionRepo := repository.NewRolePermissionRepository(db)
+	clientLogRepo := repository.NewClientLogRepository(db)
+	idempotencyKeyRepo := repository.NewIdempotencyKeyRepository(db)
 	// Initialize external clients
-	agoraClient := agora.NewClient(cfg.Agora.AppID, cfg.Agora.AppCertificate, cfg.Agora.EncodedKey)
+	agoraClient := agora.NewClient(cfg.Agora.AppID, cfg.Agora.AppCertificate, cfg.Agora.EncodedKey, recordingStorageBackends(cfg.RecordingStorage))
 	s3Client, err := s3.NewClient(cfg.AWS.AccessKeyID, cfg.AWS.SecretAccessKey, cfg.AWS.Region, cfg.AWS.S3BucketName)
 	if err != nil {
 		log.Printf("Warning: Failed to initialize S3 client: %v", err)
 	}
 
+	// s3Gateway backs the read-only S3-shaped API (pkg/s3gateway) that lets
+	// external analytics/backup tools browse a driver's gallery media
+	// without raw AWS credentials. Disabled (nil) if s3Client failed to
+	// initialize, since it redirects to presigned URLs against the real
+	// bucket.
+	var s3Gateway *s3gateway.Gateway
+	if s3Client != nil {
+		s3Gateway = s3gateway.NewGateway(driverRepo, galleryRepo, loadRepo, s3Client, cfg.Session.Secret)
+	}
+
+	// shareService backs public revocable share links for gallery/load
+	// media (pkg/sharing). Gallery-scope shares only need fileStore, so
+	// this is constructed regardless of s3Client; load-scope (zip bundle)
+	// shares additionally require s3Client and 503 without it, same as
+	// UploadStream.
+	shareService := sharing.NewService(mediaShareRepo, galleryRepo, s3Client, cfg.Session.Secret)
+
+	// Select the FileStore backend for MediaHandler's screenshot/gallery
+	// uploads (S3/S3-compatible by default, local disk for deployments
+	// without object storage). The recording pipeline above keeps using
+	// s3Client directly - it needs S3-specific capabilities this interface
+	// doesn't expose.
+	var fileStore filestore.FileStore
+	var localFileHandler *handler.LocalFileHandler
+	if cfg.FileStore.Backend == "local" {
+		localStore, err := filestore.NewLocalStore(filestore.LocalConfig{
+			Dir:     cfg.FileStore.LocalDir,
+			BaseURL: cfg.Server.BaseURL,
+			Secret:  cfg.FileStore.LocalSecret,
+		})
+		if err != nil {
+			log.Fatalf("Failed to initialize local file store: %v", err)
+		}
+		fileStore = localStore
+		localFileHandler = handler.NewLocalFileHandler(localStore)
+	} else {
+		s3Store, err := filestore.NewS3Store(filestore.S3Config{
+			AccessKeyID:     cfg.AWS.AccessKeyID,
+			SecretAccessKey: cfg.AWS.SecretAccessKey,
+			Region:          cfg.AWS.Region,
+			Bucket:          cfg.AWS.S3BucketName,
+			Endpoint:        cfg.FileStore.Endpoint,
+			ForcePathStyle:  cfg.FileStore.ForcePathStyle,
+		})
+		if err != nil {
+			log.Fatalf("Failed to initialize file store: %v", err)
+		}
+		fileStore = s3Store
+	}
+
+	// Select the session store backend (database by default, Redis for
+	// clustered deployments so sessions survive restarts and are shared
+	// across replicas).
+	var sessionStore service.SessionStore
+	if cfg.Session.Backend == "redis" {
+		sessionStore = service.NewRedisSessionStore(&cfg.Redis)
+	} else {
+		sessionStore = service.NewMemorySessionStore(sessionRepo)
+	}
+
+	// Event bus fanning meeting/recording/load lifecycle events out to every
+	// registered Sink: a ChannelSink for in-process consumers (drained by
+	// the logging goroutine below) and the webhook Dispatcher, which queues
+	// a signed delivery for each admin-registered webhook subscribed to the
+	// event.
+	eventLog := events.NewChannelSink(256)
+	webhookDispatcher := webhook.NewDispatcher(webhookRepo, webhookDeliveryRepo)
+	eventsBus := events.NewBus(eventLog, webhookDispatcher)
+	go func() {
+		for event := range eventLog.C {
+			log.Printf("event: %s %+v", event.Type, event.Payload)
+		}
+	}()
+
 	// Initialize services
-	authService := service.NewAuthService(sessionRepo, driverRepo, cfg)
-	meetingService := service.NewMeetingService(meetingRepo, loadRepo, cfg)
-	recordingService := service.NewRecordingService(meetingRepo, galleryRepo, agoraClient)
+	authService := service.NewAuthService(sessionStore, driverRepo, apiTokenRepo, cfg)
+	uiAuthService := service.NewUIAuthService(sessionStore, cfg)
+	meetingService := service.NewMeetingService(meetingRepo, loadRepo, meetingJoinTokenRepo, meetingJoinSessionRepo, cfg, eventsBus)
+	meetingACLService := service.NewMeetingACLService(meetingRepo, loadRepo, participantRepo)
+	recordingService := service.NewRecordingService(meetingRepo, galleryRepo, recordingJobRepo, agoraClient, s3Client, cfg.RecordingStorage.RetentionPolicy, eventsBus)
 	driverService := service.NewDriverService(driverRepo)
-	loadService := service.NewLoadService(loadRepo, driverRepo)
+	verificationService := service.NewVerificationService(driverRepo, &cfg.Email)
+	loadService := service.NewLoadService(loadRepo, driverRepo, eventsBus)
+	courierService := service.NewCourierService(courierRepo)
+	webhookService := service.NewWebhookService(webhookRepo, webhookDeliveryRepo)
+	policyService := service.NewPolicyService(rolePermissionRepo)
+	accessKeyService := accesskey.NewService(accessKeyRepo, loadRepo, s3Client, cfg.Session.Secret,
+		time.Duration(cfg.AccessKey.TTLSeconds)*time.Second, time.Duration(cfg.AccessKey.PresignExpirySeconds)*time.Second)
+
+	// Wire the courier dispatcher (renders + enqueues) and its background
+	// worker (delivers queued messages over SMTP/SMS/push with retry/
+	// backoff). The worker shares one outbox across every channel;
+	// notification.Service is what decides, per driver, which of them a
+	// given message actually goes out on.
+	templateRenderer := templates.NewRenderer(cfg.Courier.TemplatesRoot)
+	courierDispatcher := courier.NewDispatcher(courierRepo, templateRenderer)
+	smtpChannel := courier.NewSMTPChannel(mailer.NewClient(&cfg.Email))
+	courierChannels := map[string]courier.Channel{
+		notification.ChannelSMTP: smtpChannel,
+	}
+	if cfg.Notification.TwilioAccountSID != "" {
+		courierChannels[notification.ChannelSMS] = notification.NewTwilioSMSNotifier(cfg.Notification.TwilioAccountSID, cfg.Notification.TwilioAuthToken, cfg.Notification.TwilioFromNumber)
+	}
+	if cfg.Notification.FCMServerKey != "" {
+		courierChannels[notification.ChannelPush] = notification.NewFCMPushNotifier(cfg.Notification.FCMServerKey)
+	}
+	courierWorker := courier.NewMultiChannelWorker(courierRepo, courierChannels, time.Duration(cfg.Courier.PollInterval)*time.Second)
+	courierStop := make(chan struct{})
+	go courierWorker.Run(courierStop)
+
+	notificationService := notification.NewService(driverRepo, notificationPrefRepo, courierRepo, templateRenderer)
+
+	// Background worker that resumes RecordingJobs stuck short of a
+	// terminal state, e.g. after a crash mid-upload-verification.
+	recordingJobWorker := service.NewRecordingJobWorker(recordingJobRepo, recordingService, time.Duration(cfg.RecordingStorage.JobPollIntervalSeconds)*time.Second)
+	recordingJobStop := make(chan struct{})
+	go recordingJobWorker.Run(recordingJobStop)
+
+	// Background worker that revokes access keys past their TTL but never
+	// explicitly revoked.
+	accessKeyWorker := accesskey.NewWorker(accessKeyService, time.Duration(cfg.AccessKey.SweepIntervalSeconds)*time.Second)
+	accessKeyStop := make(chan struct{})
+	go accessKeyWorker.Run(accessKeyStop)
+
+	// Background worker that POSTs queued webhook deliveries, retrying
+	// failures with backoff until they're abandoned (see internal/webhook).
+	webhookWorker := webhook.NewWorker(webhookRepo, webhookDeliveryRepo, time.Duration(cfg.Webhook.PollIntervalSeconds)*time.Second)
+	webhookStop := make(chan struct{})
+	go webhookWorker.Run(webhookStop)
+
+	// Select the malware scanner: disabled entirely (NoopScanner, always
+	// clean) unless ScannerConfig.Mode opts into sync/async scanning with a
+	// ClamAV address configured.
+	var mediaScanner scanner.Scanner = scanner.NoopScanner{}
+	if cfg.Scanner.Mode != "off" && cfg.Scanner.ClamAVAddress != "" {
+		mediaScanner = scanner.NewClamAVScanner(cfg.Scanner.ClamAVAddress)
+	}
+	securityEventRepo := repository.NewSecurityEventRepository(db)
+
+	// Background worker that scans Gallery rows uploaded under async
+	// scanning and quarantines any that turn out infected.
+	screenshotScanStop := make(chan struct{})
+	if cfg.Scanner.Mode == "async" {
+		screenshotScanWorker := service.NewScreenshotScanWorker(galleryRepo, securityEventRepo, fileStore, mediaScanner, time.Duration(cfg.Scanner.PollIntervalSeconds)*time.Second)
+		go screenshotScanWorker.Run(screenshotScanStop)
+	}
+
+	// Background worker that expires meeting rooms left idle past
+	// MeetingConfig.IdleExpiryMinutes with no join.
+	meetingJanitor := service.NewMeetingJanitor(meetingRepo, time.Duration(cfg.Meeting.IdleExpiryMinutes)*time.Minute, time.Duration(cfg.Meeting.JanitorIntervalSeconds)*time.Second)
+	meetingJanitorStop := make(chan struct{})
+	go meetingJanitor.Run(meetingJanitorStop)
 
 	// Initialize handlers
 	authHandler := handler.NewAuthHandler(authService, driverService, cfg)
-	meetingHandler := handler.NewMeetingHandler(meetingService)
+	oidcHandler := handler.NewOIDCHandler(authService, cfg)
+	meetingHandler := handler.NewMeetingHandler(meetingService, meetingACLService, agoraClient)
 	agoraHandler := handler.NewAgoraHandler(agoraClient, recordingService)
 	emailHandler := handler.NewEmailHandler(&cfg.Email)
-	mediaHandler := handler.NewMediaHandler(s3Client, galleryRepo, meetingRepo, cfg)
-	driverHandler := handler.NewDriverHandler(driverService)
+	notificationHandler := handler.NewNotificationHandler(notificationService)
+	mediaHandler := handler.NewMediaHandler(fileStore, galleryRepo, meetingRepo, s3Client, uploadRepo, s3Gateway, shareService, mediaScanner, cfg.Scanner.Mode, securityEventRepo)
+	driverHandler := handler.NewDriverHandler(driverService, authService, uiAuthService, verificationService, courierDispatcher, cfg, s3Gateway)
 	loadHandler := handler.NewLoadHandler(loadService)
+	courierHandler := handler.NewCourierHandler(courierService)
+	accessKeyHandler := handler.NewAccessKeyHandler(accessKeyService, galleryRepo)
+	webhookHandler := handler.NewWebhookHandler(webhookService)
+	permissionHandler := handler.NewPermissionHandler(policyService)
+	clientLogHandler := handler.NewClientLogHandler(clientLogRepo)
 
 	// Setup Gin router
 	r := gin.Default()
@@ -78,6 +262,27 @@ func main() {
 		c.JSON(200, gin.H{"status": "ok"})
 	})
 
+	// Serve the local filestore backend's signed URLs, if enabled.
+	if localFileHandler != nil {
+		r.GET("/files/:token", localFileHandler.ServeFile)
+	}
+
+	// pkg/s3gateway's read-only S3-shaped API, authenticated by its own
+	// SigV4 Authorization header rather than a session/API token - kept
+	// outside the /api/v1 versioning so external S3 clients can point at a
+	// stable https://api.securedlinq/s3/... endpoint.
+	s3GatewayRoutes := r.Group("/s3")
+	{
+		s3GatewayRoutes.GET("", mediaHandler.ListObjectsV2)
+		s3GatewayRoutes.GET("/:key", mediaHandler.GetObject)
+		s3GatewayRoutes.HEAD("/:key", mediaHandler.HeadObject)
+	}
+
+	// Public, unauthenticated media-share resolution - kept outside
+	// /api/v1 versioning like /files and /s3 above, since recipients are
+	// never logged-in users.
+	r.GET("/s/:token", mediaHandler.ResolveMediaShare)
+
 	// Debug route to list all routes (only in debug mode)
 	if cfg.Server.GinMode == "debug" {
 		r.GET("/debug/routes", func(c *gin.Context) {
@@ -89,117 +294,410 @@ func main() {
 		})
 	}
 
-	// API routes
-	api := r.Group("/api")
+	deps := routeDeps{
+		cfg:                 cfg,
+		authService:         authService,
+		authHandler:         authHandler,
+		oidcHandler:         oidcHandler,
+		meetingHandler:      meetingHandler,
+		agoraHandler:        agoraHandler,
+		emailHandler:        emailHandler,
+		notificationHandler: notificationHandler,
+		mediaHandler:        mediaHandler,
+		driverHandler:       driverHandler,
+		loadHandler:         loadHandler,
+		courierHandler:      courierHandler,
+		accessKeyHandler:    accessKeyHandler,
+		webhookHandler:      webhookHandler,
+		permissionHandler:   permissionHandler,
+		policyService:       policyService,
+		clientLogHandler:    clientLogHandler,
+		idempotencyKeyRepo:  idempotencyKeyRepo,
+	}
+
+	// v1 is mounted both at its explicit path and, unversioned, at /api for
+	// existing clients that predate the v1/v2 split; both are tagged v1 via
+	// X-API-Version/Deprecation/Sunset headers so those clients can tell
+	// they're on the deprecated surface.
+	registerV1Routes(r.Group("/api/v1", middleware.APIVersionMiddleware(middleware.APIVersionV1)), deps)
+	registerV1Routes(r.Group("/api", middleware.APIVersionMiddleware(middleware.APIVersionV1)), deps)
+
+	registerV2Routes(r.Group("/api/v2", middleware.APIVersionMiddleware(middleware.APIVersionV2)), deps)
+
+	srv := &http.Server{
+		Addr:    ":" + cfg.Server.Port,
+		Handler: r,
+	}
+
+	// Start server
+	go func() {
+		log.Printf("Server starting on port %s", cfg.Server.Port)
+		if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Fatalf("Failed to start server: %v", err)
+		}
+	}()
+
+	// Graceful shutdown
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
+	<-quit
+	log.Println("Shutting down server...")
+
+	close(courierStop)
+	close(recordingJobStop)
+	close(accessKeyStop)
+	close(webhookStop)
+	close(meetingJanitorStop)
+	if cfg.Scanner.Mode == "async" {
+		close(screenshotScanStop)
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), time.Duration(cfg.Server.ShutdownTimeoutSeconds)*time.Second)
+	defer cancel()
+
+	// Stop in-flight HTTP requests from draining past the deadline before
+	// touching any of the server's dependencies.
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		log.Printf("Server shutdown did not complete cleanly: %v", err)
+	}
+
+	// Force-stop any Agora cloud recordings still running so they don't keep
+	// burning credit after the process exits; each stop is persisted as a
+	// RecordingJob so RecordingJobWorker can resume it on the next boot if it
+	// doesn't finish in time.
+	for _, err := range recordingService.StopAllActive() {
+		log.Printf("failed to stop active recording during shutdown: %v", err)
+	}
+
+	if sqlDB, err := db.DB(); err != nil {
+		log.Printf("failed to get underlying sql.DB for shutdown: %v", err)
+	} else if err := sqlDB.Close(); err != nil {
+		log.Printf("failed to close database connection: %v", err)
+	}
+
+	log.Println("Shutdown complete")
+}
+
+// recordingStorageBackends builds the agora.StorageBackend list for
+// cfg.RecordingStorage: the primary backend first, followed by the
+// failover backend if one is configured. Construction errors (an unknown
+// vendor name) are logged and that backend is skipped rather than
+// failing startup, since recording is a secondary feature of the app.
+func recordingStorageBackends(cfg config.RecordingStorageConfig) []agora.StorageBackend {
+	backends := make([]agora.StorageBackend, 0, 2)
+
+	primary, err := agora.NewBackendFromVendor(cfg.Primary.Vendor, recordingBackendConfig(cfg.Primary))
+	if err != nil {
+		log.Printf("Warning: Failed to configure primary recording storage backend: %v", err)
+	} else {
+		backends = append(backends, primary)
+	}
+
+	if cfg.Failover != nil {
+		failover, err := agora.NewBackendFromVendor(cfg.Failover.Vendor, recordingBackendConfig(*cfg.Failover))
+		if err != nil {
+			log.Printf("Warning: Failed to configure failover recording storage backend: %v", err)
+		} else {
+			backends = append(backends, failover)
+		}
+	}
+
+	return backends
+}
+
+func recordingBackendConfig(rb config.RecordingBackendConfig) agora.BackendConfig {
+	return agora.BackendConfig{
+		Label:          rb.Vendor,
+		Region:         rb.Region,
+		AWSRegion:      rb.AWSRegion,
+		Bucket:         rb.Bucket,
+		AccessKey:      rb.AccessKey,
+		SecretKey:      rb.SecretKey,
+		FileNamePrefix: rb.FileNamePrefix,
+		SSE: agora.SSEConfig{
+			Mode:        agora.SSEMode(rb.SSEMode),
+			KMSKeyARN:   rb.SSEKMSKeyARN,
+			CustomerKey: rb.SSECustomerKey,
+		},
+	}
+}
+
+// routeDeps bundles the handlers/services route registration needs, so
+// registerV1Routes and registerV2Routes can share one argument list instead
+// of each growing its own long parameter list as the API surface grows.
+type routeDeps struct {
+	cfg                 *config.Config
+	authService         *service.AuthService
+	authHandler         *handler.AuthHandler
+	oidcHandler         *handler.OIDCHandler
+	meetingHandler      *handler.MeetingHandler
+	agoraHandler        *handler.AgoraHandler
+	emailHandler        *handler.EmailHandler
+	notificationHandler *handler.NotificationHandler
+	mediaHandler        *handler.MediaHandler
+	driverHandler       *handler.DriverHandler
+	loadHandler         *handler.LoadHandler
+	courierHandler      *handler.CourierHandler
+	accessKeyHandler    *handler.AccessKeyHandler
+	webhookHandler      *handler.WebhookHandler
+	permissionHandler   *handler.PermissionHandler
+	policyService       *service.PolicyService
+	clientLogHandler    *handler.ClientLogHandler
+	idempotencyKeyRepo  *repository.IdempotencyKeyRepository
+}
+
+// registerV1Routes mounts the original, pre-versioning API surface under rg.
+// It is called for both /api/v1 and the unversioned /api alias kept for
+// clients that predate the split.
+func registerV1Routes(rg *gin.RouterGroup, d routeDeps) {
+	// Auth routes (public)
+	auth := rg.Group("/auth")
+	{
+		auth.POST("/login", d.authHandler.Login) // Admin login
+		auth.POST("/logout", d.authHandler.Logout)
+		auth.GET("/validate", d.authHandler.ValidateSession)
+
+		// Driver auth routes
+		auth.POST("/driver/register", d.authHandler.DriverRegister)
+		auth.POST("/driver/login", d.authHandler.DriverLogin)
+
+		// SSO routes, one "provider" per cfg.OAuth.Providers entry (e.g.
+		// /auth/oidc/google/login)
+		auth.GET("/oidc/:provider/login", d.oidcHandler.Login)
+		auth.GET("/oidc/:provider/callback", d.oidcHandler.Callback)
+	}
+
+	// Email verification (public - reached by clicking an emailed link)
+	rg.GET("/verify-email", d.driverHandler.VerifyEmail)
+
+	// Agora routes (public - for video calls)
+	agoraRoutes := rg.Group("/agora")
 	{
-		// Auth routes (public)
-		auth := api.Group("/auth")
+		agoraRoutes.POST("/token", d.agoraHandler.GenerateToken)
+
+		// start/stop are non-idempotent under retry (a flaky mobile network
+		// can double-charge recording minutes), so an Idempotency-Key header
+		// short-circuits a repeat with the original response instead of
+		// starting/stopping the job twice. Scoped by client IP since this
+		// group has no session.
+		recording := agoraRoutes.Group("/recording")
+		recording.Use(middleware.IdempotencyMiddleware(d.idempotencyKeyRepo))
 		{
-			auth.POST("/login", authHandler.Login) // Admin login
-			auth.POST("/logout", authHandler.Logout)
-			auth.GET("/validate", authHandler.ValidateSession)
+			recording.POST("/start", d.agoraHandler.StartRecording)
+			recording.POST("/stop", d.agoraHandler.StopRecording)
+			recording.GET("/query", d.agoraHandler.QueryRecording)
+			recording.POST("/jobs/:jobId/resume", d.agoraHandler.ResumeRecording)
+		}
+	}
+
+	// Backend routes (machine-to-machine, HMAC-authenticated dispatch systems)
+	backendRoutes := rg.Group("/backend")
+	backendRoutes.Use(middleware.BackendHMACMiddleware(&d.cfg.Backend))
+	{
+		backendRoutes.POST("/meetings", d.meetingHandler.CreateBackendMeeting)
+		backendRoutes.POST("/meetings/end", d.meetingHandler.EndBackendMeeting)
+	}
+
+	// Meeting routes (for authenticated users - admins, dispatchers, and
+	// drivers). GetMeetingByRoomID/EndMeeting additionally go through
+	// MeetingACLService.CheckAccess inside the handler, which does the
+	// finer-grained per-room resolution (assigned driver or invited
+	// participant) that a generic ScopeResolver can't express without
+	// also knowing about invites.
+	meetings := rg.Group("/meetings")
+	meetings.Use(middleware.AuthMiddleware(d.authService))
+	{
+		// GetOrCreateMeetingRoom is naturally idempotent per load_id, but a
+		// retried request still re-runs the lookup/insert race under load;
+		// an Idempotency-Key header lets a retrying client skip straight to
+		// the cached response instead.
+		meetings.POST("", middleware.IdempotencyMiddleware(d.idempotencyKeyRepo), d.meetingHandler.CreateMeeting)
+		meetings.GET("", middleware.RequirePermission(d.policyService, middleware.PermMeetingsJoin), d.meetingHandler.GetMeetingByRoomID)
+		meetings.DELETE("", middleware.RequirePermission(d.policyService, middleware.PermMeetingsEnd), d.meetingHandler.EndMeeting)
+		meetings.POST("/invite", middleware.RequirePermission(d.policyService, middleware.PermMeetingsInvite), d.meetingHandler.InviteParticipant)
+		// JoinMeeting redeems a join token minted by GetMeetingByRoomID and is
+		// itself gated only on room/token state, not a fresh ACL check.
+		meetings.POST("/:roomId/join", middleware.RequirePermission(d.policyService, middleware.PermMeetingsJoin), d.meetingHandler.JoinMeeting)
+	}
 
-			// Driver auth routes
-			auth.POST("/driver/register", authHandler.DriverRegister)
-			auth.POST("/driver/login", authHandler.DriverLogin)
+	// Protected routes (require auth)
+	protected := rg.Group("")
+	protected.Use(middleware.AuthMiddleware(d.authService))
+	{
+		// Notification routes (admin and dispatcher)
+		notifications := protected.Group("/notifications")
+		notifications.Use(middleware.RequirePermission(d.policyService, middleware.PermMeetingsInvite))
+		{
+			// A retried send under an Idempotency-Key replays the queued
+			// result instead of double-sending the invite email/SMS/push.
+			notifications.POST("/meeting-invite", middleware.IdempotencyMiddleware(d.idempotencyKeyRepo), d.notificationHandler.SendMeetingInvite)
 		}
 
-		// Agora routes (public - for video calls)
-		agoraRoutes := api.Group("/agora")
+		// Media routes (admin only)
+		media := protected.Group("/media")
+		media.Use(middleware.RequirePermission(d.policyService, middleware.PermLoadsWrite))
 		{
-			agoraRoutes.POST("/token", agoraHandler.GenerateToken)
+			media.GET("", d.mediaHandler.GetLoadMedia)
+			media.POST("/screenshot", d.mediaHandler.SaveScreenshot)
+			media.GET("/screenshots", d.mediaHandler.GetScreenshotsByLoad)
+			media.GET("/signed-url", d.mediaHandler.GetSignedURL)
+			media.POST("/upload-stream", d.mediaHandler.UploadStream)
+			media.POST("/share", d.mediaHandler.CreateMediaShare)
+			media.DELETE("/share/:token", d.mediaHandler.RevokeMediaShare)
+		}
+	}
 
-			recording := agoraRoutes.Group("/recording")
-			{
-				recording.POST("/start", agoraHandler.StartRecording)
-				recording.POST("/stop", agoraHandler.StopRecording)
-				recording.GET("/query", agoraHandler.QueryRecording)
-			}
+	// Driver API token routes (admin or the owning driver). The
+	// accesskeys.manage permission is granted role-wide to drivers, but
+	// handler.OwnDriverScope confines a non-admin session to the :id in
+	// the URL - the concrete resource-scoped check the RBAC ticket asked
+	// for.
+	driverTokens := rg.Group("/drivers")
+	driverTokens.Use(middleware.AuthMiddleware(d.authService))
+	driverTokens.Use(middleware.RequirePermission(d.policyService, middleware.PermAccessKeysManage, handler.OwnDriverScope))
+	{
+		driverTokens.POST("/:id/tokens", d.driverHandler.CreateToken)
+		driverTokens.DELETE("/:id/tokens/:tokenId", d.driverHandler.DeleteToken)
+
+		// Direct-to-S3 access key management (admin or the owning driver)
+		driverTokens.GET("/:id/access-keys", d.accessKeyHandler.GetKeys)
+		driverTokens.POST("/:id/access-keys", d.accessKeyHandler.IssueKey)
+		driverTokens.DELETE("/:id/access-keys/:keyId", d.accessKeyHandler.DeleteKey)
+		driverTokens.POST("/:id/access-keys/:keyId/rotate", d.accessKeyHandler.RotateKey)
+	}
+
+	// Access key presign/confirm routes (public - authenticated by the
+	// access key's own keyId/secret, not a session, since the whole point
+	// is letting the mobile app upload without one)
+	accessKeys := rg.Group("/access-keys")
+	{
+		accessKeys.POST("/presign", d.accessKeyHandler.PresignUpload)
+		accessKeys.POST("/confirm-upload", d.accessKeyHandler.ConfirmUpload)
+	}
+
+	// Client-side log ingestion (optionally authenticated - a driver's app
+	// can fail before login completes, so OptionalAuthMiddleware tags the
+	// entry with a session when one exists instead of requiring one).
+	clientLogs := rg.Group("/client")
+	clientLogs.Use(middleware.OptionalAuthMiddleware(d.authService))
+	{
+		clientLogs.POST("/logs", d.clientLogHandler.SubmitLog)
+	}
+
+	// Admin/dispatcher routes. Each subgroup used to sit behind one
+	// blanket AdminOnlyMiddleware; it's now a RequirePermission call per
+	// resource, so a role (like the new dispatcher one) can be granted
+	// just the subset it needs instead of all-or-nothing admin access.
+	// PermissionHandler lets admins edit those grants at runtime.
+	admin := rg.Group("/admin")
+	admin.Use(middleware.AuthMiddleware(d.authService))
+	{
+		// Drivers management (admin only - dispatchers coordinate loads
+		// and meetings, not driver accounts)
+		drivers := admin.Group("/drivers")
+		drivers.Use(middleware.RequirePermission(d.policyService, middleware.PermUsersManage))
+		{
+			drivers.GET("", d.driverHandler.GetAllDrivers)
+			drivers.POST("/bulk", d.driverHandler.BulkDriverAction)
+			drivers.GET("/:id", d.driverHandler.GetDriverByID)
+			drivers.POST("/:id/deactivate", d.driverHandler.DeactivateDriver)
+			drivers.POST("/:id/activate", d.driverHandler.ActivateDriver)
+			drivers.DELETE("/:id", d.driverHandler.DeleteDriver)
+			drivers.POST("/:id/restore", d.driverHandler.RestoreDriver)
+			drivers.POST("/:id/reset-password", d.driverHandler.ResetPassword)
+			drivers.POST("/:id/send-verification-email", d.driverHandler.SendVerificationEmail)
+			drivers.POST("/:id/s3-keys", d.driverHandler.IssueS3Keys)
 		}
 
-		// Meeting routes (for authenticated users - admins and drivers)
-		meetings := api.Group("/meetings")
-		meetings.Use(middleware.AuthMiddleware(authService))
+		// Role permission grants (admin only)
+		permissions := admin.Group("/roles")
+		permissions.Use(middleware.RequirePermission(d.policyService, middleware.PermUsersManage))
 		{
-			meetings.POST("", meetingHandler.CreateMeeting)
-			meetings.GET("", meetingHandler.GetMeetingByRoomID)
-			meetings.DELETE("", meetingHandler.EndMeeting)
+			permissions.GET("/:role/permissions", d.permissionHandler.GetRolePermissions)
+			permissions.POST("/:role/permissions", d.permissionHandler.GrantPermission)
+			permissions.DELETE("/:role/permissions/:permission", d.permissionHandler.RevokePermission)
 		}
 
-		// Protected routes (require auth)
-		protected := api.Group("")
-		protected.Use(middleware.AuthMiddleware(authService))
+		// Email diagnostics (admin only)
+		adminEmail := admin.Group("/email")
+		adminEmail.Use(middleware.RequirePermission(d.policyService, middleware.PermSystemDiagnostics))
 		{
-			// Email routes (admin only)
-			email := protected.Group("/email")
-			email.Use(middleware.AdminOnlyMiddleware())
-			{
-				email.POST("/send-meeting-link", emailHandler.SendMeetingLink)
-			}
+			adminEmail.POST("/test", d.emailHandler.TestSMTP)
+		}
 
-			// Media routes (admin only)
-			media := protected.Group("/media")
-			media.Use(middleware.AdminOnlyMiddleware())
-			{
-				media.GET("", mediaHandler.GetLoadMedia)
-				media.POST("/screenshot", mediaHandler.SaveScreenshot)
-				media.GET("/screenshots", mediaHandler.GetScreenshotsByLoad)
-				media.GET("/signed-url", mediaHandler.GetSignedURL)
-			}
+		// Courier queue inspection (admin and dispatcher)
+		courierAdmin := admin.Group("/courier")
+		courierAdmin.Use(middleware.RequirePermission(d.policyService, middleware.PermCourierRead))
+		{
+			courierAdmin.GET("/messages", d.courierHandler.GetMessages)
 		}
 
-		// Admin-only routes
-		admin := api.Group("/admin")
-		admin.Use(middleware.AuthMiddleware(authService))
-		admin.Use(middleware.AdminOnlyMiddleware())
+		// Client log triage (admin and dispatcher)
+		clientLogsAdmin := admin.Group("/client-logs")
+		clientLogsAdmin.Use(middleware.RequirePermission(d.policyService, middleware.PermClientLogsRead))
 		{
-			// Drivers management
-			drivers := admin.Group("/drivers")
-			{
-				drivers.GET("", driverHandler.GetAllDrivers)
-				drivers.GET("/:id", driverHandler.GetDriverByID)
-				drivers.POST("/:id/deactivate", driverHandler.DeactivateDriver)
-				drivers.POST("/:id/activate", driverHandler.ActivateDriver)
-			}
+			clientLogsAdmin.GET("", d.clientLogHandler.GetClientLogs)
+		}
 
-			// Loads management
-			loads := admin.Group("/loads")
-			{
-				loads.POST("", loadHandler.CreateLoad)
-				loads.GET("", loadHandler.GetAllLoads)
-				loads.GET("/by-status", loadHandler.GetLoadsByStatus)
-				loads.GET("/:id", loadHandler.GetLoadByID)
-				loads.POST("/:id/assign", loadHandler.AssignDriver)
-				loads.POST("/:id/start-meeting", loadHandler.StartMeeting)
-				loads.DELETE("/:id", loadHandler.DeleteLoad)
-			}
+		// Webhook subscription management (admin only)
+		webhooks := admin.Group("/webhooks")
+		webhooks.Use(middleware.RequirePermission(d.policyService, middleware.PermWebhooksManage))
+		{
+			webhooks.POST("", d.webhookHandler.RegisterWebhook)
+			webhooks.GET("", d.webhookHandler.GetWebhooks)
+			webhooks.PUT("/:id", d.webhookHandler.UpdateWebhook)
+			webhooks.DELETE("/:id", d.webhookHandler.DeleteWebhook)
+			webhooks.GET("/:id/deliveries", d.webhookHandler.GetDeliveries)
 		}
 
-		// Driver-only routes
-		driver := api.Group("/driver")
-		driver.Use(middleware.AuthMiddleware(authService))
-		driver.Use(middleware.DriverOnlyMiddleware())
+		// Loads management (admin and dispatcher, split between read,
+		// write, and assign so dispatchers can triage/assign loads
+		// without being able to create or delete them)
+		loads := admin.Group("/loads")
 		{
-			// Driver's loads
-			driver.GET("/loads", loadHandler.GetDriverLoads)
-			driver.GET("/loads/:id", loadHandler.GetLoadByID)
-			driver.POST("/loads/:id/complete", loadHandler.MarkCompleted)
-			driver.PUT("/loads/:id/status", loadHandler.UpdateLoadStatus)
+			loads.POST("", middleware.RequirePermission(d.policyService, middleware.PermLoadsWrite), d.loadHandler.CreateLoad)
+			loads.GET("", middleware.RequirePermission(d.policyService, middleware.PermLoadsRead), d.loadHandler.GetAllLoads)
+			loads.GET("/by-status", middleware.RequirePermission(d.policyService, middleware.PermLoadsRead), d.loadHandler.GetLoadsByStatus)
+			loads.GET("/:id", middleware.RequirePermission(d.policyService, middleware.PermLoadsRead), d.loadHandler.GetLoadByID)
+			loads.POST("/:id/assign", middleware.RequirePermission(d.policyService, middleware.PermLoadsAssign), d.loadHandler.AssignDriver)
+			loads.POST("/:id/start-meeting", middleware.RequirePermission(d.policyService, middleware.PermMeetingsRecord), d.loadHandler.StartMeeting)
+			loads.DELETE("/:id", middleware.RequirePermission(d.policyService, middleware.PermLoadsWrite), d.loadHandler.DeleteLoad)
 		}
 	}
 
-	// Graceful shutdown
-	quit := make(chan os.Signal, 1)
-	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
+	// Driver-only routes
+	driver := rg.Group("/driver")
+	driver.Use(middleware.AuthMiddleware(d.authService))
+	driver.Use(middleware.DriverOnlyMiddleware())
+	{
+		// Driver's loads
+		driver.GET("/loads", d.loadHandler.GetDriverLoads)
+		driver.GET("/loads/:id", d.loadHandler.GetLoadByID)
+		driver.POST("/loads/:id/complete", d.loadHandler.MarkCompleted)
+		driver.PUT("/loads/:id/status", d.loadHandler.UpdateLoadStatus)
+	}
+}
 
-	go func() {
-		<-quit
-		log.Println("Shutting down server...")
-	}()
+// registerV2Routes mounts the v2 API surface under rg. v1 handlers keep
+// working unchanged by delegating to the same services as v2; so far only
+// the endpoints called out for v2 treatment (cursor-paginated driver
+// listing, consistently-cased meeting room objects) have a v2 counterpart.
+// Everything else is reachable at the same paths with the v1 response shape
+// until it gets its own v2 version.
+func registerV2Routes(rg *gin.RouterGroup, d routeDeps) {
+	meetings := rg.Group("/meetings")
+	meetings.Use(middleware.AuthMiddleware(d.authService))
+	{
+		meetings.GET("", middleware.RequirePermission(d.policyService, middleware.PermMeetingsJoin), d.meetingHandler.GetMeetingByRoomIDV2)
+	}
 
-	// Start server
-	log.Printf("Server starting on port %s", cfg.Server.Port)
-	if err := r.Run(":" + cfg.Server.Port); err != nil {
-		log.Fatalf("Failed to start server: %v", err)
+	admin := rg.Group("/admin")
+	admin.Use(middleware.AuthMiddleware(d.authService))
+	admin.Use(middleware.RequirePermission(d.policyService, middleware.PermUsersManage))
+	{
+		drivers := admin.Group("/drivers")
+		{
+			drivers.GET("", d.driverHandler.GetAllDriversV2)
+		}
 	}
 }
@@ -0,0 +1,104 @@
+// Command migrate applies, reverts, and inspects versioned SQL migrations
+// against the SecuredLinQ database. It is the operator-facing counterpart
+// to database.EnsureSchema, which the API server uses to refuse booting
+// when migrations are pending and AUTO_MIGRATE is not set.
+//
+// Usage:
+//
+//	migrate up
+//	migrate down [N]
+//	migrate status
+//	migrate force VERSION
+package main
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+
+	"github.com/securedlinq/backend/internal/config"
+	"github.com/securedlinq/backend/internal/database"
+)
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(1)
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatalf("Failed to load config: %v", err)
+	}
+
+	db, err := database.Connect(&cfg.Database)
+	if err != nil {
+		log.Fatalf("Failed to connect to database: %v", err)
+	}
+
+	sqlDB, err := db.DB()
+	if err != nil {
+		log.Fatalf("Failed to get underlying SQL DB: %v", err)
+	}
+
+	runner, err := database.NewRunner(sqlDB)
+	if err != nil {
+		log.Fatalf("Failed to load migrations: %v", err)
+	}
+
+	switch os.Args[1] {
+	case "up":
+		if err := runner.Up(0); err != nil {
+			log.Fatalf("Migration failed: %v", err)
+		}
+		log.Println("Migrations applied successfully")
+
+	case "down":
+		n := 1
+		if len(os.Args) > 2 {
+			n, err = strconv.Atoi(os.Args[2])
+			if err != nil {
+				log.Fatalf("Invalid N for `down`: %v", err)
+			}
+		}
+		if err := runner.Down(n); err != nil {
+			log.Fatalf("Rollback failed: %v", err)
+		}
+		log.Println("Rollback completed successfully")
+
+	case "status":
+		statuses, err := runner.StatusAll()
+		if err != nil {
+			log.Fatalf("Failed to read migration status: %v", err)
+		}
+		for _, s := range statuses {
+			state := "pending"
+			if s.Applied {
+				state = "applied"
+			}
+			fmt.Printf("%04d_%s\t%s\n", s.Version, s.Name, state)
+		}
+
+	case "force":
+		if len(os.Args) < 3 {
+			log.Fatal("`force` requires a VERSION argument")
+		}
+		version, err := strconv.Atoi(os.Args[2])
+		if err != nil {
+			log.Fatalf("Invalid VERSION for `force`: %v", err)
+		}
+		if err := runner.Force(version); err != nil {
+			log.Fatalf("Force failed: %v", err)
+		}
+		log.Printf("Migration version forced to %d", version)
+
+	default:
+		usage()
+		os.Exit(1)
+	}
+}
+
+func usage() {
+	fmt.Println("Usage: migrate up|down [N]|status|force VERSION")
+}
@@ -3,19 +3,74 @@ package config
 import (
 	"os"
 	"strconv"
+	"strings"
 
 	"github.com/joho/godotenv"
+	"github.com/securedlinq/backend/pkg/agora"
 )
 
 // Config holds all configuration for the application
 type Config struct {
-	Server   ServerConfig
-	Database DatabaseConfig
-	Session  SessionConfig
-	Admin    AdminConfig
-	Agora    AgoraConfig
-	AWS      AWSConfig
-	Email    EmailConfig
+	Server           ServerConfig
+	Database         DatabaseConfig
+	Session          SessionConfig
+	Redis            RedisConfig
+	Backend          BackendConfig
+	Admin            AdminConfig
+	Dispatcher       DispatcherConfig
+	Agora            AgoraConfig
+	AWS              AWSConfig
+	RecordingStorage RecordingStorageConfig
+	Email            EmailConfig
+	UIAuth           UIAuthConfig
+	Courier          CourierConfig
+	OAuth            OAuthConfig
+	AccessKey        AccessKeyConfig
+	FileStore        FileStoreConfig
+	Scanner          ScannerConfig
+	Notification     NotificationConfig
+	Webhook          WebhookConfig
+	Meeting          MeetingConfig
+}
+
+// MeetingConfig configures the meeting room lifecycle (see
+// service.MeetingService and service.MeetingJanitor).
+type MeetingConfig struct {
+	// IdleExpiryMinutes auto-ends an active room if UpdateLastJoined hasn't
+	// been called in this long, so an abandoned room doesn't sit "active"
+	// indefinitely.
+	IdleExpiryMinutes int
+	// MaxParticipants caps how many concurrently-joined participants
+	// (meeting_join_sessions rows with no left_at) a room accepts before
+	// POST /meetings/:roomId/join is rejected.
+	MaxParticipants int
+	// JoinTokenTTLSeconds is how long a JoinToken minted for
+	// GetOrCreateMeetingRoom/GetMeetingRoomByRoomID stays valid before
+	// MeetingHandler.JoinMeeting rejects it, distinct from (and much
+	// shorter than) the Agora RTC token it's exchanged for.
+	JoinTokenTTLSeconds int
+	// JanitorIntervalSeconds is how often MeetingJanitor scans for idle or
+	// join-token-expired rooms to sweep.
+	JanitorIntervalSeconds int
+}
+
+// NotificationConfig configures the notification package's SMS and push
+// channels (email reuses the existing Email/Courier config). A channel is
+// only registered with the courier worker, and so only actually attempted,
+// when its credentials are non-empty.
+type NotificationConfig struct {
+	TwilioAccountSID string
+	TwilioAuthToken  string
+	TwilioFromNumber string
+	FCMServerKey     string
+}
+
+// WebhookConfig configures the outbound webhook delivery worker (see
+// internal/webhook).
+type WebhookConfig struct {
+	// PollIntervalSeconds is how often the worker checks for due
+	// webhook_deliveries rows.
+	PollIntervalSeconds int
 }
 
 type EmailConfig struct {
@@ -24,6 +79,27 @@ type EmailConfig struct {
 	SenderEmail string
 	SenderName  string
 	AppPassword string
+	// VerificationSecret signs driver email-verification tokens (see
+	// service.VerificationService); it is independent of AppPassword so it
+	// can be rotated without breaking SMTP auth.
+	VerificationSecret string
+	// SMTPConnectionSecurity selects how the SMTP connection is secured:
+	// "none" (plain TCP, for local capture servers like mailtest),
+	// "starttls" (opportunistic STARTTLS; the production default), or
+	// "tls" (implicit TLS from the first byte, e.g. port 465).
+	SMTPConnectionSecurity string
+	// SkipServerCertificateVerification disables certificate validation on
+	// the TLS connection. Only meant for test fixtures (mailtest) that
+	// can't present a trusted certificate; never enable it in production.
+	SkipServerCertificateVerification bool
+}
+
+// CourierConfig configures the queued mail dispatcher: where the
+// subject/body templates live on disk and how often the background worker
+// polls for due messages.
+type CourierConfig struct {
+	TemplatesRoot string
+	PollInterval  int // seconds
 }
 
 type ServerConfig struct {
@@ -31,6 +107,10 @@ type ServerConfig struct {
 	GinMode     string
 	FrontendURL string
 	BaseURL     string
+	// ShutdownTimeoutSeconds bounds how long graceful shutdown waits for
+	// in-flight requests to drain via http.Server.Shutdown before main()
+	// gives up and exits anyway.
+	ShutdownTimeoutSeconds int
 }
 
 type DatabaseConfig struct {
@@ -39,6 +119,10 @@ type DatabaseConfig struct {
 	User     string
 	Password string
 	Name     string
+	// AutoMigrate allows the server to apply pending migrations on startup
+	// instead of refusing to boot. Prefer running `migrate up` explicitly in
+	// production; this is mainly a convenience for local/dev environments.
+	AutoMigrate bool
 }
 
 type SessionConfig struct {
@@ -46,11 +130,71 @@ type SessionConfig struct {
 	MaxAge   int
 	Secure   bool
 	SameSite string
+	// Backend selects the SessionStore implementation: "memory" (database-backed,
+	// default) or "redis" (shared cache, required for clustered deployments).
+	Backend string
+}
+
+// RedisConfig holds connection settings for the Redis-backed session store.
+type RedisConfig struct {
+	Addr      string
+	Password  string
+	DB        int
+	PoolSize  int
+	KeyPrefix string
+}
+
+// BackendConfig holds shared secrets for machine-to-machine requests from
+// trusted dispatch systems, keyed by the X-Backend-ID header they present.
+type BackendConfig struct {
+	Secrets map[string]string
+}
+
+// UIAuthConfig configures the user-interactive auth stages required before
+// sensitive driver operations, modeled on Matrix's /auth flow negotiation.
+type UIAuthConfig struct {
+	Flows [][]string
 }
 
 type AdminConfig struct {
 	Username string
 	Password string
+	// TOTPSecret enables the x.login.totp UI-auth stage when set, validated
+	// against the single admin account configured above.
+	TOTPSecret string
+}
+
+// DispatcherConfig holds the single config-based dispatcher account,
+// mirroring AdminConfig. Username is empty by default, which disables the
+// dispatcher login provider entirely until an operator configures one.
+type DispatcherConfig struct {
+	Username string
+	Password string
+}
+
+// OAuthConfig configures the OpenID Connect / OAuth2 SSO providers
+// available for the "oauth:<name>" auth provider (see
+// service.AuthProviderRegistry), keyed by provider name.
+type OAuthConfig struct {
+	Providers map[string]OIDCProviderConfig
+}
+
+// OIDCProviderConfig holds the per-provider settings needed to drive an
+// OIDC authorization-code flow, configured via OIDC_<NAME>_* env vars so an
+// operator can wire Google/Okta/Azure AD without code changes.
+type OIDCProviderConfig struct {
+	ClientID     string
+	ClientSecret string
+	AuthURL      string
+	TokenURL     string
+	UserInfoURL  string
+	RedirectURL  string
+	Scopes       []string
+	// RoleClaim names the userinfo claim consulted for role mapping (e.g.
+	// "hd" or "groups"); empty means every login provisions a driver.
+	RoleClaim string
+	// RoleMapping maps a RoleClaim value to a local role name.
+	RoleMapping map[string]string
 }
 
 type AgoraConfig struct {
@@ -66,6 +210,87 @@ type AWSConfig struct {
 	S3BucketName    string
 }
 
+// FileStoreConfig selects and configures the filestore.FileStore
+// implementation backing MediaHandler's screenshot/gallery uploads.
+type FileStoreConfig struct {
+	// Backend selects the FileStore implementation: "s3" (AWS S3 or an
+	// S3-compatible endpoint, default) or "local" (disk-backed, for
+	// deployments with no object storage available).
+	Backend string
+	// Endpoint overrides the default AWS S3 endpoint for S3-compatible
+	// providers (MinIO, Wasabi, Backblaze B2). Empty talks to AWS S3 itself.
+	Endpoint string
+	// ForcePathStyle addresses objects as "endpoint/bucket/key", which most
+	// S3-compatible providers require instead of AWS's virtual-hosted style.
+	ForcePathStyle bool
+	// LocalDir is the directory local-backend objects are written under.
+	LocalDir string
+	// LocalSecret HMAC-signs the tokens local-backend signed URLs embed.
+	LocalSecret string
+}
+
+// RecordingStorageConfig configures where Agora cloud recordings are
+// written. Failover is nil unless a failover vendor is explicitly
+// configured, in which case it is passed to agora.Client after the primary;
+// the client records to it only once it is chosen with SetStorageBackend.
+type RecordingStorageConfig struct {
+	Primary  RecordingBackendConfig
+	Failover *RecordingBackendConfig
+	// RetentionPolicy is the tag value applied to recordings via
+	// agora.ApplyLifecycleTag so a bucket lifecycle rule can expire them.
+	// Empty disables tagging.
+	RetentionPolicy string
+	// JobPollIntervalSeconds is how often RecordingJobWorker scans for
+	// stuck recording jobs to resume.
+	JobPollIntervalSeconds int
+}
+
+// AccessKeyConfig configures the short-lived driver access keys used to
+// presign direct-to-S3 upload URLs.
+type AccessKeyConfig struct {
+	// TTLSeconds is how long an issued access key remains valid before the
+	// sweep worker revokes it, even if never used.
+	TTLSeconds int
+	// PresignExpirySeconds is how long a single presigned upload URL minted
+	// from a key stays valid.
+	PresignExpirySeconds int
+	// SweepIntervalSeconds is how often the sweep worker scans for expired,
+	// not-yet-revoked keys.
+	SweepIntervalSeconds int
+}
+
+// ScannerConfig configures the pkg/scanner malware scan MediaHandler runs
+// on a screenshot before it reaches the gallery.
+type ScannerConfig struct {
+	// Mode is "off" (no scanning - scan_status is always "skipped"), "sync"
+	// (scan before upload, rejecting an infected file with 422), or "async"
+	// (upload immediately, scan in the background, and quarantine if the
+	// scan later comes back infected).
+	Mode string
+	// ClamAVAddress is the ClamAV daemon's TCP address (host:port), used
+	// for the INSTREAM protocol. Required unless Mode is "off".
+	ClamAVAddress string
+	// PollIntervalSeconds is how often the async scan worker checks for
+	// gallery rows still awaiting a scan.
+	PollIntervalSeconds int
+}
+
+// RecordingBackendConfig configures a single agora.StorageBackend.
+type RecordingBackendConfig struct {
+	// Vendor is one of "s3", "ibm-cos", "aliyun-oss", "gcs", "azure-blob",
+	// matching agora.NewBackendFromVendor.
+	Vendor         string
+	Region         int
+	AWSRegion      string
+	Bucket         string
+	AccessKey      string
+	SecretKey      string
+	FileNamePrefix []string
+	SSEMode        string
+	SSEKMSKeyARN   string
+	SSECustomerKey string
+}
+
 // Load loads configuration from environment variables
 func Load() (*Config, error) {
 	// Load .env file if it exists
@@ -73,29 +298,63 @@ func Load() (*Config, error) {
 
 	maxAge, _ := strconv.Atoi(getEnv("SESSION_MAX_AGE", "86400"))
 	secure, _ := strconv.ParseBool(getEnv("SESSION_SECURE", "false"))
+	redisDB, _ := strconv.Atoi(getEnv("REDIS_DB", "0"))
+	redisPoolSize, _ := strconv.Atoi(getEnv("REDIS_POOL_SIZE", "10"))
+	courierPollInterval, _ := strconv.Atoi(getEnv("COURIER_POLL_INTERVAL_SECONDS", "30"))
+	webhookPollInterval, _ := strconv.Atoi(getEnv("WEBHOOK_POLL_INTERVAL_SECONDS", "30"))
+	skipSMTPCertVerification, _ := strconv.ParseBool(getEnv("SMTP_SKIP_SERVER_CERT_VERIFICATION", "false"))
+	autoMigrate, _ := strconv.ParseBool(getEnv("AUTO_MIGRATE", "false"))
+	accessKeyTTL, _ := strconv.Atoi(getEnv("ACCESS_KEY_TTL_SECONDS", "3600"))
+	accessKeyPresignExpiry, _ := strconv.Atoi(getEnv("ACCESS_KEY_PRESIGN_EXPIRY_SECONDS", "900"))
+	accessKeySweepInterval, _ := strconv.Atoi(getEnv("ACCESS_KEY_SWEEP_INTERVAL_SECONDS", "300"))
+	fileStoreForcePathStyle, _ := strconv.ParseBool(getEnv("FILESTORE_S3_FORCE_PATH_STYLE", "false"))
+	scannerPollInterval, _ := strconv.Atoi(getEnv("SCANNER_POLL_INTERVAL_SECONDS", "30"))
+	meetingIdleExpiryMinutes, _ := strconv.Atoi(getEnv("MEETING_IDLE_EXPIRY_MINUTES", "30"))
+	meetingMaxParticipants, _ := strconv.Atoi(getEnv("MEETING_MAX_PARTICIPANTS", "4"))
+	meetingJoinTokenTTL, _ := strconv.Atoi(getEnv("MEETING_JOIN_TOKEN_TTL_SECONDS", "300"))
+	meetingJanitorInterval, _ := strconv.Atoi(getEnv("MEETING_JANITOR_INTERVAL_SECONDS", "60"))
+	shutdownTimeout, _ := strconv.Atoi(getEnv("SERVER_SHUTDOWN_TIMEOUT_SECONDS", "30"))
 
 	config := &Config{
 		Server: ServerConfig{
-			Port:        getEnv("PORT", "8080"),
-			GinMode:     getEnv("GIN_MODE", "debug"),
-			FrontendURL: getEnv("FRONTEND_URL", "http://localhost:5173"),
-			BaseURL:     getEnv("BASE_URL", "http://localhost:8080"),
+			Port:                   getEnv("PORT", "8080"),
+			GinMode:                getEnv("GIN_MODE", "debug"),
+			FrontendURL:            getEnv("FRONTEND_URL", "http://localhost:5173"),
+			BaseURL:                getEnv("BASE_URL", "http://localhost:8080"),
+			ShutdownTimeoutSeconds: shutdownTimeout,
 		},
 		Database: DatabaseConfig{
-			Host:     getEnv("DB_HOST", "localhost"),
-			Port:     getEnv("DB_PORT", "3306"),
-			User:     getEnv("DB_USER", "root"),
-			Password: getEnv("DB_PASSWORD", ""),
-			Name:     getEnv("DB_NAME", "uatsecuredlinq_db"),
+			Host:        getEnv("DB_HOST", "localhost"),
+			Port:        getEnv("DB_PORT", "3306"),
+			User:        getEnv("DB_USER", "root"),
+			Password:    getEnv("DB_PASSWORD", ""),
+			Name:        getEnv("DB_NAME", "uatsecuredlinq_db"),
+			AutoMigrate: autoMigrate,
 		},
 		Session: SessionConfig{
 			MaxAge:   maxAge,
 			Secure:   secure,
 			SameSite: getEnv("SESSION_SAME_SITE", "lax"),
+			Backend:  getEnv("SESSION_BACKEND", "memory"),
+		},
+		Redis: RedisConfig{
+			Addr:      getEnv("REDIS_ADDR", "localhost:6379"),
+			Password:  getEnv("REDIS_PASSWORD", ""),
+			DB:        redisDB,
+			PoolSize:  redisPoolSize,
+			KeyPrefix: getEnv("REDIS_SESSION_PREFIX", "sess:"),
+		},
+		Backend: BackendConfig{
+			Secrets: parseBackendSecrets(getEnv("BACKEND_SECRETS", "")),
 		},
 		Admin: AdminConfig{
-			Username: getEnv("ADMIN_USERNAME", "admin"),
-			Password: getEnv("ADMIN_PASSWORD", "secure123"),
+			Username:   getEnv("ADMIN_USERNAME", "admin"),
+			Password:   getEnv("ADMIN_PASSWORD", "secure123"),
+			TOTPSecret: getEnv("ADMIN_TOTP_SECRET", ""),
+		},
+		Dispatcher: DispatcherConfig{
+			Username: getEnv("DISPATCHER_USERNAME", ""),
+			Password: getEnv("DISPATCHER_PASSWORD", ""),
 		},
 		Agora: AgoraConfig{
 			AppID:          getEnv("AGORA_APP_ID", ""),
@@ -108,21 +367,210 @@ func Load() (*Config, error) {
 			Region:          getEnv("AWS_REGION", "us-east-1"),
 			S3BucketName:    getEnv("AWS_S3_BUCKET_NAME", ""),
 		},
+		FileStore: FileStoreConfig{
+			Backend:        getEnv("FILESTORE_BACKEND", "s3"),
+			Endpoint:       getEnv("FILESTORE_S3_ENDPOINT", ""),
+			ForcePathStyle: fileStoreForcePathStyle,
+			LocalDir:       getEnv("FILESTORE_LOCAL_DIR", "./filestore-data"),
+			LocalSecret:    getEnv("FILESTORE_LOCAL_SECRET", ""),
+		},
 		Email: EmailConfig{
-			SMTPHost:    getEnv("SMTP_HOST", "smtp.gmail.com"),
-			SMTPPort:    getEnv("SMTP_PORT", "587"),
-			SenderEmail: getEnv("SENDER_EMAIL", ""),
-			SenderName:  getEnv("SENDER_NAME", "SecuredLinQ"),
-			AppPassword: getEnv("EMAIL_APP_PASSWORD", ""),
+			SMTPHost:                          getEnv("SMTP_HOST", "smtp.gmail.com"),
+			SMTPPort:                          getEnv("SMTP_PORT", "587"),
+			SenderEmail:                       getEnv("SENDER_EMAIL", ""),
+			SenderName:                        getEnv("SENDER_NAME", "SecuredLinQ"),
+			AppPassword:                       getEnv("EMAIL_APP_PASSWORD", ""),
+			VerificationSecret:                getEnv("EMAIL_VERIFICATION_SECRET", ""),
+			SMTPConnectionSecurity:            getEnv("SMTP_CONNECTION_SECURITY", "starttls"),
+			SkipServerCertificateVerification: skipSMTPCertVerification,
+		},
+		UIAuth: UIAuthConfig{
+			Flows: parseUIAuthFlows(getEnv("UIAUTH_FLOWS", "m.login.password")),
+		},
+		Courier: CourierConfig{
+			TemplatesRoot: getEnv("COURIER_TEMPLATES_ROOT", "internal/courier/templates/files"),
+			PollInterval:  courierPollInterval,
+		},
+		OAuth: OAuthConfig{
+			Providers: parseOIDCProviders(getEnv("OIDC_PROVIDERS", "")),
+		},
+		AccessKey: AccessKeyConfig{
+			TTLSeconds:           accessKeyTTL,
+			PresignExpirySeconds: accessKeyPresignExpiry,
+			SweepIntervalSeconds: accessKeySweepInterval,
+		},
+		Scanner: ScannerConfig{
+			Mode:                getEnv("SCANNER_MODE", "off"),
+			ClamAVAddress:       getEnv("SCANNER_CLAMAV_ADDRESS", ""),
+			PollIntervalSeconds: scannerPollInterval,
+		},
+		Notification: NotificationConfig{
+			TwilioAccountSID: getEnv("TWILIO_ACCOUNT_SID", ""),
+			TwilioAuthToken:  getEnv("TWILIO_AUTH_TOKEN", ""),
+			TwilioFromNumber: getEnv("TWILIO_FROM_NUMBER", ""),
+			FCMServerKey:     getEnv("FCM_SERVER_KEY", ""),
+		},
+		Webhook: WebhookConfig{
+			PollIntervalSeconds: webhookPollInterval,
+		},
+		Meeting: MeetingConfig{
+			IdleExpiryMinutes:      meetingIdleExpiryMinutes,
+			MaxParticipants:        meetingMaxParticipants,
+			JoinTokenTTLSeconds:    meetingJoinTokenTTL,
+			JanitorIntervalSeconds: meetingJanitorInterval,
 		},
 	}
 
+	config.RecordingStorage = loadRecordingStorageConfig(config.AWS)
+
 	return config, nil
 }
 
+// loadRecordingStorageConfig builds the Agora cloud-recording storage
+// config. It defaults the primary backend to AWS S3 using the existing
+// AWS_* settings so deployments that haven't adopted RECORDING_STORAGE_*
+// keep working unchanged; a failover backend is only configured when
+// RECORDING_STORAGE_FAILOVER_VENDOR is set.
+func loadRecordingStorageConfig(aws AWSConfig) RecordingStorageConfig {
+	primary := RecordingBackendConfig{
+		Vendor:         getEnv("RECORDING_STORAGE_VENDOR", "s3"),
+		AWSRegion:      getEnv("RECORDING_STORAGE_REGION", aws.Region),
+		Bucket:         getEnv("RECORDING_STORAGE_BUCKET", aws.S3BucketName),
+		AccessKey:      getEnv("RECORDING_STORAGE_ACCESS_KEY", aws.AccessKeyID),
+		SecretKey:      getEnv("RECORDING_STORAGE_SECRET_KEY", aws.SecretAccessKey),
+		FileNamePrefix: []string{"recordings"},
+		SSEMode:        getEnv("RECORDING_STORAGE_SSE_MODE", "none"),
+		SSEKMSKeyARN:   getEnv("RECORDING_STORAGE_SSE_KMS_KEY_ARN", ""),
+		SSECustomerKey: getEnv("RECORDING_STORAGE_SSE_CUSTOMER_KEY", ""),
+	}
+	if primary.Vendor == "s3" {
+		primary.Region = agora.AWSRegionCode(primary.AWSRegion)
+	}
+
+	jobPollInterval, _ := strconv.Atoi(getEnv("RECORDING_JOB_POLL_INTERVAL_SECONDS", "60"))
+
+	cfg := RecordingStorageConfig{
+		Primary:                primary,
+		RetentionPolicy:        getEnv("RECORDING_STORAGE_RETENTION_POLICY", ""),
+		JobPollIntervalSeconds: jobPollInterval,
+	}
+
+	if failoverVendor := getEnv("RECORDING_STORAGE_FAILOVER_VENDOR", ""); failoverVendor != "" {
+		failover := RecordingBackendConfig{
+			Vendor:         failoverVendor,
+			AWSRegion:      getEnv("RECORDING_STORAGE_FAILOVER_REGION", ""),
+			Bucket:         getEnv("RECORDING_STORAGE_FAILOVER_BUCKET", ""),
+			AccessKey:      getEnv("RECORDING_STORAGE_FAILOVER_ACCESS_KEY", ""),
+			SecretKey:      getEnv("RECORDING_STORAGE_FAILOVER_SECRET_KEY", ""),
+			FileNamePrefix: []string{"recordings"},
+			SSEMode:        getEnv("RECORDING_STORAGE_FAILOVER_SSE_MODE", "none"),
+			SSEKMSKeyARN:   getEnv("RECORDING_STORAGE_FAILOVER_SSE_KMS_KEY_ARN", ""),
+			SSECustomerKey: getEnv("RECORDING_STORAGE_FAILOVER_SSE_CUSTOMER_KEY", ""),
+		}
+		if failover.Vendor == "s3" {
+			failover.Region = agora.AWSRegionCode(failover.AWSRegion)
+		}
+		cfg.Failover = &failover
+	}
+
+	return cfg
+}
+
 func getEnv(key, defaultValue string) string {
 	if value := os.Getenv(key); value != "" {
 		return value
 	}
 	return defaultValue
 }
+
+// parseBackendSecrets parses a "BACKEND_SECRETS" value of the form
+// "id1:secret1,id2:secret2" into a lookup keyed by backend ID.
+func parseBackendSecrets(raw string) map[string]string {
+	secrets := make(map[string]string)
+	if raw == "" {
+		return secrets
+	}
+
+	for _, pair := range strings.Split(raw, ",") {
+		parts := strings.SplitN(strings.TrimSpace(pair), ":", 2)
+		if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			continue
+		}
+		secrets[parts[0]] = parts[1]
+	}
+
+	return secrets
+}
+
+// parseUIAuthFlows parses a "UIAUTH_FLOWS" value of the form
+// "stage1,stage2;stage1,stage3" - flows separated by ";", stages within a
+// flow by "," - into the list of stage sequences the UIAuthService will
+// accept as satisfying its challenge.
+func parseUIAuthFlows(raw string) [][]string {
+	if raw == "" {
+		return nil
+	}
+
+	var flows [][]string
+	for _, flow := range strings.Split(raw, ";") {
+		var stages []string
+		for _, stage := range strings.Split(flow, ",") {
+			stage = strings.TrimSpace(stage)
+			if stage != "" {
+				stages = append(stages, stage)
+			}
+		}
+		if len(stages) > 0 {
+			flows = append(flows, stages)
+		}
+	}
+
+	return flows
+}
+
+// parseOIDCProviders parses an "OIDC_PROVIDERS" value of the form
+// "google,okta" into a config for each named provider, reading the
+// provider's settings from "OIDC_<NAME>_*" env vars (e.g.
+// OIDC_GOOGLE_CLIENT_ID, or OIDC_GOOGLE_ROLE_CLAIM="hd" with
+// OIDC_GOOGLE_ROLE_MAPPING="ourcompany.com:admin"). Names not
+// listed here have no provider registered at startup.
+func parseOIDCProviders(raw string) map[string]OIDCProviderConfig {
+	providers := make(map[string]OIDCProviderConfig)
+	if raw == "" {
+		return providers
+	}
+
+	for _, name := range strings.Split(raw, ",") {
+		name = strings.TrimSpace(strings.ToLower(name))
+		if name == "" {
+			continue
+		}
+
+		prefix := "OIDC_" + strings.ToUpper(name) + "_"
+		providers[name] = OIDCProviderConfig{
+			ClientID:     getEnv(prefix+"CLIENT_ID", ""),
+			ClientSecret: getEnv(prefix+"CLIENT_SECRET", ""),
+			AuthURL:      getEnv(prefix+"AUTH_URL", ""),
+			TokenURL:     getEnv(prefix+"TOKEN_URL", ""),
+			UserInfoURL:  getEnv(prefix+"USERINFO_URL", ""),
+			RedirectURL:  getEnv(prefix+"REDIRECT_URL", ""),
+			Scopes:       parseScopes(getEnv(prefix+"SCOPES", "openid,email,profile")),
+			RoleClaim:    getEnv(prefix+"ROLE_CLAIM", ""),
+			RoleMapping:  parseBackendSecrets(getEnv(prefix+"ROLE_MAPPING", "")),
+		}
+	}
+
+	return providers
+}
+
+// parseScopes parses a comma-separated OAuth2 scope list.
+func parseScopes(raw string) []string {
+	var scopes []string
+	for _, scope := range strings.Split(raw, ",") {
+		scope = strings.TrimSpace(scope)
+		if scope != "" {
+			scopes = append(scopes, scope)
+		}
+	}
+	return scopes
+}
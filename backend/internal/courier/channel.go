@@ -0,0 +1,8 @@
+package courier
+
+// Channel delivers a rendered message to a single recipient. SMTP, Twilio
+// SMS and FCM push implement it; adding another kind of delivery only
+// means implementing Channel, not touching the Dispatcher or Worker.
+type Channel interface {
+	Send(to, subject, body string) error
+}
@@ -0,0 +1,85 @@
+package courier
+
+import (
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	"github.com/securedlinq/backend/internal/courier/templates"
+	"github.com/securedlinq/backend/internal/models"
+	"github.com/securedlinq/backend/internal/repository"
+)
+
+// maxAttempts is how many delivery attempts a message gets (across all
+// retries) before the worker marks it abandoned.
+const maxAttempts = 8
+
+// VerificationEmailData is the template data for the "email_verification"
+// message type.
+type VerificationEmailData struct {
+	DriverName       string
+	VerificationLink string
+}
+
+// Dispatcher renders a message template and persists it as a queued
+// courier_messages row. The Worker delivers it asynchronously, so callers
+// only pay for template rendering and a single insert, not the SMTP
+// round-trip.
+type Dispatcher struct {
+	repo     *repository.CourierRepository
+	renderer *templates.Renderer
+}
+
+// NewDispatcher creates a new Dispatcher.
+func NewDispatcher(repo *repository.CourierRepository, renderer *templates.Renderer) *Dispatcher {
+	return &Dispatcher{repo: repo, renderer: renderer}
+}
+
+// EnqueueVerificationEmail renders the email_verification template and
+// queues it for delivery over the "smtp" channel.
+func (d *Dispatcher) EnqueueVerificationEmail(to string, data VerificationEmailData) (*models.CourierMessage, error) {
+	subject, body, err := d.renderer.Render("email_verification", data)
+	if err != nil {
+		return nil, err
+	}
+
+	msg := &models.CourierMessage{
+		Channel:       "smtp",
+		Recipient:     to,
+		Subject:       subject,
+		Body:          body,
+		Status:        "queued",
+		NextAttemptAt: time.Now(),
+	}
+
+	if err := d.repo.Create(msg); err != nil {
+		return nil, err
+	}
+
+	return msg, nil
+}
+
+// newBackoff returns the exponential backoff schedule used between
+// retries, capped at 12h so a failing provider doesn't get hammered
+// forever; maxAttempts (not MaxElapsedTime) is what bounds total retries.
+// Randomization is disabled, since the library applies it after the cap
+// and would otherwise push delays past 12h.
+func newBackoff() *backoff.ExponentialBackOff {
+	b := backoff.NewExponentialBackOff()
+	b.InitialInterval = 30 * time.Second
+	b.MaxInterval = 12 * time.Hour
+	b.MaxElapsedTime = 0
+	b.RandomizationFactor = 0
+	return b
+}
+
+// backoffFor returns the retry delay for the given attempt count. Since no
+// per-message backoff state is kept between worker ticks, it replays a
+// fresh schedule attempts times to land on the right interval.
+func backoffFor(attempts int) time.Duration {
+	b := newBackoff()
+	var d time.Duration
+	for i := 0; i < attempts; i++ {
+		d = b.NextBackOff()
+	}
+	return d
+}
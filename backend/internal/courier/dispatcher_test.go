@@ -0,0 +1,23 @@
+package courier
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestBackoffForGrowsAndCaps checks the exponential backoff schedule used
+// between retries: delays should grow with attempt count and never exceed
+// the 12h cap, regardless of how many attempts are replayed.
+func TestBackoffForGrowsAndCaps(t *testing.T) {
+	first := backoffFor(1)
+	third := backoffFor(3)
+	many := backoffFor(50)
+
+	assert.Greater(t, third, first)
+	assert.LessOrEqual(t, many, 12*time.Hour)
+}
+
+// Note: Dispatcher/Worker tests beyond backoffFor require a database (for
+// CourierRepository) and are not included in this package.
@@ -0,0 +1,18 @@
+package courier
+
+import "github.com/securedlinq/backend/internal/mailer"
+
+// SMTPChannel delivers courier messages over SMTP using the mailer package.
+type SMTPChannel struct {
+	client *mailer.Client
+}
+
+// NewSMTPChannel creates a new SMTPChannel.
+func NewSMTPChannel(client *mailer.Client) *SMTPChannel {
+	return &SMTPChannel{client: client}
+}
+
+// Send implements Channel.
+func (c *SMTPChannel) Send(to, subject, body string) error {
+	return c.client.Send(to, subject, body)
+}
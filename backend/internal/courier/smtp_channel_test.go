@@ -0,0 +1,108 @@
+package courier
+
+import (
+	"io"
+	"net"
+	"testing"
+
+	"github.com/securedlinq/backend/internal/config"
+	"github.com/securedlinq/backend/internal/courier/templates"
+	"github.com/securedlinq/backend/internal/mailer"
+	"github.com/securedlinq/backend/internal/mailtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testMailerConfig points a mailer.Client at srv over plain TCP, the way a
+// local Inbucket-style capture target is exercised outside production
+// (which keeps STARTTLS).
+func testMailerConfig(t *testing.T, srv *mailtest.Server) *config.EmailConfig {
+	host, port, err := net.SplitHostPort(srv.Addr())
+	require.NoError(t, err)
+
+	return &config.EmailConfig{
+		SMTPHost:               host,
+		SMTPPort:               port,
+		SenderEmail:            "sender@example.com",
+		SenderName:             "SecuredLinQ",
+		SMTPConnectionSecurity: "none",
+	}
+}
+
+// meetingLinkData is a local stand-in for the "meeting_link" template's
+// data shape - the message type itself is no longer dispatched in
+// production (see notification.MeetingInviteData), but these tests keep
+// exercising the Renderer/SMTPChannel combo against its template files.
+type meetingLinkData struct {
+	DriverName  string
+	LoadNumber  string
+	MeetingLink string
+}
+
+func TestEmailContentGeneration(t *testing.T) {
+	srv, err := mailtest.Start()
+	require.NoError(t, err)
+	defer srv.Close()
+
+	renderer := templates.NewRenderer("templates/files")
+	channel := NewSMTPChannel(mailer.NewClient(testMailerConfig(t, srv)))
+
+	subject, body, err := renderer.Render("meeting_link", meetingLinkData{
+		DriverName:  "John Doe",
+		LoadNumber:  "LOAD-001",
+		MeetingLink: "http://localhost:5173/join/load_1_abc123",
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, channel.Send("driver@example.com", subject, body))
+
+	messages := srv.ListMailbox("driver@example.com")
+	require.Len(t, messages, 1)
+
+	msg := messages[0]
+	assert.Equal(t, "driver@example.com", msg.To)
+	assert.Equal(t, subject, msg.Header.Get("Subject"))
+
+	html, err := io.ReadAll(msg.Body)
+	require.NoError(t, err)
+	assert.Contains(t, string(html), "John Doe")
+	assert.Contains(t, string(html), "LOAD-001")
+	assert.Contains(t, string(html), "http://localhost:5173/join/load_1_abc123")
+	assert.Contains(t, string(html), "Join Video Call")
+}
+
+func TestEmailSubjectGeneration(t *testing.T) {
+	srv, err := mailtest.Start()
+	require.NoError(t, err)
+	defer srv.Close()
+
+	renderer := templates.NewRenderer("templates/files")
+	channel := NewSMTPChannel(mailer.NewClient(testMailerConfig(t, srv)))
+
+	tests := []struct {
+		name       string
+		loadNumber string
+	}{
+		{name: "Standard load number", loadNumber: "LOAD-001"},
+		{name: "Long load number", loadNumber: "LOAD-2024-001-ABC"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			subject, body, err := renderer.Render("meeting_link", meetingLinkData{
+				DriverName:  "John Doe",
+				LoadNumber:  tt.loadNumber,
+				MeetingLink: "http://localhost:5173/join/test",
+			})
+			require.NoError(t, err)
+
+			require.NoError(t, channel.Send("driver@example.com", subject, body))
+
+			messages := srv.ListMailbox("driver@example.com")
+			require.NotEmpty(t, messages)
+
+			last := messages[len(messages)-1]
+			assert.Contains(t, last.Header.Get("Subject"), tt.loadNumber)
+		})
+	}
+}
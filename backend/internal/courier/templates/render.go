@@ -0,0 +1,115 @@
+// Package templates renders courier message content from html/template
+// files on disk, so editing an email's wording doesn't require a rebuild.
+// If a file isn't found at Root (e.g. a deployment forgot to mount the
+// templates directory), rendering falls back to the copy embedded in this
+// binary at build time, so a misconfigured COURIER_TEMPLATES_ROOT degrades
+// rather than breaking delivery outright.
+package templates
+
+import (
+	"bytes"
+	"embed"
+	"errors"
+	"fmt"
+	htmltemplate "html/template"
+	"io/fs"
+	"os"
+	"path/filepath"
+	texttemplate "text/template"
+)
+
+//go:embed files
+var defaultFS embed.FS
+
+// Renderer loads templates rooted at Root. Each named template is up to
+// three files: "<name>.subject.tmpl" and "<name>.body.html.tmpl" (plain
+// text subject and HTML body, used by Render for email), and
+// "<name>.text.tmpl" (a plain-text rendering, used by RenderText for
+// channels with no concept of HTML, like SMS and push).
+type Renderer struct {
+	Root string
+}
+
+// NewRenderer creates a new Renderer rooted at root.
+func NewRenderer(root string) *Renderer {
+	return &Renderer{Root: root}
+}
+
+// Render executes the named template pair against data, returning the
+// rendered subject and HTML body.
+func (r *Renderer) Render(name string, data any) (subject, body string, err error) {
+	subject, err = r.renderHTML(name+".subject.tmpl", data)
+	if err != nil {
+		return "", "", fmt.Errorf("render %s subject: %w", name, err)
+	}
+
+	body, err = r.renderHTML(name+".body.html.tmpl", data)
+	if err != nil {
+		return "", "", fmt.Errorf("render %s body: %w", name, err)
+	}
+
+	return subject, body, nil
+}
+
+// RenderText executes the named plain-text template against data. Used by
+// notification.Service for the SMS and push channels, which have no HTML
+// body and no separate subject line.
+func (r *Renderer) RenderText(name string, data any) (string, error) {
+	body, err := r.renderText(name+".text.tmpl", data)
+	if err != nil {
+		return "", fmt.Errorf("render %s text: %w", name, err)
+	}
+	return body, nil
+}
+
+func (r *Renderer) renderHTML(filename string, data any) (string, error) {
+	raw, err := r.readFile(filename)
+	if err != nil {
+		return "", err
+	}
+
+	tmpl, err := htmltemplate.New(filename).Parse(string(raw))
+	if err != nil {
+		return "", err
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+
+	return buf.String(), nil
+}
+
+func (r *Renderer) renderText(filename string, data any) (string, error) {
+	raw, err := r.readFile(filename)
+	if err != nil {
+		return "", err
+	}
+
+	tmpl, err := texttemplate.New(filename).Parse(string(raw))
+	if err != nil {
+		return "", err
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+
+	return buf.String(), nil
+}
+
+// readFile reads filename from Root on disk, falling back to the copy
+// embedded under files/ if Root doesn't have it.
+func (r *Renderer) readFile(filename string) ([]byte, error) {
+	raw, err := os.ReadFile(filepath.Join(r.Root, filename))
+	if err == nil {
+		return raw, nil
+	}
+	if !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+
+	return defaultFS.ReadFile(filepath.Join("files", filename))
+}
@@ -0,0 +1,29 @@
+package templates
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRenderMeetingLink(t *testing.T) {
+	r := NewRenderer("files")
+
+	type meetingLinkData struct {
+		DriverName  string
+		LoadNumber  string
+		MeetingLink string
+	}
+
+	subject, body, err := r.Render("meeting_link", meetingLinkData{
+		DriverName:  "John Doe",
+		LoadNumber:  "LOAD-001",
+		MeetingLink: "http://localhost:5173/join/load_1_abc123",
+	})
+	require.NoError(t, err)
+
+	assert.Contains(t, subject, "LOAD-001")
+	assert.Contains(t, body, "John Doe")
+	assert.Contains(t, body, "http://localhost:5173/join/load_1_abc123")
+}
@@ -0,0 +1,91 @@
+package courier
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/securedlinq/backend/internal/models"
+	"github.com/securedlinq/backend/internal/repository"
+)
+
+// Worker periodically pulls due messages from the queue and delivers each
+// over the Channel registered for its Channel field, retrying failures
+// with exponential backoff until maxAttempts is reached.
+type Worker struct {
+	repo     *repository.CourierRepository
+	channels map[string]Channel
+	interval time.Duration
+}
+
+// NewWorker creates a new Worker delivering over a single named channel -
+// the common case, e.g. "smtp" for the existing email_verification flow.
+func NewWorker(repo *repository.CourierRepository, name string, channel Channel, interval time.Duration) *Worker {
+	return NewMultiChannelWorker(repo, map[string]Channel{name: channel}, interval)
+}
+
+// NewMultiChannelWorker creates a new Worker delivering over several named
+// channels from one outbox, e.g. notification.Service's "smtp"/"sms"/"push"
+// sharing the same retry/backoff queue.
+func NewMultiChannelWorker(repo *repository.CourierRepository, channels map[string]Channel, interval time.Duration) *Worker {
+	return &Worker{repo: repo, channels: channels, interval: interval}
+}
+
+// Run blocks, polling for due messages every interval until stop is closed.
+func (w *Worker) Run(stop <-chan struct{}) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			w.tick()
+		}
+	}
+}
+
+func (w *Worker) tick() {
+	messages, err := w.repo.GetDue(20)
+	if err != nil {
+		log.Printf("courier: failed to load due messages: %v", err)
+		return
+	}
+
+	for _, msg := range messages {
+		w.deliver(msg)
+	}
+}
+
+func (w *Worker) deliver(msg models.CourierMessage) {
+	channel, ok := w.channels[msg.Channel]
+	if !ok {
+		w.reschedule(msg, fmt.Errorf("no channel registered for %q", msg.Channel))
+		return
+	}
+
+	if err := w.repo.MarkSending(msg.ID); err != nil {
+		log.Printf("courier: failed to mark message %d sending: %v", msg.ID, err)
+		return
+	}
+
+	if err := channel.Send(msg.Recipient, msg.Subject, msg.Body); err != nil {
+		w.reschedule(msg, err)
+		return
+	}
+
+	if err := w.repo.MarkSent(msg.ID); err != nil {
+		log.Printf("courier: failed to mark message %d sent: %v", msg.ID, err)
+	}
+}
+
+func (w *Worker) reschedule(msg models.CourierMessage, sendErr error) {
+	attempts := msg.Attempts + 1
+	abandoned := attempts >= maxAttempts
+	next := time.Now().Add(backoffFor(attempts))
+
+	if err := w.repo.Reschedule(msg.ID, attempts, next, sendErr.Error(), abandoned); err != nil {
+		log.Printf("courier: failed to reschedule message %d: %v", msg.ID, err)
+	}
+}
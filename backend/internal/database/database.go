@@ -6,7 +6,6 @@ import (
 	"time"
 
 	"github.com/securedlinq/backend/internal/config"
-	"github.com/securedlinq/backend/internal/models"
 	"gorm.io/driver/mysql"
 	"gorm.io/gorm"
 	"gorm.io/gorm/logger"
@@ -47,39 +46,47 @@ func Connect(cfg *config.DatabaseConfig) (*gorm.DB, error) {
 	return db, nil
 }
 
-// AutoMigrate runs auto migration for all models
-func AutoMigrate(db *gorm.DB) error {
-	log.Println("Running database migrations...")
+// EnsureSchema checks the database's migration version against the embedded
+// migrations in internal/database/migrations. If migrations are pending and
+// autoMigrate is true, it applies them; otherwise it refuses to proceed so
+// that schema drift is never applied silently in production.
+func EnsureSchema(db *gorm.DB, autoMigrate bool) error {
+	sqlDB, err := db.DB()
+	if err != nil {
+		return fmt.Errorf("failed to get underlying SQL DB: %w", err)
+	}
 
-	migrator := db.Migrator()
+	runner, err := NewRunner(sqlDB)
+	if err != nil {
+		return fmt.Errorf("failed to load migrations: %w", err)
+	}
+
+	_, dirty, err := runner.Version()
+	if err != nil {
+		return err
+	}
+	if dirty {
+		return fmt.Errorf("database is marked dirty from a previous failed migration; run `migrate force VERSION` to repair it")
+	}
+
+	pending, err := runner.Pending()
+	if err != nil {
+		return err
+	}
+	if len(pending) == 0 {
+		log.Println("Database schema is up to date")
+		return nil
+	}
 
-	// Migrate tables one by one to handle errors gracefully
-	// Only these tables are used in the system:
-	// - drivers, loads, sessions, meeting_rooms, gallery
-	tables := []interface{}{
-		&models.Driver{},
-		&models.Load{},
-		&models.Session{},
-		&models.MeetingRoom{},
-		&models.Gallery{},
+	if !autoMigrate {
+		return fmt.Errorf("%d pending migration(s) found; set AUTO_MIGRATE=true or run `migrate up` before starting the server", len(pending))
 	}
 
-	for _, table := range tables {
-		// Check if table exists
-		if !migrator.HasTable(table) {
-			log.Printf("Creating table for %T", table)
-			if err := migrator.AutoMigrate(table); err != nil {
-				log.Printf("Error creating table %T: %v", table, err)
-				return err
-			}
-		} else {
-			log.Printf("Table for %T already exists, attempting to sync schema", table)
-			// For existing tables, try to migrate but don't fail on column modification errors
-			if err := migrator.AutoMigrate(table); err != nil {
-				log.Printf("Warning: Migration issue for existing table %T: %v (continuing...)", table, err)
-			}
-		}
+	log.Printf("Applying %d pending migration(s)...", len(pending))
+	if err := runner.Up(0); err != nil {
+		return err
 	}
 
+	log.Println("Database schema is up to date")
 	return nil
 }
@@ -0,0 +1,109 @@
+// Package migrations embeds the versioned SQL migration scripts for the
+// SecuredLinQ schema and exposes them as parsed, ordered Migration values.
+// Filenames follow the convention "<version>_<name>.<up|down>.sql", e.g.
+// "0001_create_drivers_table.up.sql".
+package migrations
+
+import (
+	"embed"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+//go:embed *.sql
+var files embed.FS
+
+// Migration is a single versioned schema change with its forward (Up) and
+// reverse (Down) SQL scripts.
+type Migration struct {
+	Version int
+	Name    string
+	Up      string
+	Down    string
+}
+
+// All reads every embedded .sql file, pairs up and down scripts by version,
+// and returns the migrations sorted in ascending version order.
+func All() ([]Migration, error) {
+	entries, err := files.ReadDir(".")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read embedded migrations: %w", err)
+	}
+
+	byVersion := make(map[int]*Migration)
+
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+
+		version, name, direction, err := parseFilename(entry.Name())
+		if err != nil {
+			return nil, err
+		}
+
+		content, err := files.ReadFile(entry.Name())
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", entry.Name(), err)
+		}
+
+		m, ok := byVersion[version]
+		if !ok {
+			m = &Migration{Version: version, Name: name}
+			byVersion[version] = m
+		}
+
+		switch direction {
+		case "up":
+			m.Up = string(content)
+		case "down":
+			m.Down = string(content)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if m.Up == "" {
+			return nil, fmt.Errorf("migration %04d_%s is missing an .up.sql script", m.Version, m.Name)
+		}
+		if m.Down == "" {
+			return nil, fmt.Errorf("migration %04d_%s is missing a .down.sql script", m.Version, m.Name)
+		}
+		migrations = append(migrations, *m)
+	}
+
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+
+	return migrations, nil
+}
+
+// parseFilename splits a migration filename into its version, name, and
+// direction ("up" or "down").
+func parseFilename(filename string) (version int, name string, direction string, err error) {
+	base := strings.TrimSuffix(filename, ".sql")
+
+	parts := strings.Split(base, ".")
+	if len(parts) != 2 {
+		return 0, "", "", fmt.Errorf("migration filename %q does not match <version>_<name>.<up|down>.sql", filename)
+	}
+	direction = parts[1]
+	if direction != "up" && direction != "down" {
+		return 0, "", "", fmt.Errorf("migration filename %q has unknown direction %q", filename, direction)
+	}
+
+	versionAndName := strings.SplitN(parts[0], "_", 2)
+	if len(versionAndName) != 2 {
+		return 0, "", "", fmt.Errorf("migration filename %q does not match <version>_<name>.<up|down>.sql", filename)
+	}
+
+	version, err = strconv.Atoi(versionAndName[0])
+	if err != nil {
+		return 0, "", "", fmt.Errorf("migration filename %q has a non-numeric version: %w", filename, err)
+	}
+
+	return version, versionAndName[1], direction, nil
+}
@@ -0,0 +1,213 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/securedlinq/backend/internal/database/migrations"
+)
+
+// Runner applies and reverts versioned SQL migrations against a database,
+// tracking progress in a schema_migrations table.
+type Runner struct {
+	db         *sql.DB
+	migrations []migrations.Migration
+}
+
+// Status describes a single migration's position relative to the database's
+// current version.
+type Status struct {
+	Version int
+	Name    string
+	Applied bool
+}
+
+// NewRunner loads the embedded migrations and ensures the schema_migrations
+// tracking table exists.
+func NewRunner(db *sql.DB) (*Runner, error) {
+	all, err := migrations.All()
+	if err != nil {
+		return nil, err
+	}
+
+	r := &Runner{db: db, migrations: all}
+	if err := r.ensureVersionTable(); err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+func (r *Runner) ensureVersionTable() error {
+	_, err := r.db.Exec(`CREATE TABLE IF NOT EXISTS schema_migrations (
+		version BIGINT NOT NULL PRIMARY KEY,
+		dirty BOOLEAN NOT NULL DEFAULT false
+	)`)
+	if err != nil {
+		return fmt.Errorf("failed to create schema_migrations table: %w", err)
+	}
+
+	var count int
+	if err := r.db.QueryRow(`SELECT COUNT(*) FROM schema_migrations`).Scan(&count); err != nil {
+		return fmt.Errorf("failed to read schema_migrations: %w", err)
+	}
+	if count == 0 {
+		if _, err := r.db.Exec(`INSERT INTO schema_migrations (version, dirty) VALUES (0, false)`); err != nil {
+			return fmt.Errorf("failed to seed schema_migrations: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// Version returns the database's current migration version and whether the
+// last migration attempt left it in a dirty (partially applied) state.
+func (r *Runner) Version() (version int, dirty bool, err error) {
+	err = r.db.QueryRow(`SELECT version, dirty FROM schema_migrations LIMIT 1`).Scan(&version, &dirty)
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to read migration version: %w", err)
+	}
+	return version, dirty, nil
+}
+
+// Pending returns the migrations with a version greater than the database's
+// current version, in ascending order.
+func (r *Runner) Pending() ([]migrations.Migration, error) {
+	version, _, err := r.Version()
+	if err != nil {
+		return nil, err
+	}
+
+	var pending []migrations.Migration
+	for _, m := range r.migrations {
+		if m.Version > version {
+			pending = append(pending, m)
+		}
+	}
+
+	return pending, nil
+}
+
+// Up applies up to n pending migrations in ascending version order. A
+// non-positive n applies all pending migrations.
+func (r *Runner) Up(n int) error {
+	pending, err := r.Pending()
+	if err != nil {
+		return err
+	}
+
+	if n > 0 && n < len(pending) {
+		pending = pending[:n]
+	}
+
+	for _, m := range pending {
+		if err := r.apply(m, m.Up, m.Version); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Down reverts the last n applied migrations in descending version order. A
+// non-positive n is treated as 1.
+func (r *Runner) Down(n int) error {
+	if n <= 0 {
+		n = 1
+	}
+
+	version, _, err := r.Version()
+	if err != nil {
+		return err
+	}
+
+	applied := make([]migrations.Migration, 0, len(r.migrations))
+	for _, m := range r.migrations {
+		if m.Version <= version {
+			applied = append(applied, m)
+		}
+	}
+
+	for i := len(applied) - 1; i >= 0 && n > 0; i, n = i-1, n-1 {
+		m := applied[i]
+		prev := previousVersion(r.migrations, m.Version)
+		if err := r.apply(m, m.Down, prev); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Force sets the database's recorded version directly without running any
+// migration script, and clears the dirty flag. Use after manually repairing
+// a database left dirty by a failed migration.
+func (r *Runner) Force(version int) error {
+	_, err := r.db.Exec(`UPDATE schema_migrations SET version = ?, dirty = false`, version)
+	if err != nil {
+		return fmt.Errorf("failed to force migration version: %w", err)
+	}
+	return nil
+}
+
+// StatusAll returns every known migration along with whether it is applied
+// to the current database.
+func (r *Runner) StatusAll() ([]Status, error) {
+	version, _, err := r.Version()
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]Status, 0, len(r.migrations))
+	for _, m := range r.migrations {
+		statuses = append(statuses, Status{
+			Version: m.Version,
+			Name:    m.Name,
+			Applied: m.Version <= version,
+		})
+	}
+
+	return statuses, nil
+}
+
+// apply runs script in a transaction, marking the database dirty for the
+// duration and recording resultVersion once the script commits successfully.
+func (r *Runner) apply(m migrations.Migration, script string, resultVersion int) error {
+	if _, err := r.db.Exec(`UPDATE schema_migrations SET dirty = true`); err != nil {
+		return fmt.Errorf("failed to mark schema_migrations dirty: %w", err)
+	}
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin migration %04d_%s: %w", m.Version, m.Name, err)
+	}
+
+	if _, err := tx.Exec(script); err != nil {
+		tx.Rollback()
+		return fmt.Errorf("failed to apply migration %04d_%s: %w", m.Version, m.Name, err)
+	}
+
+	if _, err := tx.Exec(`UPDATE schema_migrations SET version = ?, dirty = false`, resultVersion); err != nil {
+		tx.Rollback()
+		return fmt.Errorf("failed to record migration %04d_%s: %w", m.Version, m.Name, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit migration %04d_%s: %w", m.Version, m.Name, err)
+	}
+
+	return nil
+}
+
+// previousVersion returns the version of the migration immediately before
+// version in the ordered migration list, or 0 if version is the earliest.
+func previousVersion(all []migrations.Migration, version int) int {
+	prev := 0
+	for _, m := range all {
+		if m.Version >= version {
+			break
+		}
+		prev = m.Version
+	}
+	return prev
+}
@@ -0,0 +1,23 @@
+package events
+
+// ChannelSink fans events out over a buffered Go channel, for in-process
+// consumers (e.g. a log line per event, metrics counters) that would
+// rather range over a channel than implement Sink themselves. Publish
+// drops the event if the channel is full instead of blocking the
+// publisher - an in-process consumer falling behind shouldn't stall a
+// meeting or load request.
+type ChannelSink struct {
+	C chan Event
+}
+
+// NewChannelSink creates a ChannelSink buffering up to size events.
+func NewChannelSink(size int) *ChannelSink {
+	return &ChannelSink{C: make(chan Event, size)}
+}
+
+func (s *ChannelSink) Publish(event Event) {
+	select {
+	case s.C <- event:
+	default:
+	}
+}
@@ -0,0 +1,63 @@
+// Package events publishes structured domain events (meeting and recording
+// lifecycle, load assignment/completion) to whichever Sinks are registered
+// with the Bus, so integrations like the webhook dispatcher don't have to
+// be wired into every service that can produce an event.
+package events
+
+import "time"
+
+// Type identifies what happened. Event types are dot-namespaced
+// "<subject>.<verb>", matching the admin-facing webhook event filter
+// strings.
+type Type string
+
+const (
+	MeetingCreated   Type = "meeting.created"
+	MeetingJoined    Type = "meeting.joined"
+	MeetingEnded     Type = "meeting.ended"
+	RecordingStarted Type = "recording.started"
+	RecordingStopped Type = "recording.stopped"
+	LoadAssigned     Type = "load.assigned"
+	LoadCompleted    Type = "load.completed"
+)
+
+// Event is a single fact published to the Bus. Payload is a plain struct
+// specific to Type (see the *Payload types alongside each publisher) -
+// Sinks that serialize it (the webhook dispatcher) do so via encoding/json,
+// so field names should be exported and JSON-friendly.
+type Event struct {
+	Type       Type
+	Payload    any
+	OccurredAt time.Time
+}
+
+// Sink receives every event published to a Bus. Publish must return
+// quickly - a Sink that needs slow work (an HTTP delivery, say) should
+// queue it internally rather than doing it inline on the publisher's
+// goroutine.
+type Sink interface {
+	Publish(event Event)
+}
+
+// Bus fans a single Publish call out to every registered Sink. A nil *Bus
+// is safe to call Publish on (it's a no-op), so services can hold one
+// unconditionally without nil-checking at every call site.
+type Bus struct {
+	sinks []Sink
+}
+
+// NewBus creates a Bus delivering to the given Sinks, in order.
+func NewBus(sinks ...Sink) *Bus {
+	return &Bus{sinks: sinks}
+}
+
+// Publish fans out a new Event of the given type to every registered Sink.
+func (b *Bus) Publish(eventType Type, payload any) {
+	if b == nil {
+		return
+	}
+	event := Event{Type: eventType, Payload: payload, OccurredAt: time.Now()}
+	for _, sink := range b.sinks {
+		sink.Publish(event)
+	}
+}
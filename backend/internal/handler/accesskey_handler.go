@@ -0,0 +1,224 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/securedlinq/backend/internal/models"
+	"github.com/securedlinq/backend/internal/repository"
+	"github.com/securedlinq/backend/internal/service/accesskey"
+)
+
+// AccessKeyHandler handles driver-scoped direct-to-S3 access key HTTP requests
+type AccessKeyHandler struct {
+	accessKeyService *accesskey.Service
+	galleryRepo      *repository.GalleryRepository
+}
+
+// NewAccessKeyHandler creates a new access key handler
+func NewAccessKeyHandler(accessKeyService *accesskey.Service, galleryRepo *repository.GalleryRepository) *AccessKeyHandler {
+	return &AccessKeyHandler{accessKeyService: accessKeyService, galleryRepo: galleryRepo}
+}
+
+// IssueKeyRequest represents a request to mint a new access key
+type IssueKeyRequest struct {
+	LoadNumber string `json:"loadNumber" binding:"required"`
+}
+
+// IssueKey mints a new access key for a driver, scoped to a load's upload
+// prefix (admin or the owning driver)
+func (h *AccessKeyHandler) IssueKey(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid driver ID"})
+		return
+	}
+
+	var req IssueKeyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "loadNumber is required"})
+		return
+	}
+
+	secret, key, err := h.accessKeyService.Issue(uint(id), req.LoadNumber)
+	if err != nil {
+		status := http.StatusBadRequest
+		if errors.Is(err, accesskey.ErrLoadNotOwned) {
+			status = http.StatusForbidden
+		}
+		c.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"keyId":   key.KeyID,
+		"secret":  secret,
+		"info":    key,
+		"message": "Secret will not be shown again",
+	})
+}
+
+// DeleteKey revokes an access key (admin or the owning driver)
+func (h *AccessKeyHandler) DeleteKey(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid driver ID"})
+		return
+	}
+
+	keyDBIDStr := c.Param("keyId")
+	keyDBID, err := strconv.ParseUint(keyDBIDStr, 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid key ID"})
+		return
+	}
+
+	if err := h.accessKeyService.Revoke(uint(id), uint(keyDBID)); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Access key revoked",
+	})
+}
+
+// RotateKey revokes an access key and issues a fresh one with the same
+// scope (admin or the owning driver)
+func (h *AccessKeyHandler) RotateKey(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid driver ID"})
+		return
+	}
+
+	keyDBIDStr := c.Param("keyId")
+	keyDBID, err := strconv.ParseUint(keyDBIDStr, 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid key ID"})
+		return
+	}
+
+	secret, key, err := h.accessKeyService.Rotate(uint(id), uint(keyDBID))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"keyId":   key.KeyID,
+		"secret":  secret,
+		"info":    key,
+		"message": "Secret will not be shown again",
+	})
+}
+
+// GetKeys lists a driver's access keys (admin or the owning driver)
+func (h *AccessKeyHandler) GetKeys(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid driver ID"})
+		return
+	}
+
+	keys, err := h.accessKeyService.GetKeys(uint(id))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"keys":    keys,
+	})
+}
+
+// PresignUploadRequest represents a request to mint a presigned S3 upload
+// URL against an access key. It is authenticated by the key itself
+// (keyId/secret), not a session, since the whole point of access keys is
+// letting the mobile app upload without one.
+type PresignUploadRequest struct {
+	KeyID       string `json:"keyId" binding:"required"`
+	Secret      string `json:"secret" binding:"required"`
+	ObjectKey   string `json:"objectKey" binding:"required"`
+	ContentType string `json:"contentType" binding:"required"`
+}
+
+// PresignUpload mints a presigned S3 PUT URL scoped to the calling access
+// key's allowed prefix (public - authenticated via keyId/secret in the body)
+func (h *AccessKeyHandler) PresignUpload(c *gin.Context) {
+	var req PresignUploadRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "keyId, secret, objectKey, and contentType are required"})
+		return
+	}
+
+	url, err := h.accessKeyService.PresignUpload(req.KeyID, req.Secret, req.ObjectKey, req.ContentType)
+	if err != nil {
+		status := http.StatusBadRequest
+		if errors.Is(err, accesskey.ErrKeyNotFound) || errors.Is(err, accesskey.ErrInvalidSecret) {
+			status = http.StatusUnauthorized
+		}
+		c.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"url":     url,
+	})
+}
+
+// ConfirmUploadRequest represents a request to record a Gallery row for an
+// object the client uploaded directly to S3 with a presigned URL.
+type ConfirmUploadRequest struct {
+	KeyID     string `json:"keyId" binding:"required"`
+	ObjectKey string `json:"objectKey" binding:"required"`
+	FileName  string `json:"fileName" binding:"required"`
+}
+
+// ConfirmUpload records a Gallery row for a direct-to-S3 upload, after
+// verifying the uploaded object's key falls within the access key's
+// allowed prefix (public - authenticated via keyId in the body, the object
+// itself already required a valid presigned URL to exist)
+func (h *AccessKeyHandler) ConfirmUpload(c *gin.Context) {
+	var req ConfirmUploadRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "keyId, objectKey, and fileName are required"})
+		return
+	}
+
+	key, err := h.accessKeyService.VerifyUpload(req.KeyID, req.ObjectKey)
+	if err != nil {
+		status := http.StatusBadRequest
+		if errors.Is(err, accesskey.ErrKeyNotFound) {
+			status = http.StatusUnauthorized
+		}
+		c.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	gallery := &models.Gallery{
+		LoadID:   key.LoadID,
+		FileName: req.FileName,
+		S3Key:    req.ObjectKey,
+	}
+	if err := h.galleryRepo.Create(gallery); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"id":      gallery.ID,
+	})
+}
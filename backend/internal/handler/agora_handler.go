@@ -3,6 +3,7 @@ package handler
 import (
 	"fmt"
 	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -34,9 +35,8 @@ type TokenRequest struct {
 
 // GenerateToken generates an Agora RTC token
 func (h *AgoraHandler) GenerateToken(c *gin.Context) {
-	var req TokenRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "channelName and uid are required"})
+	req, ok := bindAndValidate[TokenRequest](c)
+	if !ok {
 		return
 	}
 
@@ -103,9 +103,8 @@ type StartRecordingRequest struct {
 
 // StartRecording starts cloud recording
 func (h *AgoraHandler) StartRecording(c *gin.Context) {
-	var req StartRecordingRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+	req, ok := bindAndValidate[StartRecordingRequest](c)
+	if !ok {
 		return
 	}
 
@@ -133,9 +132,8 @@ type StopRecordingRequest struct {
 
 // StopRecording stops cloud recording
 func (h *AgoraHandler) StopRecording(c *gin.Context) {
-	var req StopRecordingRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "resourceId, sid, channelName, and uid are required"})
+	req, ok := bindAndValidate[StopRecordingRequest](c)
+	if !ok {
 		return
 	}
 
@@ -153,6 +151,24 @@ func (h *AgoraHandler) StopRecording(c *gin.Context) {
 	c.JSON(http.StatusOK, result)
 }
 
+// ResumeRecording re-drives a recording job that didn't reach a terminal
+// state, e.g. after a crashed backend restarts.
+func (h *AgoraHandler) ResumeRecording(c *gin.Context) {
+	jobID, err := strconv.ParseUint(c.Param("jobId"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "jobId must be a number"})
+		return
+	}
+
+	result, err := h.recordingService.ResumeRecordingJob(uint(jobID))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
 // QueryRecordingRequest represents a recording query request
 type QueryRecordingRequest struct {
 	ResourceID string `json:"resourceId" binding:"required"`
@@ -43,7 +43,7 @@ type DriverRegisterRequest struct {
 	Email       string `json:"email"`
 }
 
-// Login handles admin login
+// Login handles staff login (admin or dispatcher)
 func (h *AuthHandler) Login(c *gin.Context) {
 	var req LoginRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
@@ -51,13 +51,12 @@ func (h *AuthHandler) Login(c *gin.Context) {
 		return
 	}
 
-	// Validate credentials
-	err := h.authService.ValidateAdminCredentials(req.Username, req.Password)
+	sessionInfo, err := h.attemptStaffLogin(req.Username, req.Password)
 	if err != nil {
 		// Return specific error messages
 		switch {
 		case errors.Is(err, service.ErrUserNotFound):
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Admin account not found. Please check your username."})
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Account not found. Please check your username."})
 		case errors.Is(err, service.ErrInvalidPassword):
 			c.JSON(http.StatusUnauthorized, gin.H{"error": "Incorrect password. Please try again."})
 		default:
@@ -66,13 +65,6 @@ func (h *AuthHandler) Login(c *gin.Context) {
 		return
 	}
 
-	// Create session (userID = 0 for config-based admin)
-	sessionInfo, err := h.authService.CreateSession(0, "admin")
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create session"})
-		return
-	}
-
 	// Set session cookie
 	c.SetSameSite(h.getSameSite())
 	c.SetCookie(
@@ -91,6 +83,35 @@ func (h *AuthHandler) Login(c *gin.Context) {
 	})
 }
 
+// attemptStaffLogin tries the config-based "admin" LoginProvider, then
+// "dispatcher", so this single endpoint serves both config-based staff
+// accounts without the caller needing to say which one they are. Login
+// is dispatched through the registry rather than calling
+// ValidateAdminCredentials/ValidateDispatcherCredentials directly, so this
+// handler doesn't need to know how either provider authenticates.
+func (h *AuthHandler) attemptStaffLogin(username, password string) (*service.SessionInfo, error) {
+	var lastErr error
+	for _, name := range []string{"admin", "dispatcher"} {
+		provider, err := h.authService.Providers().LoginProvider(name)
+		if err != nil {
+			continue
+		}
+
+		sessionInfo, err := provider.AttemptLogin(username, password)
+		if err == nil {
+			return sessionInfo, nil
+		}
+		if !errors.Is(err, service.ErrUserNotFound) {
+			return nil, err
+		}
+		lastErr = err
+	}
+	if lastErr == nil {
+		lastErr = service.ErrUserNotFound
+	}
+	return nil, lastErr
+}
+
 // Logout handles user logout
 func (h *AuthHandler) Logout(c *gin.Context) {
 	sessionID, err := c.Cookie(middleware.SessionCookieName)
@@ -203,8 +224,15 @@ func (h *AuthHandler) DriverLogin(c *gin.Context) {
 		return
 	}
 
-	// Validate driver credentials
-	driver, err := h.authService.ValidateDriverCredentials(req.Username, req.Password)
+	// Dispatch through the "driver" LoginProvider rather than calling
+	// ValidateDriverCredentials/CreateSession directly.
+	provider, err := h.authService.Providers().LoginProvider("driver")
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Driver login is not configured"})
+		return
+	}
+
+	sessionInfo, err := provider.AttemptLogin(req.Username, req.Password)
 	if err != nil {
 		// Return specific error messages
 		switch {
@@ -220,10 +248,9 @@ func (h *AuthHandler) DriverLogin(c *gin.Context) {
 		return
 	}
 
-	// Create session for driver
-	sessionInfo, err := h.authService.CreateSession(int(driver.ID), "driver")
+	driver, err := h.authService.GetDriverByID(sessionInfo.UserID)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create session"})
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load driver"})
 		return
 	}
 
@@ -0,0 +1,22 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// bindAndValidate binds and validates a JSON request body into a new T
+// (per its `binding` struct tags), writing a uniform RespondError envelope
+// instead of a handler-specific message when binding fails. Callers should
+// return immediately when ok is false - the response has already been
+// written.
+func bindAndValidate[T any](c *gin.Context) (T, bool) {
+	var req T
+	if err := c.ShouldBindJSON(&req); err != nil {
+		RespondError(c, http.StatusBadRequest, "invalid_request", "Request body failed validation", gin.H{"error": err.Error()})
+		var zero T
+		return zero, false
+	}
+	return req, true
+}
@@ -0,0 +1,249 @@
+package handler
+
+import (
+	"database/sql"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/securedlinq/backend/internal/middleware"
+	"github.com/securedlinq/backend/internal/models"
+	"github.com/securedlinq/backend/internal/ratelimit"
+	"github.com/securedlinq/backend/internal/repository"
+)
+
+// maxClientLogBodyBytes bounds a single client log submission so a broken
+// client can't send an arbitrarily large stack trace/context blob.
+const maxClientLogBodyBytes = 16 * 1024
+
+// Per-key token-bucket limits for POST /client/logs. The session limit is
+// tighter since it scopes to one client instance; the IP limit is a
+// looser backstop covering pre-auth submissions and NATed clients sharing
+// an address.
+const (
+	sessionBurstCapacity   = 20
+	sessionRefillPerSecond = 1
+	ipBurstCapacity        = 100
+	ipRefillPerSecond      = 5
+)
+
+var validClientLogLevels = map[string]bool{
+	"debug": true,
+	"info":  true,
+	"warn":  true,
+	"error": true,
+	"fatal": true,
+}
+
+// ClientLogHandler accepts structured log entries shipped from the
+// driver-facing web/mobile client, so in-the-field video-call failures
+// can be triaged from the server logs and client_logs table instead of
+// relying on the user to describe what happened.
+type ClientLogHandler struct {
+	clientLogRepo  *repository.ClientLogRepository
+	sessionLimiter *ratelimit.Limiter
+	ipLimiter      *ratelimit.Limiter
+	logger         *slog.Logger
+}
+
+// NewClientLogHandler creates a new client log handler
+func NewClientLogHandler(clientLogRepo *repository.ClientLogRepository) *ClientLogHandler {
+	return &ClientLogHandler{
+		clientLogRepo:  clientLogRepo,
+		sessionLimiter: ratelimit.NewLimiter(sessionBurstCapacity, sessionRefillPerSecond),
+		ipLimiter:      ratelimit.NewLimiter(ipBurstCapacity, ipRefillPerSecond),
+		logger:         slog.Default(),
+	}
+}
+
+// ClientLogRequest represents a single structured log entry shipped by the
+// client. SessionID/UserAgent/Timestamp are the client's own record of
+// them, used when the request isn't authenticated (e.g. a pre-login
+// failure); an authenticated request is tagged from its SessionInfo
+// instead, since that's trusted and the client's copy isn't.
+type ClientLogRequest struct {
+	Level      string          `json:"level" binding:"required"`
+	Message    string          `json:"message" binding:"required"`
+	Context    json.RawMessage `json:"context"`
+	Timestamp  string          `json:"timestamp"`
+	SessionID  string          `json:"sessionId"`
+	UserAgent  string          `json:"userAgent"`
+	StackTrace string          `json:"stackTrace"`
+}
+
+// SubmitLog records one client log entry (authenticated or pre-auth),
+// rate-limited per session and per IP.
+func (h *ClientLogHandler) SubmitLog(c *gin.Context) {
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxClientLogBodyBytes)
+
+	var req ClientLogRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid log entry"})
+		return
+	}
+
+	level := strings.ToLower(req.Level)
+	if !validClientLogLevels[level] {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "level must be one of debug, info, warn, error, fatal"})
+		return
+	}
+
+	ip := c.ClientIP()
+	if !h.ipLimiter.Allow(ip) {
+		c.JSON(http.StatusTooManyRequests, gin.H{"error": "Too many log submissions from this address"})
+		return
+	}
+
+	sessionInfo, authenticated := middleware.GetCurrentUser(c)
+	sessionKey := ip
+	switch {
+	case authenticated:
+		sessionKey = sessionInfo.SessionID
+	case req.SessionID != "":
+		sessionKey = req.SessionID
+	}
+	if !h.sessionLimiter.Allow(sessionKey) {
+		c.JSON(http.StatusTooManyRequests, gin.H{"error": "Too many log submissions for this session"})
+		return
+	}
+
+	entry := &models.ClientLog{
+		Level:     level,
+		Message:   req.Message,
+		IPAddress: ip,
+	}
+
+	if len(req.Context) > 0 {
+		entry.Context = sql.NullString{String: string(req.Context), Valid: true}
+		if loadID, ok := loadIDFromContext(req.Context); ok {
+			entry.LoadID = sql.NullInt64{Int64: int64(loadID), Valid: true}
+		}
+	}
+
+	userAgent := req.UserAgent
+	if userAgent == "" {
+		userAgent = c.GetHeader("User-Agent")
+	}
+	if userAgent != "" {
+		entry.UserAgent = sql.NullString{String: userAgent, Valid: true}
+	}
+
+	if req.StackTrace != "" {
+		entry.StackTrace = sql.NullString{String: req.StackTrace, Valid: true}
+	}
+
+	if req.Timestamp != "" {
+		if ts, err := time.Parse(time.RFC3339, req.Timestamp); err == nil {
+			entry.ClientTime = sql.NullTime{Time: ts, Valid: true}
+		}
+	}
+
+	if authenticated {
+		entry.SessionID = sql.NullString{String: sessionInfo.SessionID, Valid: true}
+		entry.UserType = sql.NullString{String: sessionInfo.UserType, Valid: true}
+		if sessionInfo.UserType == string(middleware.RoleDriver) {
+			entry.DriverID = sql.NullInt64{Int64: int64(sessionInfo.UserID), Valid: true}
+		}
+	} else if req.SessionID != "" {
+		entry.SessionID = sql.NullString{String: req.SessionID, Valid: true}
+	}
+
+	h.logEntry(entry)
+
+	if err := h.clientLogRepo.Create(entry); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to record log"})
+		return
+	}
+
+	c.JSON(http.StatusAccepted, gin.H{"success": true})
+}
+
+// logEntry mirrors entry to the server log via the structured logger, so
+// an on-call engineer sees client errors alongside server-side ones.
+func (h *ClientLogHandler) logEntry(entry *models.ClientLog) {
+	attrs := []any{
+		"source", "client",
+		"ip", entry.IPAddress,
+		"sessionId", entry.SessionID.String,
+	}
+	switch entry.Level {
+	case "error", "fatal":
+		h.logger.Error(entry.Message, append(attrs, "stackTrace", entry.StackTrace.String)...)
+	case "warn":
+		h.logger.Warn(entry.Message, attrs...)
+	default:
+		h.logger.Info(entry.Message, attrs...)
+	}
+}
+
+// loadIDFromContext opportunistically pulls a "loadId"/"load_id" numeric
+// field out of the client-supplied context blob, so the admin triage
+// endpoint can filter by load without the client having to send it as a
+// separate top-level field.
+func loadIDFromContext(context json.RawMessage) (uint, bool) {
+	var fields map[string]json.Number
+	if err := json.Unmarshal(context, &fields); err != nil {
+		return 0, false
+	}
+	for _, key := range []string{"loadId", "load_id"} {
+		if n, ok := fields[key]; ok {
+			if id, err := strconv.ParseUint(n.String(), 10, 32); err == nil {
+				return uint(id), true
+			}
+		}
+	}
+	return 0, false
+}
+
+// GetClientLogs lists recent client log entries, optionally filtered by
+// driver_id and/or load_id query params (admin/dispatcher only).
+func (h *ClientLogHandler) GetClientLogs(c *gin.Context) {
+	page := 1
+	pageSize := 20
+
+	if p := c.Query("page"); p != "" {
+		if parsed, err := strconv.Atoi(p); err == nil && parsed > 0 {
+			page = parsed
+		}
+	}
+
+	if ps := c.Query("page_size"); ps != "" {
+		if parsed, err := strconv.Atoi(ps); err == nil && parsed > 0 {
+			pageSize = parsed
+		}
+	}
+
+	var driverID *uint
+	if d := c.Query("driver_id"); d != "" {
+		if parsed, err := strconv.ParseUint(d, 10, 32); err == nil {
+			id := uint(parsed)
+			driverID = &id
+		}
+	}
+
+	var loadID *uint
+	if l := c.Query("load_id"); l != "" {
+		if parsed, err := strconv.ParseUint(l, 10, 32); err == nil {
+			id := uint(parsed)
+			loadID = &id
+		}
+	}
+
+	logs, total, err := h.clientLogRepo.GetFiltered(driverID, loadID, page, pageSize)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":  true,
+		"logs":     logs,
+		"total":    total,
+		"page":     page,
+		"pageSize": pageSize,
+	})
+}
@@ -0,0 +1,53 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/securedlinq/backend/internal/service"
+)
+
+// CourierHandler exposes courier queue state for operators
+type CourierHandler struct {
+	courierService *service.CourierService
+}
+
+// NewCourierHandler creates a new courier handler
+func NewCourierHandler(courierService *service.CourierService) *CourierHandler {
+	return &CourierHandler{courierService: courierService}
+}
+
+// GetMessages lists courier_messages (queued/sending/sent/abandoned) with
+// pagination, so operators can inspect delivery without shell access to
+// the database (admin only)
+func (h *CourierHandler) GetMessages(c *gin.Context) {
+	page := 1
+	pageSize := 20
+
+	if p := c.Query("page"); p != "" {
+		if parsed, err := strconv.Atoi(p); err == nil && parsed > 0 {
+			page = parsed
+		}
+	}
+
+	if ps := c.Query("page_size"); ps != "" {
+		if parsed, err := strconv.Atoi(ps); err == nil && parsed > 0 {
+			pageSize = parsed
+		}
+	}
+
+	messages, total, err := h.courierService.GetAllMessages(page, pageSize)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":  true,
+		"messages": messages,
+		"total":    total,
+		"page":     page,
+		"pageSize": pageSize,
+	})
+}
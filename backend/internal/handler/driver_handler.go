@@ -1,26 +1,106 @@
 package handler
 
 import (
+	"errors"
+	"fmt"
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/securedlinq/backend/internal/config"
+	"github.com/securedlinq/backend/internal/courier"
+	"github.com/securedlinq/backend/internal/middleware"
+	"github.com/securedlinq/backend/internal/models"
+	"github.com/securedlinq/backend/internal/repository"
 	"github.com/securedlinq/backend/internal/service"
+	"github.com/securedlinq/backend/pkg/s3gateway"
 )
 
+// VerificationEmailEnqueuer queues a verification-email message for
+// asynchronous delivery. *courier.Dispatcher implements this; tests can
+// supply a fake so DriverHandler doesn't need a database to be unit tested.
+type VerificationEmailEnqueuer interface {
+	EnqueueVerificationEmail(to string, data courier.VerificationEmailData) (*models.CourierMessage, error)
+}
+
 // DriverHandler handles driver HTTP requests
 type DriverHandler struct {
-	driverService *service.DriverService
+	driverService       *service.DriverService
+	authService         *service.AuthService
+	uiAuthService       *service.UIAuthService
+	verificationService *service.VerificationService
+	courier             VerificationEmailEnqueuer
+	frontendURL         string
+	// s3Gateway mints pkg/s3gateway access keys for IssueS3Keys. Nil if the
+	// deployment isn't using the S3 backend, in which case that endpoint
+	// responds 503.
+	s3Gateway *s3gateway.Gateway
 }
 
-// NewDriverHandler creates a new driver handler
-func NewDriverHandler(driverService *service.DriverService) *DriverHandler {
+// NewDriverHandler creates a new driver handler. s3Gateway may be nil if the
+// deployment isn't using the S3 backend.
+func NewDriverHandler(driverService *service.DriverService, authService *service.AuthService, uiAuthService *service.UIAuthService, verificationService *service.VerificationService, courierDispatcher VerificationEmailEnqueuer, cfg *config.Config, s3Gateway *s3gateway.Gateway) *DriverHandler {
 	return &DriverHandler{
-		driverService: driverService,
+		driverService:       driverService,
+		authService:         authService,
+		uiAuthService:       uiAuthService,
+		verificationService: verificationService,
+		courier:             courierDispatcher,
+		frontendURL:         cfg.Server.FrontendURL,
+		s3Gateway:           s3Gateway,
+	}
+}
+
+// uiAuthRequest wraps the optional UI-auth submission sent alongside a
+// protected request body.
+type uiAuthRequest struct {
+	Auth *service.UIAuthSubmission `json:"auth"`
+}
+
+// requireUIAuth challenges sensitive driver operations with a Matrix-style
+// user-interactive auth flow (see service.UIAuthService). On the first call
+// (no `auth` block) or an unsatisfied flow, it writes the 401 challenge
+// itself and returns ok=false so the caller can return immediately.
+func (h *DriverHandler) requireUIAuth(c *gin.Context, actorID int) (ok bool) {
+	var req uiAuthRequest
+	_ = c.ShouldBindJSON(&req)
+
+	return h.checkUIAuth(c, actorID, req.Auth)
+}
+
+// checkUIAuth is requireUIAuth for a caller that has already bound the
+// request body itself (so it can't re-bind it for the `auth` block, like
+// requireUIAuth does) and pass the submission in directly.
+func (h *DriverHandler) checkUIAuth(c *gin.Context, actorID int, submission *service.UIAuthSubmission) (ok bool) {
+	authed, challenge, err := h.uiAuthService.Authenticate(actorID, submission)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return false
+	}
+	if !authed {
+		c.JSON(http.StatusUnauthorized, challenge)
+		return false
 	}
+	return true
 }
 
-// GetAllDrivers gets all drivers with pagination (admin only)
+// OwnDriverScope is a middleware.ScopeResolver restricting driver-scoped
+// routes (API tokens, direct-to-S3 access keys) to the driver named by the
+// :id URL param. Registered alongside middleware.PermAccessKeysManage on
+// the driverTokens route group; admins bypass it like every resolver.
+func OwnDriverScope(c *gin.Context, session *service.SessionInfo) bool {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return false
+	}
+	return session.UserType == string(middleware.RoleDriver) && uint(session.UserID) == uint(id)
+}
+
+// GetAllDrivers gets all drivers with pagination and filtering (admin only).
+// Supported query parameters: page, page_size, search (matches username/
+// phone_number/email), active (true/false), created_after (RFC3339), and
+// sort (e.g. "username" or "username:asc").
 func (h *DriverHandler) GetAllDrivers(c *gin.Context) {
 	page := 1
 	pageSize := 20
@@ -37,7 +117,22 @@ func (h *DriverHandler) GetAllDrivers(c *gin.Context) {
 		}
 	}
 
-	drivers, total, err := h.driverService.GetAllDrivers(page, pageSize)
+	filters := repository.DriverFilters{
+		Search: c.Query("search"),
+		Sort:   c.Query("sort"),
+	}
+	if activeStr := c.Query("active"); activeStr != "" {
+		if active, err := strconv.ParseBool(activeStr); err == nil {
+			filters.Active = &active
+		}
+	}
+	if createdAfterStr := c.Query("created_after"); createdAfterStr != "" {
+		if createdAfter, err := time.Parse(time.RFC3339, createdAfterStr); err == nil {
+			filters.CreatedAfter = &createdAfter
+		}
+	}
+
+	drivers, total, err := h.driverService.GetAllDrivers(page, pageSize, filters)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
@@ -113,3 +208,301 @@ func (h *DriverHandler) ActivateDriver(c *gin.Context) {
 	})
 }
 
+// DeleteDriver soft-deletes a driver account (admin only): it disappears
+// from GetAllDrivers but can be brought back via RestoreDriver. It is still
+// gated behind requireUIAuth like the old hard-delete was: the first call
+// gets a 401 challenge and the caller resubmits with an `auth` block until
+// the configured flow is satisfied.
+func (h *DriverHandler) DeleteDriver(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid driver ID"})
+		return
+	}
+
+	user, ok := middleware.GetCurrentUser(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	if !h.requireUIAuth(c, user.UserID) {
+		return
+	}
+
+	if err := h.driverService.DeleteDriver(uint(id)); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Driver deleted successfully",
+	})
+}
+
+// RestoreDriver reverses a prior soft-delete (admin only).
+func (h *DriverHandler) RestoreDriver(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid driver ID"})
+		return
+	}
+
+	if err := h.driverService.RestoreDriver(uint(id)); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Driver restored successfully",
+	})
+}
+
+// bulkDriverActionRequest represents a POST /admin/drivers/bulk request.
+type bulkDriverActionRequest struct {
+	IDs    []uint                    `json:"ids" binding:"required"`
+	Action string                    `json:"action" binding:"required"`
+	Auth   *service.UIAuthSubmission `json:"auth"`
+}
+
+// BulkDriverAction applies activate/deactivate/delete to several drivers at
+// once (admin only), reporting a per-ID success/failure result rather than
+// failing the whole request if one ID is bad. Bulk delete and deactivate are
+// gated behind requireUIAuth, same as the single-driver DeleteDriver - the
+// bulk form is strictly more damaging than the single-ID one, so it doesn't
+// get a weaker gate.
+func (h *DriverHandler) BulkDriverAction(c *gin.Context) {
+	var req bulkDriverActionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ids and action are required"})
+		return
+	}
+
+	if req.Action == service.BulkActionDelete || req.Action == service.BulkActionDeactivate {
+		user, ok := middleware.GetCurrentUser(c)
+		if !ok {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+			return
+		}
+
+		if !h.checkUIAuth(c, user.UserID, req.Auth) {
+			return
+		}
+	}
+
+	results, err := h.driverService.BulkAction(req.IDs, req.Action)
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidBulkAction) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"results": results,
+	})
+}
+
+// ResetPassword issues a new random password for a driver (admin only),
+// gated behind the same user-interactive auth flow as DeleteDriver.
+func (h *DriverHandler) ResetPassword(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid driver ID"})
+		return
+	}
+
+	user, ok := middleware.GetCurrentUser(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	if !h.requireUIAuth(c, user.UserID) {
+		return
+	}
+
+	newPassword, err := h.driverService.ResetDriverPassword(uint(id))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":  true,
+		"password": newPassword,
+		"message":  "Password will not be shown again",
+	})
+}
+
+// CreateTokenRequest represents a request to issue a new API token
+type CreateTokenRequest struct {
+	Name string `json:"name" binding:"required"`
+}
+
+// CreateToken issues a new API token for a driver (admin or the owning driver)
+func (h *DriverHandler) CreateToken(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid driver ID"})
+		return
+	}
+
+	var req CreateTokenRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "name is required"})
+		return
+	}
+
+	rawToken, token, err := h.authService.CreateAPIToken(uint(id), req.Name)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"token":   rawToken,
+		"info":    token,
+		"message": "Token will not be shown again",
+	})
+}
+
+// IssueS3Keys mints a pkg/s3gateway access key/secret pair for a driver, so
+// an external analytics/backup tool can read that driver's gallery media
+// through the S3-shaped API (admin only - unlike API tokens and access
+// keys, a driver can't mint these for themselves).
+func (h *DriverHandler) IssueS3Keys(c *gin.Context) {
+	if h.s3Gateway == nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "S3 gateway is not configured"})
+		return
+	}
+
+	idStr := c.Param("id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid driver ID"})
+		return
+	}
+
+	accessKey, secretKey, err := h.s3Gateway.IssueKeys(uint(id))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":    true,
+		"access_key": accessKey,
+		"secret_key": secretKey,
+		"message":    "Secret key will not be shown again",
+	})
+}
+
+// DeleteToken revokes an API token (admin or the owning driver)
+func (h *DriverHandler) DeleteToken(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid driver ID"})
+		return
+	}
+
+	tokenIDStr := c.Param("tokenId")
+	tokenID, err := strconv.ParseUint(tokenIDStr, 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid token ID"})
+		return
+	}
+
+	if err := h.authService.RevokeAPIToken(uint(id), uint(tokenID)); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Token revoked",
+	})
+}
+
+// SendVerificationEmail queues a driver email-verification message
+// (parallel to NotificationHandler.SendMeetingInvite): it issues a signed
+// token and enqueues a link to FrontendURL/verify-email?token=... for
+// delivery by the courier worker (admin only).
+func (h *DriverHandler) SendVerificationEmail(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid driver ID"})
+		return
+	}
+
+	driver, err := h.driverService.GetDriverByID(uint(id))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Driver not found"})
+		return
+	}
+
+	if !driver.Email.Valid || driver.Email.String == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Driver has no email on file"})
+		return
+	}
+
+	if driver.EmailVerifiedAt.Valid {
+		c.JSON(http.StatusConflict, gin.H{"error": gin.H{"code": "email-already-verified", "message": "Driver email is already verified"}})
+		return
+	}
+
+	token := h.verificationService.IssueToken(driver.ID, driver.Email.String)
+	verificationLink := fmt.Sprintf("%s/verify-email?token=%s", h.frontendURL, token)
+
+	_, err = h.courier.EnqueueVerificationEmail(driver.Email.String, courier.VerificationEmailData{
+		DriverName:       driver.FirstName,
+		VerificationLink: verificationLink,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("Failed to queue verification email: %v", err)})
+		return
+	}
+
+	c.JSON(http.StatusAccepted, gin.H{
+		"success": true,
+		"message": "Verification email queued for delivery",
+	})
+}
+
+// VerifyEmail validates a verification token's signature and expiry, marks
+// the referenced driver's email verified, and redirects the browser back to
+// the frontend. It is public (no auth) since it's reached by clicking an
+// emailed link, not from within an authenticated session.
+func (h *DriverHandler) VerifyEmail(c *gin.Context) {
+	token := c.Query("token")
+	if token == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "token is required"})
+		return
+	}
+
+	_, err := h.verificationService.VerifyToken(token)
+	switch {
+	case err == nil:
+		c.Redirect(http.StatusFound, h.frontendURL+"/verify-email?status=verified")
+	case errors.Is(err, service.ErrEmailAlreadyVerified):
+		c.JSON(http.StatusConflict, gin.H{"error": gin.H{"code": "email-already-verified", "message": "Driver email is already verified"}})
+	case errors.Is(err, service.ErrVerificationTokenExpired):
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": gin.H{"code": "verification-token-expired", "message": "Verification link has expired"}})
+	case errors.Is(err, service.ErrVerificationTokenInvalid):
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": gin.H{"code": "verification-token-invalid", "message": "Verification link is invalid"}})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
@@ -0,0 +1,46 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetAllDriversV2 lists drivers using cursor pagination and the v2
+// {data, error} envelope, replacing v1's page/pageSize query params with
+// cursor/limit.
+func (h *DriverHandler) GetAllDriversV2(c *gin.Context) {
+	var cursor uint
+	if cs := c.Query("cursor"); cs != "" {
+		parsed, err := strconv.ParseUint(cs, 10, 32)
+		if err != nil {
+			RespondError(c, http.StatusBadRequest, "invalid_cursor", "cursor must be a positive integer", nil)
+			return
+		}
+		cursor = uint(parsed)
+	}
+
+	limit := 20
+	if ls := c.Query("limit"); ls != "" {
+		if parsed, err := strconv.Atoi(ls); err == nil && parsed > 0 {
+			limit = parsed
+		}
+	}
+
+	drivers, nextCursor, err := h.driverService.GetAllDriversCursor(cursor, limit)
+	if err != nil {
+		RespondError(c, http.StatusInternalServerError, "internal_error", err.Error(), nil)
+		return
+	}
+
+	nextCursorStr := ""
+	if nextCursor != 0 {
+		nextCursorStr = strconv.FormatUint(uint64(nextCursor), 10)
+	}
+
+	RespondData(c, http.StatusOK, gin.H{
+		"drivers":     drivers,
+		"next_cursor": nextCursorStr,
+	})
+}
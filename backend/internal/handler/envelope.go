@@ -0,0 +1,32 @@
+package handler
+
+import "github.com/gin-gonic/gin"
+
+// ErrorDetail is the structured error body v2 responses use in place of v1's
+// flat {"error": "message"} shape, so clients can branch on a stable code
+// instead of parsing message text.
+type ErrorDetail struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+	Details any    `json:"details,omitempty"`
+}
+
+// dataEnvelope wraps a successful v2 response payload.
+type dataEnvelope struct {
+	Data any `json:"data"`
+}
+
+// errorEnvelope wraps a failed v2 response payload.
+type errorEnvelope struct {
+	Error ErrorDetail `json:"error"`
+}
+
+// RespondData writes a v2 {"data": ...} envelope.
+func RespondData(c *gin.Context, status int, data any) {
+	c.JSON(status, dataEnvelope{Data: data})
+}
+
+// RespondError writes a v2 {"error": {code, message, details}} envelope.
+func RespondError(c *gin.Context, status int, code, message string, details any) {
+	c.JSON(status, errorEnvelope{Error: ErrorDetail{Code: code, Message: message, Details: details}})
+}
@@ -0,0 +1,62 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestRespondDataAndErrorEnvelopes checks v2's {data, error} envelope: a
+// fixture payload written with RespondData comes back under "data", and a
+// fixture error written with RespondError comes back under "error" with its
+// code and message.
+func TestRespondDataAndErrorEnvelopes(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	t.Run("data envelope", func(t *testing.T) {
+		router := gin.New()
+		router.GET("/v2/fixture", func(c *gin.Context) {
+			RespondData(c, http.StatusOK, gin.H{"id": 1, "name": "fixture-driver"})
+		})
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/v2/fixture", nil))
+
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var body map[string]json.RawMessage
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+
+		data, ok := body["data"]
+		require.True(t, ok, "v2 response must be wrapped under \"data\"")
+
+		var payload map[string]any
+		require.NoError(t, json.Unmarshal(data, &payload))
+		assert.Equal(t, "fixture-driver", payload["name"])
+	})
+
+	t.Run("error envelope", func(t *testing.T) {
+		router := gin.New()
+		router.GET("/v2/fixture", func(c *gin.Context) {
+			RespondError(c, http.StatusNotFound, "driver_not_found", "driver not found", nil)
+		})
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/v2/fixture", nil))
+
+		require.Equal(t, http.StatusNotFound, w.Code)
+
+		var body map[string]ErrorDetail
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+
+		errDetail, ok := body["error"]
+		require.True(t, ok, "v2 error response must be wrapped under \"error\"")
+		assert.Equal(t, "driver_not_found", errDetail.Code)
+		assert.Equal(t, "driver not found", errDetail.Message)
+	})
+}
@@ -0,0 +1,35 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/securedlinq/backend/pkg/filestore"
+)
+
+// LocalFileHandler handles HTTP requests for the local filestore backend
+type LocalFileHandler struct {
+	store *filestore.LocalStore
+}
+
+// NewLocalFileHandler creates a new local file handler
+func NewLocalFileHandler(store *filestore.LocalStore) *LocalFileHandler {
+	return &LocalFileHandler{store: store}
+}
+
+// ServeFile serves the object a filestore.LocalStore.SignedURL token points
+// at, for "GET /files/:token". The token itself carries the key and expiry,
+// HMAC-signed, so this route needs no session and no database lookup.
+func (h *LocalFileHandler) ServeFile(c *gin.Context) {
+	token := c.Param("token")
+
+	f, err := h.store.Open(token)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "file not found or link expired"})
+		return
+	}
+	defer f.Close()
+
+	http.ServeContent(c.Writer, c.Request, token, time.Time{}, f)
+}
@@ -1,31 +1,71 @@
 package handler
 
 import (
+	"bytes"
+	"database/sql"
+	"encoding/base64"
 	"fmt"
 	"net/http"
+	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
-	"github.com/securedlinq/backend/internal/config"
 	"github.com/securedlinq/backend/internal/models"
 	"github.com/securedlinq/backend/internal/repository"
+	"github.com/securedlinq/backend/pkg/filestore"
+	"github.com/securedlinq/backend/pkg/imagesan"
 	"github.com/securedlinq/backend/pkg/s3"
+	"github.com/securedlinq/backend/pkg/s3gateway"
+	"github.com/securedlinq/backend/pkg/scanner"
+	"github.com/securedlinq/backend/pkg/sharing"
 )
 
 // MediaHandler handles media HTTP requests
 type MediaHandler struct {
-	s3Client     *s3.Client
-	galleryRepo  *repository.GalleryRepository
-	meetingRepo  *repository.MeetingRepository
-	config       *config.Config
+	fileStore   filestore.FileStore
+	galleryRepo *repository.GalleryRepository
+	meetingRepo *repository.MeetingRepository
+	// s3Client and uploadRepo back the streaming multipart upload endpoint
+	// (UploadStream) only - everything else goes through fileStore. Both are
+	// nil, and UploadStream disabled, when the filestore backend is local
+	// rather than S3.
+	s3Client   *s3.Client
+	uploadRepo *repository.UploadRepository
+	// s3Gateway backs the read-only S3-shaped API (ListObjectsV2/GetObject/
+	// HeadObject) for external tools - nil, and those endpoints disabled,
+	// when the filestore backend is local rather than S3.
+	s3Gateway *s3gateway.Gateway
+	// shareService backs the public media-share endpoints (CreateMediaShare/
+	// RevokeMediaShare/ResolveMediaShare) - nil, and those endpoints
+	// disabled, if sharing wasn't configured.
+	shareService *sharing.Service
+	// scanner screens SaveScreenshot uploads for malware (see pkg/scanner).
+	// It's always non-nil - scanner.NoopScanner{} when scanMode is "off" -
+	// so call sites never need to nil-check it.
+	scanner           scanner.Scanner
+	scanMode          string
+	securityEventRepo *repository.SecurityEventRepository
 }
 
-// NewMediaHandler creates a new media handler
-func NewMediaHandler(s3Client *s3.Client, galleryRepo *repository.GalleryRepository, meetingRepo *repository.MeetingRepository, cfg *config.Config) *MediaHandler {
+// NewMediaHandler creates a new media handler. fileStore may be any
+// filestore.FileStore implementation (S3, an S3-compatible endpoint, local
+// disk, or a mock in tests) - the handler doesn't care which. s3Client,
+// uploadRepo, s3Gateway, and shareService may be nil if the deployment
+// isn't using the S3 backend, in which case the endpoints they back (the
+// streaming upload, the S3 gateway, the media-share endpoints, and
+// load-scope share bundling) respond 503. scanMode is one of "off"/"sync"/"async" (config.ScannerConfig.Mode).
+func NewMediaHandler(fileStore filestore.FileStore, galleryRepo *repository.GalleryRepository, meetingRepo *repository.MeetingRepository, s3Client *s3.Client, uploadRepo *repository.UploadRepository, s3Gateway *s3gateway.Gateway, shareService *sharing.Service, mediaScanner scanner.Scanner, scanMode string, securityEventRepo *repository.SecurityEventRepository) *MediaHandler {
 	return &MediaHandler{
-		s3Client:    s3Client,
-		galleryRepo: galleryRepo,
-		meetingRepo: meetingRepo,
-		config:      cfg,
+		fileStore:         fileStore,
+		galleryRepo:       galleryRepo,
+		meetingRepo:       meetingRepo,
+		s3Client:          s3Client,
+		uploadRepo:        uploadRepo,
+		s3Gateway:         s3Gateway,
+		shareService:      shareService,
+		scanner:           mediaScanner,
+		scanMode:          scanMode,
+		securityEventRepo: securityEventRepo,
 	}
 }
 
@@ -37,27 +77,37 @@ func (h *MediaHandler) GetLoadMedia(c *gin.Context) {
 		return
 	}
 
-	media, err := h.s3Client.ListLoadMedia(loadNumber)
+	objects, err := h.fileStore.List(c.Request.Context(), loadNumber+"_")
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
 	// Transform for frontend
-	transformedMedia := make([]map[string]interface{}, len(media))
-	for i, m := range media {
-		transformedMedia[i] = map[string]interface{}{
-			"id":         m.Key,
-			"type":       m.Type,
-			"step":       m.Step,
-			"timestamp":  m.LastModified,
-			"fileName":   m.FileName,
-			"size":       m.Size,
-			"loadNumber": m.LoadNumber,
-			"signedUrl":  m.SignedURL,
-			"s3Key":      m.Key,
-			"uri":        m.SignedURL,
+	transformedMedia := make([]map[string]interface{}, 0, len(objects))
+	for _, obj := range objects {
+		// Only include screenshot files, matching the existing upload naming
+		// convention ("<loadNumber>_<timestamp>.screenshot.png").
+		if !strings.Contains(obj.Key, "screenshot") {
+			continue
+		}
+
+		signedURL, err := h.fileStore.SignedURL(c.Request.Context(), obj.Key, time.Hour)
+		if err != nil {
+			continue
 		}
+
+		transformedMedia = append(transformedMedia, map[string]interface{}{
+			"id":         obj.Key,
+			"type":       mediaTypeForKey(obj.Key),
+			"timestamp":  obj.LastModified,
+			"fileName":   obj.Key,
+			"size":       obj.Size,
+			"loadNumber": loadNumber,
+			"signedUrl":  signedURL,
+			"s3Key":      obj.Key,
+			"uri":        signedURL,
+		})
 	}
 
 	c.JSON(http.StatusOK, gin.H{
@@ -84,7 +134,7 @@ func (h *MediaHandler) SaveScreenshot(c *gin.Context) {
 	// Get load_id from room_id or direct load_id
 	var loadID uint
 	var loadNumber string = "unknown"
-	
+
 	if req.LoadID > 0 {
 		loadID = req.LoadID
 	} else if req.RoomID != "" {
@@ -108,45 +158,88 @@ func (h *MediaHandler) SaveScreenshot(c *gin.Context) {
 		return
 	}
 
-	// Upload to S3
-	result, err := h.s3Client.UploadBase64Image(loadNumber, req.Screenshot)
+	imageData, err := decodeBase64Image(req.Screenshot)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("failed to decode screenshot: %v", err)})
 		return
 	}
 
-	if !result.Success {
-		c.JSON(http.StatusBadRequest, gin.H{"error": result.Error})
+	imageData, err = imagesan.Sanitize(imageData, "image/png")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("failed to process screenshot: %v", err)})
+		return
+	}
+
+	key := fmt.Sprintf("%s_%d.screenshot.png", loadNumber, time.Now().UnixMilli())
+
+	scanStatus := models.GalleryScanSkipped
+	if h.scanMode == "sync" {
+		scanResult, err := h.scanner.Scan(c.Request.Context(), bytes.NewReader(imageData))
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("failed to scan screenshot: %v", err)})
+			return
+		}
+		if scanResult.Verdict != scanner.VerdictClean {
+			if scanResult.Verdict == scanner.VerdictInfected {
+				h.logSecurityEvent(models.SecurityEventInfectedUpload, nil, key, scanResult.Signature)
+			}
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"error": "screenshot failed malware scan"})
+			return
+		}
+		scanStatus = models.GalleryScanClean
+	}
+
+	result, err := h.fileStore.Upload(c.Request.Context(), key, bytes.NewReader(imageData), "image/png")
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
 	// Save to gallery
 	gallery := &models.Gallery{
-		LoadID:   loadID,
-		FileName: result.Key,
-		S3Key:    result.Key,
+		LoadID:     loadID,
+		FileName:   result.Key,
+		S3Key:      result.Key,
+		ScanStatus: sql.NullString{String: scanStatus, Valid: true},
 	}
 	if err := h.galleryRepo.Create(gallery); err != nil {
 		// Log error but don't fail the request - screenshot is already uploaded
 		fmt.Printf("Warning: Failed to save screenshot to gallery: %v\n", err)
 	}
 
-	// Construct direct S3 URL (bucket is public)
-	// Format: https://{bucket}.s3.{region}.amazonaws.com/{key}
-	directURL := fmt.Sprintf("https://%s.s3.%s.amazonaws.com/%s",
-		h.config.AWS.S3BucketName,
-		h.config.AWS.Region,
-		result.Key,
-	)
-
 	c.JSON(http.StatusOK, gin.H{
 		"success": true,
 		"s3Key":   result.Key,
-		"url":     directURL,
+		"url":     result.URL,
 		"id":      gallery.ID,
 	})
 }
 
+// logSecurityEvent records a security event, logging but not failing the
+// request if the audit write itself fails.
+func (h *MediaHandler) logSecurityEvent(eventType string, galleryID *uint, s3Key, detail string) {
+	event := &models.SecurityEvent{
+		EventType: eventType,
+		S3Key:     s3Key,
+		Detail:    detail,
+	}
+	if galleryID != nil {
+		event.GalleryID = sql.NullInt64{Int64: int64(*galleryID), Valid: true}
+	}
+	if err := h.securityEventRepo.Create(event); err != nil {
+		fmt.Printf("Warning: Failed to record security event: %v\n", err)
+	}
+}
+
+// decodeBase64Image strips a data-URL prefix, if present, and decodes the
+// remaining base64 payload.
+func decodeBase64Image(data string) ([]byte, error) {
+	data = strings.TrimPrefix(data, "data:image/png;base64,")
+	data = strings.TrimPrefix(data, "data:image/jpeg;base64,")
+	data = strings.TrimPrefix(data, "data:image/jpg;base64,")
+	return base64.StdEncoding.DecodeString(data)
+}
+
 // GetSignedURLRequest represents a signed URL request
 type GetSignedURLRequest struct {
 	Key string `json:"key" binding:"required"`
@@ -160,7 +253,7 @@ func (h *MediaHandler) GetSignedURL(c *gin.Context) {
 		return
 	}
 
-	url, err := h.s3Client.GetSignedURL(key, 3600)
+	url, err := h.fileStore.SignedURL(c.Request.Context(), key, time.Hour)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
@@ -192,41 +285,39 @@ func (h *MediaHandler) GetScreenshotsByLoad(c *gin.Context) {
 		return
 	}
 
-	// Construct direct S3 URLs for both screenshots and videos
+	// Build signed URLs for both screenshots and videos
 	screenshots := make([]map[string]interface{}, 0, len(galleries))
 	for _, gallery := range galleries {
-		var directURL string
-		var mediaType string
-		
+		var key, mediaType string
+		if gallery.Hidden {
+			continue
+		}
+
 		// Determine if it's a video or screenshot
 		if gallery.VideoRecordingKey != "" {
-			// Video recording
-			directURL = fmt.Sprintf("https://%s.s3.%s.amazonaws.com/%s",
-				h.config.AWS.S3BucketName,
-				h.config.AWS.Region,
-				gallery.VideoRecordingKey,
-			)
+			key = gallery.VideoRecordingKey
 			mediaType = "video"
 		} else if gallery.S3Key != "" {
-			// Screenshot
-			directURL = fmt.Sprintf("https://%s.s3.%s.amazonaws.com/%s",
-				h.config.AWS.S3BucketName,
-				h.config.AWS.Region,
-				gallery.S3Key,
-			)
+			key = gallery.S3Key
 			mediaType = "image"
 		} else {
 			// Skip entries with no media
 			continue
 		}
-		
+
+		url, err := h.fileStore.SignedURL(c.Request.Context(), key, time.Hour)
+		if err != nil {
+			fmt.Printf("Warning: Failed to sign URL for gallery entry %d: %v\n", gallery.ID, err)
+			continue
+		}
+
 		screenshots = append(screenshots, map[string]interface{}{
 			"id":        gallery.ID,
 			"loadId":    gallery.LoadID,
 			"fileName":  gallery.FileName,
 			"s3Key":     gallery.S3Key,
 			"videoKey":  gallery.VideoRecordingKey,
-			"url":       directURL,
+			"url":       url,
 			"type":      mediaType,
 			"createdAt": gallery.CreatedAt,
 		})
@@ -238,3 +329,16 @@ func (h *MediaHandler) GetScreenshotsByLoad(c *gin.Context) {
 	})
 }
 
+// mediaTypeForKey classifies an object key as "image" or "video" based on
+// its extension, falling back to "image" for the screenshot naming
+// convention ("<loadNumber>_<timestamp>.screenshot.png").
+func mediaTypeForKey(key string) string {
+	ext := strings.ToLower(key)
+	switch {
+	case strings.HasSuffix(ext, ".mp4"), strings.HasSuffix(ext, ".mov"),
+		strings.HasSuffix(ext, ".avi"), strings.HasSuffix(ext, ".mkv"), strings.HasSuffix(ext, ".webm"):
+		return "video"
+	default:
+		return "image"
+	}
+}
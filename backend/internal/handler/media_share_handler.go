@@ -0,0 +1,212 @@
+package handler
+
+import (
+	"archive/zip"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/securedlinq/backend/internal/middleware"
+	"github.com/securedlinq/backend/internal/models"
+	"github.com/securedlinq/backend/pkg/sharing"
+)
+
+// CreateMediaShareRequest represents a request to mint a public share link
+// for either a single gallery item or a whole load's media.
+type CreateMediaShareRequest struct {
+	GalleryID uint `json:"galleryId,omitempty"`
+	LoadID    uint `json:"loadId,omitempty"`
+	// ExpiresInHours defaults to 72 (three days) if unset.
+	ExpiresInHours int `json:"expiresInHours,omitempty"`
+	// MaxViews is unlimited if zero/unset.
+	MaxViews int `json:"maxViews,omitempty"`
+}
+
+// CreateMediaShare mints a public share link scoped to a gallery item or a
+// whole load.
+func (h *MediaHandler) CreateMediaShare(c *gin.Context) {
+	if h.shareService == nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "media sharing is not configured"})
+		return
+	}
+
+	var req CreateMediaShareRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		return
+	}
+	if (req.GalleryID == 0) == (req.LoadID == 0) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "exactly one of galleryId or loadId is required"})
+		return
+	}
+
+	expiresInHours := req.ExpiresInHours
+	if expiresInHours <= 0 {
+		expiresInHours = 72
+	}
+	expiresIn := time.Duration(expiresInHours) * time.Hour
+
+	sessionInfo, ok := middleware.GetCurrentUser(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "authentication required"})
+		return
+	}
+
+	var (
+		token string
+		err   error
+	)
+	if req.GalleryID > 0 {
+		token, _, err = h.shareService.CreateGalleryShare(req.GalleryID, expiresIn, req.MaxViews, sessionInfo.UserID)
+	} else {
+		token, _, err = h.shareService.CreateLoadShare(req.LoadID, expiresIn, req.MaxViews, sessionInfo.UserID)
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":   true,
+		"token":     token,
+		"url":       "/s/" + token,
+		"expiresAt": time.Now().Add(expiresIn),
+	})
+}
+
+// RevokeMediaShare revokes a previously issued share link, so it stops
+// resolving immediately even if it hasn't expired yet.
+func (h *MediaHandler) RevokeMediaShare(c *gin.Context) {
+	if h.shareService == nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "media sharing is not configured"})
+		return
+	}
+
+	token := c.Param("token")
+	if err := h.shareService.RevokeByToken(token); err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "share link not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"success": true})
+}
+
+// ResolveMediaShare is the public, unauthenticated endpoint a recipient
+// hits to view shared media. A gallery-scoped share redirects to a signed
+// URL; a load-scoped share streams a zip bundle of every media item on the
+// load.
+func (h *MediaHandler) ResolveMediaShare(c *gin.Context) {
+	if h.shareService == nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "media sharing is not configured"})
+		return
+	}
+
+	token := c.Param("token")
+	share, err := h.shareService.Resolve(token)
+	if err != nil {
+		c.JSON(shareErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.shareService.RecordAccess(share, c.ClientIP(), c.Request.UserAgent()); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to record access"})
+		return
+	}
+
+	switch share.Scope {
+	case models.MediaShareScopeGallery:
+		h.resolveGalleryShare(c, share)
+	case models.MediaShareScopeLoad:
+		h.resolveLoadShare(c, share)
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "share has an unknown scope"})
+	}
+}
+
+// resolveGalleryShare redirects to a short-lived signed URL for the shared
+// gallery item's underlying object, going through fileStore like every
+// other media-signing path in this handler.
+func (h *MediaHandler) resolveGalleryShare(c *gin.Context, share *models.MediaShare) {
+	key, err := h.shareService.ResolveGalleryObjectKey(share)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	url, err := h.fileStore.SignedURL(c.Request.Context(), key, 15*time.Minute)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Redirect(http.StatusFound, url)
+}
+
+// resolveLoadShare streams every media object on the shared load as a
+// single zip, since there's no single object a signed URL could point at.
+// It requires s3Client (the S3-specific streaming read), the same
+// precondition UploadStream has - deployments on the local filestore
+// backend don't support load-scope shares.
+func (h *MediaHandler) resolveLoadShare(c *gin.Context, share *models.MediaShare) {
+	if h.s3Client == nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "load media bundling requires the S3 storage backend"})
+		return
+	}
+
+	keys, err := h.shareService.ResolveLoadObjectKeys(share)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	if len(keys) == 0 {
+		c.JSON(http.StatusNotFound, gin.H{"error": "load has no media"})
+		return
+	}
+
+	fileName := bundleFileName(uint(share.LoadID.Int64))
+	c.Header("Content-Type", "application/zip")
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, fileName))
+
+	zw := zip.NewWriter(c.Writer)
+	defer zw.Close()
+
+	for _, key := range keys {
+		reader, err := h.s3Client.GetObjectReader(key)
+		if err != nil {
+			// Best effort - skip an object that's gone missing rather than
+			// failing the whole bundle partway through a streamed response.
+			continue
+		}
+
+		w, err := zw.Create(path.Base(key))
+		if err != nil {
+			reader.Close()
+			continue
+		}
+		if _, err := io.Copy(w, reader); err != nil {
+			reader.Close()
+			continue
+		}
+		reader.Close()
+	}
+}
+
+func shareErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, sharing.ErrNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, sharing.ErrRevoked), errors.Is(err, sharing.ErrExpired), errors.Is(err, sharing.ErrViewLimitReached):
+		return http.StatusGone
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// bundleFileName derives a friendly download name for a load-scope zip.
+func bundleFileName(loadID uint) string {
+	return fmt.Sprintf("load-%d-media.zip", loadID)
+}
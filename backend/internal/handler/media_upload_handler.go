@@ -0,0 +1,221 @@
+package handler
+
+import (
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/securedlinq/backend/internal/models"
+	"github.com/securedlinq/backend/pkg/s3"
+)
+
+// UploadStream streams a large recording straight into S3 via multipart
+// upload instead of buffering it in memory like SaveScreenshot does, and
+// records progress in the uploads table so a dropped connection can be
+// resumed with a follow-up request carrying the same uploadId. Accepts
+// either "multipart/form-data" (a single "file" field) or a raw
+// application/octet-stream body.
+func (h *MediaHandler) UploadStream(c *gin.Context) {
+	if h.s3Client == nil || h.uploadRepo == nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "streaming upload is not configured"})
+		return
+	}
+
+	loadNumber := c.Query("loadNumber")
+	if loadNumber == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "loadNumber is required"})
+		return
+	}
+
+	body, contentType, err := requestUploadBody(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	uploadID := c.Query("uploadId")
+	if uploadID == "" {
+		h.startUpload(c, loadNumber, contentType, body)
+		return
+	}
+	h.resumeUpload(c, uploadID, body)
+}
+
+func (h *MediaHandler) startUpload(c *gin.Context, loadNumber, contentType string, body io.Reader) {
+	uploadID, err := generateUploadID()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	key := fmt.Sprintf("%s_%d.recording%s", loadNumber, time.Now().UnixMilli(), extensionForContentType(contentType))
+
+	s3UploadID, err := h.s3Client.CreateMultipartUpload(c.Request.Context(), key, contentType)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	upload := &models.Upload{
+		UploadID:    uploadID,
+		S3UploadID:  s3UploadID,
+		S3Key:       key,
+		ContentType: contentType,
+		PartSize:    s3.DefaultMultipartPartSize,
+		Status:      models.UploadUploading,
+	}
+	if err := h.uploadRepo.Create(upload); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("failed to record upload: %v", err)})
+		return
+	}
+
+	h.runMultipartUpload(c, upload, nil, body)
+}
+
+func (h *MediaHandler) resumeUpload(c *gin.Context, uploadID string, body io.Reader) {
+	upload, err := h.uploadRepo.GetByUploadID(uploadID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "upload not found"})
+		return
+	}
+	if upload.Status != models.UploadUploading {
+		c.JSON(http.StatusConflict, gin.H{"error": fmt.Sprintf("upload is already %s", upload.Status)})
+		return
+	}
+
+	existingParts, err := decodeCompletedParts(upload.CompletedParts)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	h.runMultipartUpload(c, upload, s3.ContiguousParts(existingParts), body)
+}
+
+// runMultipartUpload drives the actual S3 transfer for both a fresh upload
+// (existingParts nil) and a resumed one, persisting progress to the uploads
+// table as parts complete so a later request can pick up where this one
+// left off if the connection drops mid-stream.
+func (h *MediaHandler) runMultipartUpload(c *gin.Context, upload *models.Upload, existingParts []s3.CompletedPart, body io.Reader) {
+	completed := append([]s3.CompletedPart{}, existingParts...)
+
+	onProgress := func(part s3.CompletedPart, bytesUploaded int64, partsCompleted int) {
+		completed = append(completed, part)
+
+		partsJSON, err := json.Marshal(s3.ContiguousParts(completed))
+		if err != nil {
+			fmt.Printf("Warning: Failed to encode completed parts for upload %s: %v\n", upload.UploadID, err)
+			return
+		}
+		if err := h.uploadRepo.UpdateProgress(upload.UploadID, bytesUploaded, string(partsJSON)); err != nil {
+			fmt.Printf("Warning: Failed to record upload progress for %s: %v\n", upload.UploadID, err)
+		}
+	}
+
+	var result *s3.MultipartUploadResult
+	var err error
+	if len(existingParts) == 0 {
+		result, err = h.s3Client.UploadMultipart(c.Request.Context(), upload.S3Key, body, upload.ContentType, upload.PartSize, onProgress)
+	} else {
+		result, err = h.s3Client.ResumeMultipartUpload(c.Request.Context(), upload.S3Key, upload.S3UploadID, existingParts, body, upload.PartSize, onProgress)
+	}
+
+	if err != nil {
+		// The connection may simply have dropped mid-stream; leave the
+		// upload resumable unless S3 itself discarded it (ctx.Err() on our
+		// side always aborts the multipart upload, so treat any failure
+		// here as aborted rather than silently stuck in "uploading").
+		if markErr := h.uploadRepo.MarkFailed(upload.UploadID, err, true); markErr != nil {
+			fmt.Printf("Warning: Failed to mark upload %s failed: %v\n", upload.UploadID, markErr)
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error(), "uploadId": upload.UploadID})
+		return
+	}
+
+	if err := h.uploadRepo.MarkComplete(upload.UploadID); err != nil {
+		fmt.Printf("Warning: Failed to mark upload %s complete: %v\n", upload.UploadID, err)
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":  true,
+		"uploadId": upload.UploadID,
+		"s3Key":    result.Key,
+		"size":     result.Size,
+	})
+}
+
+// requestUploadBody returns the upload payload's reader and content type,
+// whether the client sent multipart/form-data (a "file" field) or a raw
+// application/octet-stream body.
+func requestUploadBody(c *gin.Context) (io.Reader, string, error) {
+	mediaType, _, err := mime.ParseMediaType(c.ContentType())
+	if err == nil && mediaType == "multipart/form-data" {
+		mr, err := c.Request.MultipartReader()
+		if err != nil {
+			return nil, "", fmt.Errorf("invalid multipart request: %w", err)
+		}
+		for {
+			part, err := mr.NextPart()
+			if err == io.EOF {
+				return nil, "", fmt.Errorf("multipart request has no file field")
+			}
+			if err != nil {
+				return nil, "", fmt.Errorf("invalid multipart request: %w", err)
+			}
+			if part.FormName() == "file" {
+				contentType := part.Header.Get("Content-Type")
+				if contentType == "" {
+					contentType = "application/octet-stream"
+				}
+				return part, contentType, nil
+			}
+		}
+	}
+
+	contentType := c.ContentType()
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	return c.Request.Body, contentType, nil
+}
+
+func generateUploadID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// decodeCompletedParts unmarshals the JSON-encoded part list persisted by
+// runMultipartUpload's onProgress callback, for resuming an upload.
+func decodeCompletedParts(raw sql.NullString) ([]s3.CompletedPart, error) {
+	if !raw.Valid || raw.String == "" {
+		return nil, nil
+	}
+	var parts []s3.CompletedPart
+	if err := json.Unmarshal([]byte(raw.String), &parts); err != nil {
+		return nil, fmt.Errorf("failed to decode completed parts: %w", err)
+	}
+	return parts, nil
+}
+
+func extensionForContentType(contentType string) string {
+	switch contentType {
+	case "video/mp4":
+		return ".mp4"
+	case "video/quicktime":
+		return ".mov"
+	case "application/vnd.apple.mpegurl":
+		return ".m3u8"
+	default:
+		return ""
+	}
+}
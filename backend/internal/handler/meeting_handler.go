@@ -1,21 +1,43 @@
 package handler
 
 import (
+	"errors"
 	"net/http"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/securedlinq/backend/internal/middleware"
 	"github.com/securedlinq/backend/internal/service"
+	"github.com/securedlinq/backend/pkg/agora"
 )
 
 // MeetingHandler handles meeting HTTP requests
 type MeetingHandler struct {
 	meetingService *service.MeetingService
+	aclService     *service.MeetingACLService
+	agoraClient    *agora.Client
 }
 
 // NewMeetingHandler creates a new meeting handler
-func NewMeetingHandler(meetingService *service.MeetingService) *MeetingHandler {
+func NewMeetingHandler(meetingService *service.MeetingService, aclService *service.MeetingACLService, agoraClient *agora.Client) *MeetingHandler {
 	return &MeetingHandler{
 		meetingService: meetingService,
+		aclService:     aclService,
+		agoraClient:    agoraClient,
+	}
+}
+
+// writeMeetingACLError maps a MeetingACLService error to a Matrix-style
+// error response instead of leaking a plain 404 for rooms the caller isn't
+// permitted to see.
+func writeMeetingACLError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, service.ErrMeetingForbidden):
+		c.JSON(http.StatusForbidden, gin.H{"errcode": "FORBIDDEN", "error": "You do not have access to this meeting room"})
+	case errors.Is(err, service.ErrMeetingNotJoined):
+		c.JSON(http.StatusNotFound, gin.H{"errcode": "NOT_JOINED", "error": "Link Invalid or Expired. This meeting link has been invalidated. Please request a new link from the admin."})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 	}
 }
 
@@ -50,7 +72,9 @@ func (h *MeetingHandler) CreateMeeting(c *gin.Context) {
 	})
 }
 
-// GetMeetingByRoomID gets a meeting room by room ID
+// GetMeetingByRoomID gets a meeting room by room ID, gated on the caller
+// being a joined member of the room (an admin, the load's assigned driver,
+// or an explicitly invited participant).
 func (h *MeetingHandler) GetMeetingByRoomID(c *gin.Context) {
 	roomID := c.Query("roomId")
 	if roomID == "" || roomID == "undefined" || roomID == "null" {
@@ -58,20 +82,141 @@ func (h *MeetingHandler) GetMeetingByRoomID(c *gin.Context) {
 		return
 	}
 
-	meetingRoom, err := h.meetingService.GetMeetingRoomByRoomID(roomID)
+	user, ok := middleware.GetCurrentUser(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	meetingRoom, err := h.aclService.CheckAccess(roomID, user.UserType, user.UserID)
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{
-			"error": "Link Invalid or Expired. This meeting link has been invalidated. Please request a new link from the admin.",
-		})
+		writeMeetingACLError(c, err)
 		return
 	}
 
 	// Update last joined timestamp
 	_ = h.meetingService.UpdateLastJoined(roomID)
 
+	joinToken, err := h.meetingService.IssueJoinToken(meetingRoom)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
 	c.JSON(http.StatusOK, gin.H{
 		"success":     true,
 		"meetingRoom": meetingRoom,
+		"joinToken":   joinToken.Token,
+		"expiresAt":   joinToken.ExpiresAt.Format(time.RFC3339),
+	})
+}
+
+// JoinMeeting redeems a single-use join token minted by GetMeetingByRoomID
+// (or GetOrCreateMeetingRoom) for an Agora RTC token, gated on room state
+// and MaxParticipants rather than re-deriving ACL.
+func (h *MeetingHandler) JoinMeeting(c *gin.Context) {
+	roomID := c.Param("roomId")
+	if roomID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "roomId is required"})
+		return
+	}
+
+	var req struct {
+		JoinToken string `json:"joinToken" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "joinToken is required"})
+		return
+	}
+
+	sessionInfo, _ := middleware.GetCurrentUser(c)
+
+	result, err := h.meetingService.Join(roomID, req.JoinToken, sessionInfo)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrJoinTokenInvalid):
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Join token is invalid or has expired"})
+		case errors.Is(err, service.ErrRoomNotJoinable):
+			c.JSON(http.StatusNotFound, gin.H{"error": "Meeting room is no longer joinable"})
+		case errors.Is(err, service.ErrRoomFull):
+			c.JSON(http.StatusConflict, gin.H{"error": "Meeting room is full"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	expireSeconds := uint32(86400)
+	agoraToken, err := agora.GenerateRTCToken(
+		h.agoraClient.GetAppID(),
+		h.agoraClient.GetAppCertificate(),
+		result.ChannelName,
+		result.UID,
+		agora.RolePublisher,
+		expireSeconds,
+	)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to generate meeting token"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"agoraToken":  agoraToken,
+		"uid":         result.UID,
+		"channelName": result.ChannelName,
+		"expiresAt":   time.Now().Add(time.Duration(expireSeconds) * time.Second).Format(time.RFC3339),
+	})
+}
+
+// BackendCreateMeetingRequest represents a backend-initiated meeting creation request
+type BackendCreateMeetingRequest struct {
+	LoadID uint `json:"load_id" binding:"required"`
+}
+
+// CreateBackendMeeting creates or retrieves a meeting room on behalf of a
+// trusted backend dispatch system authenticated via BackendHMACMiddleware,
+// without requiring a user session.
+func (h *MeetingHandler) CreateBackendMeeting(c *gin.Context) {
+	var req BackendCreateMeetingRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "load_id is required"})
+		return
+	}
+
+	meetingRoom, err := h.meetingService.GetOrCreateMeetingRoom(req.LoadID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"room":    meetingRoom,
+	})
+}
+
+// BackendEndMeetingRequest represents a backend-initiated request to end the meeting for a load
+type BackendEndMeetingRequest struct {
+	LoadID uint `json:"load_id" binding:"required"`
+}
+
+// EndBackendMeeting ends the active meeting room for a load on behalf of a
+// trusted backend dispatch system authenticated via BackendHMACMiddleware.
+func (h *MeetingHandler) EndBackendMeeting(c *gin.Context) {
+	var req BackendEndMeetingRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "load_id is required"})
+		return
+	}
+
+	if err := h.meetingService.EndMeetingForLoad(req.LoadID); err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Meeting room not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Meeting room ended",
 	})
 }
 
@@ -80,7 +225,8 @@ type EndMeetingRequest struct {
 	RoomID string `json:"roomId" binding:"required"`
 }
 
-// EndMeeting ends a meeting room
+// EndMeeting ends a meeting room, gated on the caller being a joined
+// member of the room.
 func (h *MeetingHandler) EndMeeting(c *gin.Context) {
 	var req EndMeetingRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
@@ -88,6 +234,17 @@ func (h *MeetingHandler) EndMeeting(c *gin.Context) {
 		return
 	}
 
+	user, ok := middleware.GetCurrentUser(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	if _, err := h.aclService.CheckAccess(req.RoomID, user.UserType, user.UserID); err != nil {
+		writeMeetingACLError(c, err)
+		return
+	}
+
 	if err := h.meetingService.EndMeeting(req.RoomID); err != nil {
 		c.JSON(http.StatusNotFound, gin.H{"error": "Meeting room not found"})
 		return
@@ -98,3 +255,48 @@ func (h *MeetingHandler) EndMeeting(c *gin.Context) {
 		"message": "Meeting room ended",
 	})
 }
+
+// InviteParticipantRequest represents an admin request to grant a driver or
+// guest access to a meeting room
+type InviteParticipantRequest struct {
+	RoomID      string `json:"roomId" binding:"required"`
+	DriverID    uint   `json:"driver_id"`
+	PhoneNumber string `json:"phone_number"`
+	Role        string `json:"role"`
+}
+
+// InviteParticipant grants an additional driver or guest access to a
+// meeting room. Admin only.
+func (h *MeetingHandler) InviteParticipant(c *gin.Context) {
+	var req InviteParticipantRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "roomId is required"})
+		return
+	}
+
+	if req.DriverID == 0 && req.PhoneNumber == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "driver_id or phone_number is required"})
+		return
+	}
+
+	role := req.Role
+	if role == "" {
+		role = "guest"
+	}
+
+	user, ok := middleware.GetCurrentUser(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	if err := h.aclService.InviteParticipant(req.RoomID, req.DriverID, req.PhoneNumber, role, user.UserID); err != nil {
+		writeMeetingACLError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Participant invited",
+	})
+}
@@ -0,0 +1,106 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/securedlinq/backend/internal/middleware"
+	"github.com/securedlinq/backend/internal/models"
+	"github.com/securedlinq/backend/internal/service"
+)
+
+// MeetingRoomV2 is the v2 response shape for models.MeetingRoom. v1 mixes
+// camelCase ("roomId", "channelName", "lastJoinedAt") with snake_case
+// ("load_number", "save_type"), depending on when each column was added; v2
+// normalizes every field to snake_case.
+type MeetingRoomV2 struct {
+	ID                 uint    `json:"id"`
+	LoadID             uint    `json:"load_id"`
+	RoomID             string  `json:"room_id"`
+	ChannelName        string  `json:"channel_name"`
+	MeetingLink        string  `json:"meeting_link"`
+	LoadNumber         *string `json:"load_number,omitempty"`
+	SaveType           *string `json:"save_type,omitempty"`
+	Status             string  `json:"status"`
+	MaxParticipants    int     `json:"max_participants"`
+	CreatedAt          string  `json:"created_at"`
+	LastJoinedAt       *string `json:"last_joined_at,omitempty"`
+	JoinToken          string  `json:"join_token,omitempty"`
+	JoinTokenExpiresAt *string `json:"join_token_expires_at,omitempty"`
+}
+
+// toMeetingRoomV2 converts a models.MeetingRoom to its v2 shape.
+func toMeetingRoomV2(room *models.MeetingRoom) MeetingRoomV2 {
+	v2 := MeetingRoomV2{
+		ID:              room.ID,
+		LoadID:          room.LoadID,
+		RoomID:          room.RoomID,
+		ChannelName:     room.ChannelName,
+		MeetingLink:     room.MeetingLink,
+		Status:          room.Status,
+		MaxParticipants: room.MaxParticipants,
+		CreatedAt:       room.CreatedAt.Format(time.RFC3339),
+	}
+	if room.LoadNumber.Valid {
+		v2.LoadNumber = &room.LoadNumber.String
+	}
+	if room.SaveType.Valid {
+		v2.SaveType = &room.SaveType.String
+	}
+	if room.LastJoinedAt.Valid {
+		formatted := room.LastJoinedAt.Time.Format(time.RFC3339)
+		v2.LastJoinedAt = &formatted
+	}
+	return v2
+}
+
+// GetMeetingByRoomIDV2 is the v2 counterpart of GetMeetingByRoomID: the same
+// ACL check and service calls, but responding with the {data, error}
+// envelope and a MeetingRoomV2 instead of the raw, inconsistently-cased
+// model.
+func (h *MeetingHandler) GetMeetingByRoomIDV2(c *gin.Context) {
+	roomID := c.Query("roomId")
+	if roomID == "" || roomID == "undefined" || roomID == "null" {
+		RespondError(c, http.StatusBadRequest, "invalid_room_id", "roomId is required", nil)
+		return
+	}
+
+	user, ok := middleware.GetCurrentUser(c)
+	if !ok {
+		RespondError(c, http.StatusUnauthorized, "unauthorized", "Unauthorized", nil)
+		return
+	}
+
+	meetingRoom, err := h.aclService.CheckAccess(roomID, user.UserType, user.UserID)
+	if err != nil {
+		writeMeetingACLErrorV2(c, err)
+		return
+	}
+
+	// Update last joined timestamp
+	_ = h.meetingService.UpdateLastJoined(roomID)
+
+	v2 := toMeetingRoomV2(meetingRoom)
+	if joinToken, err := h.meetingService.IssueJoinToken(meetingRoom); err == nil {
+		v2.JoinToken = joinToken.Token
+		expiresAt := joinToken.ExpiresAt.Format(time.RFC3339)
+		v2.JoinTokenExpiresAt = &expiresAt
+	}
+
+	RespondData(c, http.StatusOK, v2)
+}
+
+// writeMeetingACLErrorV2 is writeMeetingACLError's v2 counterpart, using the
+// {error: {code, message}} envelope instead of v1's {errcode, error}.
+func writeMeetingACLErrorV2(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, service.ErrMeetingForbidden):
+		RespondError(c, http.StatusForbidden, "FORBIDDEN", "You do not have access to this meeting room", nil)
+	case errors.Is(err, service.ErrMeetingNotJoined):
+		RespondError(c, http.StatusNotFound, "NOT_JOINED", "Link Invalid or Expired. This meeting link has been invalidated. Please request a new link from the admin.", nil)
+	default:
+		RespondError(c, http.StatusInternalServerError, "internal_error", err.Error(), nil)
+	}
+}
@@ -0,0 +1,69 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/securedlinq/backend/internal/models"
+	"github.com/securedlinq/backend/internal/notification"
+)
+
+// MeetingInviteSender queues a meeting-invite message across a driver's
+// enabled channels. *notification.Service implements this.
+type MeetingInviteSender interface {
+	SendMeetingInvite(driverID uint, data notification.MeetingInviteData, channels []string) ([]models.CourierMessage, error)
+}
+
+// NotificationHandler handles notification HTTP requests
+type NotificationHandler struct {
+	notification MeetingInviteSender
+}
+
+// NewNotificationHandler creates a new notification handler.
+func NewNotificationHandler(notificationService MeetingInviteSender) *NotificationHandler {
+	return &NotificationHandler{notification: notificationService}
+}
+
+// MeetingInviteRequest represents a meeting invite notification request.
+// Channels is optional; an empty list fans out to every channel the driver
+// hasn't disabled (see notification.Service.SendMeetingInvite).
+type MeetingInviteRequest struct {
+	DriverID    uint     `json:"driverId" binding:"required"`
+	DriverName  string   `json:"driverName" binding:"required"`
+	MeetingLink string   `json:"meetingLink" binding:"required"`
+	LoadNumber  string   `json:"loadNumber" binding:"required"`
+	Channels    []string `json:"channels,omitempty"`
+}
+
+// SendMeetingInvite queues a meeting invite across the requested (or
+// driver-configured) notification channels and returns immediately; it
+// supersedes EmailHandler.SendMeetingLink, which only ever queued SMTP.
+func (h *NotificationHandler) SendMeetingInvite(c *gin.Context) {
+	var req MeetingInviteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "driverId, driverName, meetingLink, and loadNumber are required"})
+		return
+	}
+
+	queued, err := h.notification.SendMeetingInvite(req.DriverID, notification.MeetingInviteData{
+		DriverName:  req.DriverName,
+		LoadNumber:  req.LoadNumber,
+		MeetingLink: req.MeetingLink,
+	}, req.Channels)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("Failed to queue meeting invite: %v", err)})
+		return
+	}
+
+	channels := make([]string, 0, len(queued))
+	for _, msg := range queued {
+		channels = append(channels, msg.Channel)
+	}
+
+	c.JSON(http.StatusAccepted, gin.H{
+		"success":  true,
+		"message":  "Meeting invite queued for delivery",
+		"channels": channels,
+	})
+}
@@ -0,0 +1,183 @@
+package handler
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/securedlinq/backend/internal/config"
+	"github.com/securedlinq/backend/internal/middleware"
+	"github.com/securedlinq/backend/internal/service"
+)
+
+// oidcStateCookie and oidcVerifierCookie hold the PKCE state/code_verifier
+// between the redirect to the identity provider and its callback. They are
+// short-lived and HttpOnly, set on path "/", and cleared once the callback
+// has checked them.
+const (
+	oidcStateCookie    = "oidc_state"
+	oidcVerifierCookie = "oidc_verifier"
+	oidcFlowMaxAge     = 10 * 60 // seconds
+)
+
+// OIDCHandler drives the OIDC/OAuth2 authorization-code flow: redirecting to
+// the identity provider with state/PKCE, and handling its callback by
+// exchanging the code, fetching userinfo, and delegating to the matching
+// OAuthProvider to establish a session.
+type OIDCHandler struct {
+	authService *service.AuthService
+	config      *config.Config
+}
+
+// NewOIDCHandler creates a new OIDC handler.
+func NewOIDCHandler(authService *service.AuthService, cfg *config.Config) *OIDCHandler {
+	return &OIDCHandler{authService: authService, config: cfg}
+}
+
+// Login redirects to the named identity provider's authorization endpoint,
+// e.g. GET /auth/oidc/google/login.
+func (h *OIDCHandler) Login(c *gin.Context) {
+	name := c.Param("provider")
+
+	oauthProvider, err := h.authService.Providers().OAuthProvider(name)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Unknown identity provider"})
+		return
+	}
+
+	oidcProvider, ok := oauthProvider.(*service.OIDCProvider)
+	if !ok {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Unknown identity provider"})
+		return
+	}
+
+	state, err := randomURLSafeString(32)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to start login"})
+		return
+	}
+	verifier, err := randomURLSafeString(32)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to start login"})
+		return
+	}
+
+	c.SetCookie(oidcStateCookie, state, oidcFlowMaxAge, "/", "", h.config.Session.Secure, true)
+	c.SetCookie(oidcVerifierCookie, verifier, oidcFlowMaxAge, "/", "", h.config.Session.Secure, true)
+
+	c.Redirect(http.StatusFound, oidcProvider.AuthorizationURL(state, codeChallenge(verifier)))
+}
+
+// Callback completes the authorization-code flow for the named identity
+// provider, e.g. GET /auth/oidc/google/callback?code=...&state=....
+func (h *OIDCHandler) Callback(c *gin.Context) {
+	name := c.Param("provider")
+
+	oauthProvider, err := h.authService.Providers().OAuthProvider(name)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Unknown identity provider"})
+		return
+	}
+
+	oidcProvider, ok := oauthProvider.(*service.OIDCProvider)
+	if !ok {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Unknown identity provider"})
+		return
+	}
+
+	code := c.Query("code")
+	state := c.Query("state")
+	if code == "" || state == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Missing code or state"})
+		return
+	}
+
+	expectedState, err := c.Cookie(oidcStateCookie)
+	if err != nil || expectedState == "" || expectedState != state {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid or expired login attempt"})
+		return
+	}
+
+	verifier, err := c.Cookie(oidcVerifierCookie)
+	if err != nil || verifier == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid or expired login attempt"})
+		return
+	}
+
+	c.SetCookie(oidcStateCookie, "", -1, "/", "", h.config.Session.Secure, true)
+	c.SetCookie(oidcVerifierCookie, "", -1, "/", "", h.config.Session.Secure, true)
+
+	accessToken, err := oidcProvider.ExchangeCode(c.Request.Context(), code, verifier)
+	if err != nil {
+		c.JSON(http.StatusBadGateway, gin.H{"error": "Failed to complete login with identity provider"})
+		return
+	}
+
+	claims, err := oidcProvider.FetchUserInfo(c.Request.Context(), accessToken)
+	if err != nil {
+		c.JSON(http.StatusBadGateway, gin.H{"error": "Failed to complete login with identity provider"})
+		return
+	}
+
+	subject, _ := claims["sub"].(string)
+	if subject == "" {
+		c.JSON(http.StatusBadGateway, gin.H{"error": "Identity provider did not return a subject claim"})
+		return
+	}
+
+	sessionInfo, err := oidcProvider.AttemptLogin(subject, claims)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrAccountDeactivated):
+			c.JSON(http.StatusForbidden, gin.H{"error": "Your account has been deactivated. Please contact your administrator."})
+		case errors.Is(err, service.ErrOIDCEmailMissing):
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Identity provider did not return an email claim"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to complete login"})
+		}
+		return
+	}
+
+	c.SetSameSite(h.getSameSite())
+	c.SetCookie(
+		middleware.SessionCookieName,
+		sessionInfo.SessionID,
+		h.config.Session.MaxAge,
+		"/",
+		"",
+		h.config.Session.Secure,
+		true, // HttpOnly
+	)
+
+	c.Redirect(http.StatusFound, h.config.Server.FrontendURL)
+}
+
+func (h *OIDCHandler) getSameSite() http.SameSite {
+	switch h.config.Session.SameSite {
+	case "strict":
+		return http.SameSiteStrictMode
+	case "none":
+		return http.SameSiteNoneMode
+	default:
+		return http.SameSiteLaxMode
+	}
+}
+
+// randomURLSafeString generates a random base64url-encoded string from n
+// random bytes, used for OIDC state and PKCE code_verifier values.
+func randomURLSafeString(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// codeChallenge derives the PKCE S256 code_challenge for a code_verifier.
+func codeChallenge(verifier string) string {
+	sum := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
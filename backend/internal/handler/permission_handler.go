@@ -0,0 +1,72 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/securedlinq/backend/internal/service"
+)
+
+// PermissionHandler handles admin management of the role_permissions grant
+// table backing middleware.RequirePermission.
+type PermissionHandler struct {
+	policyService *service.PolicyService
+}
+
+// NewPermissionHandler creates a new permission handler
+func NewPermissionHandler(policyService *service.PolicyService) *PermissionHandler {
+	return &PermissionHandler{policyService: policyService}
+}
+
+// GetRolePermissions lists every permission granted to a role (admin only)
+func (h *PermissionHandler) GetRolePermissions(c *gin.Context) {
+	role := c.Param("role")
+
+	grants, err := h.policyService.GetRolePermissions(role)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":     true,
+		"role":        role,
+		"permissions": grants,
+	})
+}
+
+// GrantPermissionRequest represents a request to grant a permission to a role
+type GrantPermissionRequest struct {
+	Permission string `json:"permission" binding:"required"`
+}
+
+// GrantPermission grants a permission to a role (admin only)
+func (h *PermissionHandler) GrantPermission(c *gin.Context) {
+	role := c.Param("role")
+
+	var req GrantPermissionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "permission is required"})
+		return
+	}
+
+	if err := h.policyService.GrantPermission(role, req.Permission); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"success": true, "message": "Permission granted"})
+}
+
+// RevokePermission revokes a permission from a role (admin only)
+func (h *PermissionHandler) RevokePermission(c *gin.Context) {
+	role := c.Param("role")
+	permission := c.Param("permission")
+
+	if err := h.policyService.RevokePermission(role, permission); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"success": true, "message": "Permission revoked"})
+}
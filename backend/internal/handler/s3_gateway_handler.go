@@ -0,0 +1,129 @@
+package handler
+
+import (
+	"encoding/xml"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/securedlinq/backend/pkg/s3gateway"
+)
+
+// listBucketResult mirrors just enough of S3's ListObjectsV2 XML response
+// shape for a generic S3 SDK client to parse - Name/Prefix/KeyCount plus one
+// Contents entry per object.
+type listBucketResult struct {
+	XMLName     xml.Name           `xml:"http://s3.amazonaws.com/doc/2006-03-01/ ListBucketResult"`
+	Name        string             `xml:"Name"`
+	Prefix      string             `xml:"Prefix"`
+	KeyCount    int                `xml:"KeyCount"`
+	IsTruncated bool               `xml:"IsTruncated"`
+	Contents    []listBucketObject `xml:"Contents"`
+}
+
+type listBucketObject struct {
+	Key          string `xml:"Key"`
+	Size         int64  `xml:"Size"`
+	LastModified string `xml:"LastModified"`
+}
+
+// ListObjectsV2 answers GET /s3/ the way S3 would for a bucket scoped to the
+// authenticated driver's own gallery objects.
+func (h *MediaHandler) ListObjectsV2(c *gin.Context) {
+	if h.s3Gateway == nil {
+		c.XML(http.StatusServiceUnavailable, gin.H{"error": "S3 gateway is not configured"})
+		return
+	}
+
+	driver, err := h.s3Gateway.Authenticate(c.Request)
+	if err != nil {
+		h.writeGatewayAuthError(c, err)
+		return
+	}
+
+	objects, err := h.s3Gateway.ListObjects(driver.ID)
+	if err != nil {
+		c.XML(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	result := listBucketResult{
+		Name:     "securedlinq-gallery",
+		Prefix:   c.Query("prefix"),
+		KeyCount: len(objects),
+	}
+	for _, obj := range objects {
+		result.Contents = append(result.Contents, listBucketObject{
+			Key:          obj.Key,
+			Size:         obj.Size,
+			LastModified: obj.LastModified,
+		})
+	}
+
+	c.XML(http.StatusOK, result)
+}
+
+// GetObject answers GET /s3/:key by redirecting (307) to a short-lived
+// presigned URL against the real bucket, after confirming the authenticated
+// driver is allowed to see key.
+func (h *MediaHandler) GetObject(c *gin.Context) {
+	if h.s3Gateway == nil {
+		c.XML(http.StatusServiceUnavailable, gin.H{"error": "S3 gateway is not configured"})
+		return
+	}
+
+	driver, err := h.s3Gateway.Authenticate(c.Request)
+	if err != nil {
+		h.writeGatewayAuthError(c, err)
+		return
+	}
+
+	url, err := h.s3Gateway.ResolveObject(driver.ID, c.Param("key"))
+	if err != nil {
+		h.writeGatewayObjectError(c, err)
+		return
+	}
+
+	c.Redirect(http.StatusTemporaryRedirect, url)
+}
+
+// HeadObject answers HEAD /s3/:key with the object's size, without
+// redirecting, for clients that just want to check existence/size.
+func (h *MediaHandler) HeadObject(c *gin.Context) {
+	if h.s3Gateway == nil {
+		c.Status(http.StatusServiceUnavailable)
+		return
+	}
+
+	driver, err := h.s3Gateway.Authenticate(c.Request)
+	if err != nil {
+		h.writeGatewayAuthError(c, err)
+		return
+	}
+
+	size, err := h.s3Gateway.HeadObject(driver.ID, c.Param("key"))
+	if err != nil {
+		h.writeGatewayObjectError(c, err)
+		return
+	}
+
+	c.Header("Content-Length", strconv.FormatInt(size, 10))
+	c.Status(http.StatusOK)
+}
+
+func (h *MediaHandler) writeGatewayAuthError(c *gin.Context, err error) {
+	status := http.StatusUnauthorized
+	if errors.Is(err, s3gateway.ErrKeyNotFound) {
+		status = http.StatusForbidden
+	}
+	c.XML(status, gin.H{"error": err.Error()})
+}
+
+func (h *MediaHandler) writeGatewayObjectError(c *gin.Context, err error) {
+	status := http.StatusInternalServerError
+	if errors.Is(err, s3gateway.ErrObjectNotFound) {
+		status = http.StatusNotFound
+	}
+	c.XML(status, gin.H{"error": err.Error()})
+}
@@ -0,0 +1,150 @@
+// Package mailer wraps net/smtp to send outbound email on behalf of the
+// handler package, tagging failures with the SMTP stage they occurred at
+// (dial, auth, MAIL FROM, RCPT TO, DATA) instead of returning net/smtp's
+// opaque textproto errors.
+package mailer
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net/smtp"
+
+	"github.com/securedlinq/backend/internal/config"
+)
+
+// Stage identifies the step of an SMTP conversation a Send call failed at.
+type Stage string
+
+const (
+	StageDial     Stage = "dial"
+	StageAuth     Stage = "auth"
+	StageMailFrom Stage = "mail_from"
+	StageRcptTo   Stage = "rcpt_to"
+	StageData     Stage = "data"
+)
+
+// StageError reports an SMTP failure tagged with the stage it occurred at,
+// so callers (e.g. the admin SMTP test endpoint) can tell operators exactly
+// where delivery broke.
+type StageError struct {
+	Stage Stage
+	Err   error
+}
+
+func (e *StageError) Error() string {
+	return fmt.Sprintf("smtp %s stage failed: %v", e.Stage, e.Err)
+}
+
+func (e *StageError) Unwrap() error {
+	return e.Err
+}
+
+// Client sends email over SMTP using a config.EmailConfig.
+type Client struct {
+	cfg *config.EmailConfig
+}
+
+// NewClient creates a new mailer Client.
+func NewClient(cfg *config.EmailConfig) *Client {
+	return &Client{cfg: cfg}
+}
+
+// Send delivers a single HTML email, returning a *StageError identifying
+// the failed SMTP stage if delivery does not complete.
+func (c *Client) Send(to, subject, body string) error {
+	addr := c.cfg.SMTPHost + ":" + c.cfg.SMTPPort
+
+	var conn *smtp.Client
+	if c.cfg.SMTPConnectionSecurity == "tls" {
+		tlsConn, err := tls.Dial("tcp", addr, c.tlsConfig())
+		if err != nil {
+			return &StageError{Stage: StageDial, Err: err}
+		}
+		conn, err = smtp.NewClient(tlsConn, c.cfg.SMTPHost)
+		if err != nil {
+			return &StageError{Stage: StageDial, Err: err}
+		}
+	} else {
+		var err error
+		conn, err = smtp.Dial(addr)
+		if err != nil {
+			return &StageError{Stage: StageDial, Err: err}
+		}
+	}
+	defer conn.Close()
+
+	if err := conn.Hello(c.cfg.SMTPHost); err != nil {
+		return &StageError{Stage: StageDial, Err: err}
+	}
+
+	// "none" is only for local capture servers (mailtest) that don't speak
+	// TLS at all; "tls" already wrapped the connection above.
+	if c.cfg.SMTPConnectionSecurity == "" || c.cfg.SMTPConnectionSecurity == "starttls" {
+		if ok, _ := conn.Extension("STARTTLS"); ok {
+			if err := conn.StartTLS(c.tlsConfig()); err != nil {
+				return &StageError{Stage: StageDial, Err: err}
+			}
+		}
+	}
+
+	if ok, _ := conn.Extension("AUTH"); ok {
+		auth := smtp.PlainAuth("", c.cfg.SenderEmail, c.cfg.AppPassword, c.cfg.SMTPHost)
+		if err := conn.Auth(auth); err != nil {
+			return &StageError{Stage: StageAuth, Err: err}
+		}
+	}
+
+	if err := conn.Mail(c.cfg.SenderEmail); err != nil {
+		return &StageError{Stage: StageMailFrom, Err: err}
+	}
+
+	if err := conn.Rcpt(to); err != nil {
+		return &StageError{Stage: StageRcptTo, Err: err}
+	}
+
+	w, err := conn.Data()
+	if err != nil {
+		return &StageError{Stage: StageData, Err: err}
+	}
+
+	if _, err := w.Write([]byte(buildMessage(c.cfg, to, subject, body))); err != nil {
+		return &StageError{Stage: StageData, Err: err}
+	}
+
+	// Close must happen (and succeed) before Quit: it's what sends the
+	// DATA section's terminating "." and waits for the 250 reply. Relying
+	// on a deferred Close here would send QUIT first and leave the DATA
+	// conversation unfinished.
+	if err := w.Close(); err != nil {
+		return &StageError{Stage: StageData, Err: err}
+	}
+
+	return conn.Quit()
+}
+
+// tlsConfig builds the *tls.Config used for both implicit TLS and
+// STARTTLS, honoring SkipServerCertificateVerification for test fixtures.
+func (c *Client) tlsConfig() *tls.Config {
+	return &tls.Config{
+		ServerName:         c.cfg.SMTPHost,
+		InsecureSkipVerify: c.cfg.SkipServerCertificateVerification,
+	}
+}
+
+func buildMessage(cfg *config.EmailConfig, to, subject, body string) string {
+	headers := map[string]string{
+		"From":         fmt.Sprintf("%s <%s>", cfg.SenderName, cfg.SenderEmail),
+		"To":           to,
+		"Subject":      subject,
+		"MIME-Version": "1.0",
+		"Content-Type": "text/html; charset=UTF-8",
+	}
+
+	message := ""
+	for k, v := range headers {
+		message += fmt.Sprintf("%s: %s\r\n", k, v)
+	}
+	message += "\r\n" + body
+
+	return message
+}
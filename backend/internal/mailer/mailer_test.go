@@ -0,0 +1,35 @@
+package mailer
+
+import (
+	"errors"
+	"net"
+	"testing"
+
+	"github.com/securedlinq/backend/internal/config"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestSendTagsDialFailure verifies that a connection failure (an address
+// nothing is listening on) is reported as a dial-stage StageError, not a
+// raw net error, so operators can distinguish it from an auth/delivery
+// failure without reading Go's net/smtp internals.
+func TestSendTagsDialFailure(t *testing.T) {
+	cfg := &config.EmailConfig{
+		SMTPHost:    "127.0.0.1",
+		SMTPPort:    "1", // nothing listens on port 1
+		SenderEmail: "sender@example.com",
+		SenderName:  "SecuredLinQ",
+		AppPassword: "app-password-123",
+	}
+
+	err := NewClient(cfg).Send("driver@example.com", "subject", "body")
+	require := assert.New(t)
+	require.Error(err)
+
+	var stageErr *StageError
+	require.True(errors.As(err, &stageErr), "expected a *StageError, got %T", err)
+	require.Equal(StageDial, stageErr.Stage)
+
+	var netErr net.Error
+	require.True(errors.As(stageErr.Err, &netErr), "expected the wrapped error to be a net.Error")
+}
@@ -0,0 +1,206 @@
+// Package mailtest provides a disposable, in-process SMTP capture server
+// for tests. It accepts any mail conversation and files the result into
+// per-recipient mailboxes instead of delivering it, exposing the same
+// ListMailbox/GetMessage/DeleteMailbox shape as Inbucket's
+// /api/v1/mailbox/{addr} API - as Go methods rather than over HTTP, since
+// this package only needs to serve this repo's own test suite.
+package mailtest
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"net/mail"
+	"strings"
+	"sync"
+)
+
+// Message is a captured SMTP message. The envelope recipient (From/To) is
+// recorded separately from the parsed *mail.Message since the two can
+// legitimately differ (e.g. BCC).
+type Message struct {
+	ID   string
+	From string
+	To   string
+	Raw  []byte
+	*mail.Message
+}
+
+// Server is a disposable SMTP server that captures every message it
+// receives instead of delivering it.
+type Server struct {
+	listener net.Listener
+	addr     string
+
+	mu        sync.Mutex
+	mailboxes map[string][]*Message
+	nextID    int
+}
+
+// Start starts a Server listening on an OS-assigned localhost port.
+func Start() (*Server, error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, err
+	}
+
+	s := &Server{
+		listener:  listener,
+		addr:      listener.Addr().String(),
+		mailboxes: make(map[string][]*Message),
+	}
+
+	go s.serve()
+
+	return s, nil
+}
+
+// Addr returns the "host:port" the server is listening on.
+func (s *Server) Addr() string {
+	return s.addr
+}
+
+// Close stops the server from accepting further connections.
+func (s *Server) Close() error {
+	return s.listener.Close()
+}
+
+// ListMailbox returns the messages captured for addr, oldest first,
+// mirroring Inbucket's GET /api/v1/mailbox/{addr}.
+func (s *Server) ListMailbox(addr string) []*Message {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]*Message(nil), s.mailboxes[addr]...)
+}
+
+// GetMessage returns the message with the given ID from addr's mailbox,
+// mirroring Inbucket's GET /api/v1/mailbox/{addr}/{id}.
+func (s *Server) GetMessage(addr, id string) (*Message, bool) {
+	for _, m := range s.ListMailbox(addr) {
+		if m.ID == id {
+			return m, true
+		}
+	}
+	return nil, false
+}
+
+// DeleteMailbox discards all captured messages for addr, mirroring
+// Inbucket's DELETE /api/v1/mailbox/{addr}.
+func (s *Server) DeleteMailbox(addr string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.mailboxes, addr)
+}
+
+func (s *Server) serve() {
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+		go s.handleConn(conn)
+	}
+}
+
+// handleConn speaks just enough SMTP (EHLO/HELO, MAIL FROM, RCPT TO, DATA,
+// RSET, QUIT) to satisfy net/smtp's client - no STARTTLS/AUTH extensions
+// are advertised, so mailer.Client talks to it in plain text with
+// SMTPConnectionSecurity "none" (or the default, which only upgrades when
+// STARTTLS is advertised); "tls" cannot connect to it.
+func (s *Server) handleConn(conn net.Conn) {
+	defer conn.Close()
+
+	r := bufio.NewReader(conn)
+	w := bufio.NewWriter(conn)
+	reply := func(code int, msg string) {
+		fmt.Fprintf(w, "%d %s\r\n", code, msg)
+		w.Flush()
+	}
+
+	reply(220, "mailtest ready")
+
+	var from string
+	var recipients []string
+
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return
+		}
+		line = strings.TrimRight(line, "\r\n")
+		upper := strings.ToUpper(line)
+
+		switch {
+		case strings.HasPrefix(upper, "HELO"), strings.HasPrefix(upper, "EHLO"):
+			reply(250, "mailtest")
+		case strings.HasPrefix(upper, "MAIL FROM:"):
+			from = extractAddr(line)
+			reply(250, "OK")
+		case strings.HasPrefix(upper, "RCPT TO:"):
+			recipients = append(recipients, extractAddr(line))
+			reply(250, "OK")
+		case upper == "DATA":
+			reply(354, "Start mail input; end with <CRLF>.<CRLF>")
+			raw, err := readDotTerminated(r)
+			if err != nil {
+				return
+			}
+			s.deliver(from, recipients, raw)
+			reply(250, "OK: queued")
+			from = ""
+			recipients = nil
+		case upper == "RSET":
+			from = ""
+			recipients = nil
+			reply(250, "OK")
+		case upper == "QUIT":
+			reply(221, "Bye")
+			return
+		default:
+			reply(500, "unrecognized command")
+		}
+	}
+}
+
+func extractAddr(line string) string {
+	start := strings.Index(line, "<")
+	end := strings.Index(line, ">")
+	if start == -1 || end == -1 || end < start {
+		return ""
+	}
+	return line[start+1 : end]
+}
+
+// readDotTerminated reads SMTP DATA content up to the terminating "." line.
+func readDotTerminated(r *bufio.Reader) ([]byte, error) {
+	var buf strings.Builder
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return nil, err
+		}
+		if strings.TrimRight(line, "\r\n") == "." {
+			break
+		}
+		buf.WriteString(line)
+	}
+	return []byte(buf.String()), nil
+}
+
+func (s *Server) deliver(from string, recipients []string, raw []byte) {
+	parsed, _ := mail.ReadMessage(strings.NewReader(string(raw)))
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, to := range recipients {
+		s.nextID++
+		s.mailboxes[to] = append(s.mailboxes[to], &Message{
+			ID:      fmt.Sprintf("%d", s.nextID),
+			From:    from,
+			To:      to,
+			Raw:     raw,
+			Message: parsed,
+		})
+	}
+}
@@ -0,0 +1,30 @@
+package middleware
+
+import "github.com/gin-gonic/gin"
+
+// APIVersion identifies a mounted API generation, following the
+// Mattermost APIv4-style split between /api/v1 and /api/v2.
+type APIVersion string
+
+const (
+	APIVersionV1 APIVersion = "v1"
+	APIVersionV2 APIVersion = "v2"
+)
+
+// v1Sunset is the date v1 stops being served, surfaced via the Sunset header
+// (RFC 8594) now that v2 exists.
+const v1Sunset = "Fri, 31 Jul 2026 00:00:00 GMT"
+
+// APIVersionMiddleware stamps every response with X-API-Version, and for v1
+// additionally marks it deprecated per RFC 8594 so clients know to migrate
+// to v2 before the Sunset date.
+func APIVersionMiddleware(version APIVersion) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Header("X-API-Version", string(version))
+		if version == APIVersionV1 {
+			c.Header("Deprecation", "true")
+			c.Header("Sunset", v1Sunset)
+		}
+		c.Next()
+	}
+}
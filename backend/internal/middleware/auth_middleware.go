@@ -2,6 +2,7 @@ package middleware
 
 import (
 	"net/http"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 	"github.com/securedlinq/backend/internal/service"
@@ -10,11 +11,28 @@ import (
 const (
 	SessionCookieName = "session_id"
 	ContextUserKey    = "user"
+	bearerTokenPrefix = "Bearer "
 )
 
-// AuthMiddleware creates authentication middleware
+// AuthMiddleware creates authentication middleware. It checks for an
+// `Authorization: Bearer ...` API token first (for mobile/native clients and
+// automation), falling back to the `session_id` cookie so existing handlers
+// keep working unchanged off the same *service.SessionInfo in context.
 func AuthMiddleware(authService *service.AuthService) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		if rawToken, ok := bearerToken(c); ok {
+			sessionInfo, err := authService.ValidateAPIToken(rawToken)
+			if err != nil {
+				c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid or revoked API token"})
+				c.Abort()
+				return
+			}
+
+			c.Set(ContextUserKey, sessionInfo)
+			c.Next()
+			return
+		}
+
 		// Get session cookie
 		sessionID, err := c.Cookie(SessionCookieName)
 		if err != nil || sessionID == "" {
@@ -41,6 +59,20 @@ func AuthMiddleware(authService *service.AuthService) gin.HandlerFunc {
 	}
 }
 
+// bearerToken extracts the raw token from an `Authorization: Bearer ...`
+// header, if present.
+func bearerToken(c *gin.Context) (string, bool) {
+	header := c.GetHeader("Authorization")
+	if !strings.HasPrefix(header, bearerTokenPrefix) {
+		return "", false
+	}
+	token := strings.TrimPrefix(header, bearerTokenPrefix)
+	if token == "" {
+		return "", false
+	}
+	return token, true
+}
+
 // AdminOnlyMiddleware ensures only admin users can access the route
 // This is a convenience wrapper around RequireRole(RoleAdmin)
 func AdminOnlyMiddleware() gin.HandlerFunc {
@@ -0,0 +1,147 @@
+package middleware
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/securedlinq/backend/internal/config"
+)
+
+// Headers used by the backend HMAC authentication scheme, modeled on the
+// backend auth used by standalone Nextcloud Talk signaling servers.
+const (
+	HeaderBackendID          = "X-Backend-ID"
+	HeaderSignalingRandom    = "Spreed-Signaling-Random"
+	HeaderSignalingTimestamp = "Spreed-Signaling-Timestamp"
+	HeaderSignalingChecksum  = "Spreed-Signaling-Checksum"
+
+	// ContextBackendIDKey is the gin context key the authenticated backend ID is stored under.
+	ContextBackendIDKey = "backend_id"
+
+	backendMaxClockSkew = 30 * time.Second
+	backendNonceTTL     = 5 * time.Minute
+)
+
+// backendNonceCache tracks recently-seen nonces so a captured request can't
+// be replayed within the validity window.
+type backendNonceCache struct {
+	mu   sync.Mutex
+	seen map[string]time.Time
+}
+
+func newBackendNonceCache() *backendNonceCache {
+	return &backendNonceCache{seen: make(map[string]time.Time)}
+}
+
+// seenBefore reports whether nonce was already recorded, and records it if not.
+func (c *backendNonceCache) seenBefore(nonce string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.evictLocked()
+
+	if _, ok := c.seen[nonce]; ok {
+		return true
+	}
+	c.seen[nonce] = time.Now()
+	return false
+}
+
+func (c *backendNonceCache) evictLocked() {
+	cutoff := time.Now().Add(-backendNonceTTL)
+	for nonce, seenAt := range c.seen {
+		if seenAt.Before(cutoff) {
+			delete(c.seen, nonce)
+		}
+	}
+}
+
+// BackendHMACMiddleware authenticates machine-to-machine requests from
+// trusted dispatch systems. The caller signs each request with a shared
+// secret selected via the X-Backend-ID header:
+//
+//	checksum = HMAC-SHA256(sharedSecret, random + ":" + timestamp + ":" + body)
+//
+// Requests with a stale timestamp (>30s skew) or a replayed random nonce
+// (cached for ~5 minutes) are rejected.
+func BackendHMACMiddleware(cfg *config.BackendConfig) gin.HandlerFunc {
+	nonces := newBackendNonceCache()
+
+	return func(c *gin.Context) {
+		backendID := c.GetHeader(HeaderBackendID)
+		secret, ok := cfg.Secrets[backendID]
+		if !ok || secret == "" {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "unknown backend"})
+			c.Abort()
+			return
+		}
+
+		random := c.GetHeader(HeaderSignalingRandom)
+		timestampStr := c.GetHeader(HeaderSignalingTimestamp)
+		checksum := c.GetHeader(HeaderSignalingChecksum)
+		if random == "" || timestampStr == "" || checksum == "" {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "missing signature headers"})
+			c.Abort()
+			return
+		}
+
+		timestamp, err := strconv.ParseInt(timestampStr, 10, 64)
+		if err != nil {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid timestamp"})
+			c.Abort()
+			return
+		}
+
+		skew := time.Since(time.Unix(timestamp, 0))
+		if skew < 0 {
+			skew = -skew
+		}
+		if skew > backendMaxClockSkew {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "timestamp outside allowed clock skew"})
+			c.Abort()
+			return
+		}
+
+		if nonces.seenBefore(random) {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "replayed request"})
+			c.Abort()
+			return
+		}
+
+		body, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "failed to read request body"})
+			c.Abort()
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		expected := backendChecksum(secret, random, timestampStr, body)
+		if !hmac.Equal([]byte(expected), []byte(checksum)) {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid checksum"})
+			c.Abort()
+			return
+		}
+
+		c.Set(ContextBackendIDKey, backendID)
+		c.Next()
+	}
+}
+
+func backendChecksum(secret, random, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(random))
+	mac.Write([]byte(":"))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte(":"))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,109 @@
+package middleware
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/securedlinq/backend/internal/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func signBackendRequest(secret, random, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(random))
+	mac.Write([]byte(":"))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte(":"))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func setupBackendHMACRouter(cfg *config.BackendConfig) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.POST("/backend/meetings", BackendHMACMiddleware(cfg), func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"success": true})
+	})
+	return r
+}
+
+func doBackendRequest(r *gin.Engine, backendID, random, timestamp, checksum string, body []byte) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/backend/meetings", bytes.NewReader(body))
+	req.Header.Set(HeaderBackendID, backendID)
+	req.Header.Set(HeaderSignalingRandom, random)
+	req.Header.Set(HeaderSignalingTimestamp, timestamp)
+	req.Header.Set(HeaderSignalingChecksum, checksum)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestBackendHMACMiddleware_ValidChecksum(t *testing.T) {
+	cfg := &config.BackendConfig{Secrets: map[string]string{"dispatcher-1": "s3cret"}}
+	r := setupBackendHMACRouter(cfg)
+
+	body := []byte(`{"load_id":1}`)
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	checksum := signBackendRequest("s3cret", "random-1", timestamp, body)
+
+	w := doBackendRequest(r, "dispatcher-1", "random-1", timestamp, checksum, body)
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestBackendHMACMiddleware_BadChecksum(t *testing.T) {
+	cfg := &config.BackendConfig{Secrets: map[string]string{"dispatcher-1": "s3cret"}}
+	r := setupBackendHMACRouter(cfg)
+
+	body := []byte(`{"load_id":1}`)
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+
+	w := doBackendRequest(r, "dispatcher-1", "random-2", timestamp, "deadbeef", body)
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+}
+
+func TestBackendHMACMiddleware_UnknownBackend(t *testing.T) {
+	cfg := &config.BackendConfig{Secrets: map[string]string{"dispatcher-1": "s3cret"}}
+	r := setupBackendHMACRouter(cfg)
+
+	body := []byte(`{"load_id":1}`)
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	checksum := signBackendRequest("s3cret", "random-3", timestamp, body)
+
+	w := doBackendRequest(r, "unknown", "random-3", timestamp, checksum, body)
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+}
+
+func TestBackendHMACMiddleware_ReplayedNonce(t *testing.T) {
+	cfg := &config.BackendConfig{Secrets: map[string]string{"dispatcher-1": "s3cret"}}
+	r := setupBackendHMACRouter(cfg)
+
+	body := []byte(`{"load_id":1}`)
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	checksum := signBackendRequest("s3cret", "random-4", timestamp, body)
+
+	w1 := doBackendRequest(r, "dispatcher-1", "random-4", timestamp, checksum, body)
+	assert.Equal(t, http.StatusOK, w1.Code)
+
+	w2 := doBackendRequest(r, "dispatcher-1", "random-4", timestamp, checksum, body)
+	assert.Equal(t, http.StatusUnauthorized, w2.Code)
+}
+
+func TestBackendHMACMiddleware_ClockSkew(t *testing.T) {
+	cfg := &config.BackendConfig{Secrets: map[string]string{"dispatcher-1": "s3cret"}}
+	r := setupBackendHMACRouter(cfg)
+
+	body := []byte(`{"load_id":1}`)
+	staleTimestamp := strconv.FormatInt(time.Now().Add(-5*time.Minute).Unix(), 10)
+	checksum := signBackendRequest("s3cret", "random-5", staleTimestamp, body)
+
+	w := doBackendRequest(r, "dispatcher-1", "random-5", staleTimestamp, checksum, body)
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+}
@@ -0,0 +1,94 @@
+package middleware
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/securedlinq/backend/internal/repository"
+)
+
+// IdempotencyKeyHeader is the client-supplied header IdempotencyMiddleware
+// keys a cached response on.
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+// idempotencyResponseWriter buffers a handler's response body alongside
+// writing it through, so IdempotencyMiddleware can persist exactly what
+// the caller received for replay on a repeated key.
+type idempotencyResponseWriter struct {
+	gin.ResponseWriter
+	body       *bytes.Buffer
+	statusCode int
+}
+
+func (w *idempotencyResponseWriter) Write(b []byte) (int, error) {
+	w.body.Write(b)
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *idempotencyResponseWriter) WriteHeader(statusCode int) {
+	w.statusCode = statusCode
+	w.ResponseWriter.WriteHeader(statusCode)
+}
+
+// IdempotencyMiddleware implements the Idempotency-Key header contract: a
+// request carrying the header gets its response cached against (caller,
+// key), so a client retrying after a flaky network replays the original
+// response instead of re-running a non-idempotent side effect (e.g.
+// AgoraHandler.StartRecording double-charging recording minutes, or
+// NotificationHandler.SendMeetingInvite double-sending an email). A request
+// without the header passes through unmodified - the header is opt-in, not
+// mandatory.
+//
+// repo.Reserve claims (scopeKey, key) under the table's unique constraint
+// before the handler runs, so two concurrent requests with the same key
+// can't both pass a check and both execute the side effect: the loser
+// either replays the winner's finished response or, if the winner hasn't
+// finished yet, is told the request is already in flight.
+//
+// The caller is scoped by session ID when a session is present (the
+// typical case behind AuthMiddleware), falling back to the client IP for
+// routes with no session, like the public Agora recording endpoints.
+func IdempotencyMiddleware(repo *repository.IdempotencyKeyRepository) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := c.GetHeader(IdempotencyKeyHeader)
+		if key == "" {
+			c.Next()
+			return
+		}
+
+		scopeKey := c.ClientIP()
+		if sessionInfo, ok := GetCurrentUser(c); ok {
+			scopeKey = sessionInfo.SessionID
+		}
+
+		cached, err := repo.Reserve(scopeKey, key)
+		if err != nil {
+			if errors.Is(err, repository.ErrIdempotencyKeyInFlight) {
+				c.JSON(http.StatusConflict, gin.H{"error": "A request with this Idempotency-Key is already being processed"})
+				c.Abort()
+				return
+			}
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to process idempotency key"})
+			c.Abort()
+			return
+		}
+		if cached != nil {
+			c.Data(cached.StatusCode, gin.MIMEJSON, []byte(cached.ResponseBody))
+			c.Abort()
+			return
+		}
+
+		writer := &idempotencyResponseWriter{ResponseWriter: c.Writer, body: &bytes.Buffer{}, statusCode: http.StatusOK}
+		c.Writer = writer
+
+		c.Next()
+
+		if c.IsAborted() {
+			return
+		}
+
+		_ = repo.Complete(scopeKey, key, writer.statusCode, writer.body.String())
+	}
+}
@@ -0,0 +1,80 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/securedlinq/backend/internal/service"
+)
+
+// Permission is a fine-grained "<resource>.<verb>" action string, checked
+// by RequirePermission against a role's grants in role_permissions (see
+// service.PolicyService) instead of the coarse admin/driver check
+// RequireRole(RoleAdmin) used to do.
+type Permission string
+
+const (
+	PermLoadsRead         Permission = "loads.read"
+	PermLoadsWrite        Permission = "loads.write"
+	PermLoadsAssign       Permission = "loads.assign"
+	PermMeetingsRecord    Permission = "meetings.record"
+	PermMeetingsEnd       Permission = "meetings.end"
+	PermMeetingsJoin      Permission = "meetings.join"
+	PermMeetingsInvite    Permission = "meetings.invite"
+	PermUsersManage       Permission = "users.manage"
+	PermAccessKeysManage  Permission = "accesskeys.manage"
+	PermCourierRead       Permission = "courier.read"
+	PermWebhooksManage    Permission = "webhooks.manage"
+	PermSystemDiagnostics Permission = "system.diagnostics"
+	PermClientLogsRead    Permission = "clientlogs.read"
+)
+
+// ScopeResolver loads the resource a request targets (typically from a URL
+// param) and reports whether session owns it. RequirePermission runs every
+// resolver for non-admin sessions after the role-level grant passes, so a
+// role can be granted a permission system-wide while still being confined
+// to its own resources (e.g. a driver granted meetings.join may only join
+// the meeting for a load they're assigned to).
+type ScopeResolver func(c *gin.Context, session *service.SessionInfo) bool
+
+// RequirePermission creates middleware requiring the caller's role to hold
+// perm, per policy. Admins bypass every resolver - resolvers exist to
+// narrow a non-admin role's access to resources it owns, not to further
+// restrict admins.
+func RequirePermission(policy *service.PolicyService, perm Permission, resolvers ...ScopeResolver) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		sessionInfo, ok := GetCurrentUser(c)
+		if !ok {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+			c.Abort()
+			return
+		}
+
+		granted, err := policy.HasPermission(sessionInfo.UserType, string(perm))
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to evaluate permissions"})
+			c.Abort()
+			return
+		}
+		if !granted {
+			c.JSON(http.StatusForbidden, gin.H{
+				"error":   "Insufficient permissions",
+				"message": string(perm) + " access required",
+			})
+			c.Abort()
+			return
+		}
+
+		if sessionInfo.UserType != string(RoleAdmin) {
+			for _, resolve := range resolvers {
+				if !resolve(c, sessionInfo) {
+					c.JSON(http.StatusForbidden, gin.H{"error": "Insufficient permissions"})
+					c.Abort()
+					return
+				}
+			}
+		}
+
+		c.Next()
+	}
+}
@@ -11,8 +11,9 @@ import (
 type Role string
 
 const (
-	RoleAdmin  Role = "admin"
-	RoleDriver Role = "driver"
+	RoleAdmin      Role = "admin"
+	RoleDispatcher Role = "dispatcher"
+	RoleDriver     Role = "driver"
 )
 
 // HasRole checks if the current user has the specified role
@@ -112,6 +113,11 @@ func IsAdmin(c *gin.Context) bool {
 	return HasRole(c, RoleAdmin)
 }
 
+// IsDispatcher checks if the current user is a dispatcher
+func IsDispatcher(c *gin.Context) bool {
+	return HasRole(c, RoleDispatcher)
+}
+
 // IsDriver checks if the current user is a driver
 func IsDriver(c *gin.Context) bool {
 	return HasRole(c, RoleDriver)
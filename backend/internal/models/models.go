@@ -8,35 +8,55 @@ import (
 
 // Driver represents registered drivers
 type Driver struct {
-	ID           uint           `gorm:"primaryKey;column:id" json:"id"`
-	Username     string         `gorm:"column:username;size:100;uniqueIndex;not null" json:"username"`
-	PasswordHash string         `gorm:"column:password_hash;size:255;not null" json:"-"`
-	PhoneNumber  string         `gorm:"column:phone_number;size:50;not null" json:"phone_number"`
-	FirstName    string         `gorm:"column:first_name;size:100" json:"first_name"`
-	LastName     string         `gorm:"column:last_name;size:100" json:"last_name"`
-	Email        sql.NullString `gorm:"column:email;size:255" json:"-"`
-	IsActive     bool           `gorm:"column:is_active;default:true" json:"is_active"`
-	CreatedAt    time.Time      `gorm:"column:created_at" json:"created_at"`
-	UpdatedAt    time.Time      `gorm:"column:updated_at" json:"updated_at"`
+	ID              uint           `gorm:"primaryKey;column:id" json:"id"`
+	Username        string         `gorm:"column:username;size:100;uniqueIndex;not null" json:"username"`
+	PasswordHash    string         `gorm:"column:password_hash;size:255;not null" json:"-"`
+	PhoneNumber     string         `gorm:"column:phone_number;size:50;not null" json:"phone_number"`
+	FirstName       string         `gorm:"column:first_name;size:100" json:"first_name"`
+	LastName        string         `gorm:"column:last_name;size:100" json:"last_name"`
+	Email           sql.NullString `gorm:"column:email;size:255" json:"-"`
+	EmailVerifiedAt sql.NullTime   `gorm:"column:email_verified_at" json:"-"`
+	IsActive        bool           `gorm:"column:is_active;default:true" json:"is_active"`
+	// S3AccessKey and S3SecretEncrypted back the pkg/s3gateway SigV4
+	// endpoint (see MediaHandler.ListObjectsV2/GetObject/HeadObject) - an access key ID handed out by
+	// admins to let external tools read this driver's gallery media
+	// through an S3-shaped API. S3SecretEncrypted holds the secret
+	// encrypted (not one-way hashed like AccessKey.SecretHash), because
+	// SigV4 verification must reconstruct the same signing key the client
+	// used, which isn't possible from a hash alone.
+	S3AccessKey       sql.NullString `gorm:"column:s3_access_key;size:32;uniqueIndex" json:"-"`
+	S3SecretEncrypted sql.NullString `gorm:"column:s3_secret_encrypted;size:255" json:"-"`
+	// DeletedAt marks a driver soft-deleted by an admin. Soft-deleted
+	// drivers are excluded from DriverRepository.GetAll but the row is kept
+	// around so DriverHandler.RestoreDriver can reverse it.
+	DeletedAt sql.NullTime `gorm:"column:deleted_at;index" json:"-"`
+	CreatedAt time.Time    `gorm:"column:created_at" json:"created_at"`
+	UpdatedAt time.Time    `gorm:"column:updated_at" json:"updated_at"`
 }
 
 func (Driver) TableName() string {
 	return "drivers"
 }
 
-// MarshalJSON customizes JSON serialization for Driver to handle sql.NullString properly
+// MarshalJSON customizes JSON serialization for Driver to handle sql.NullString/sql.NullTime properly
 func (d Driver) MarshalJSON() ([]byte, error) {
 	type Alias Driver
 	var email *string
 	if d.Email.Valid {
 		email = &d.Email.String
 	}
+	var emailVerifiedAt *time.Time
+	if d.EmailVerifiedAt.Valid {
+		emailVerifiedAt = &d.EmailVerifiedAt.Time
+	}
 	return json.Marshal(&struct {
-		Email *string `json:"email,omitempty"`
+		Email           *string    `json:"email,omitempty"`
+		EmailVerifiedAt *time.Time `json:"email_verified_at,omitempty"`
 		*Alias
 	}{
-		Email: email,
-		Alias: (*Alias)(&d),
+		Email:           email,
+		EmailVerifiedAt: emailVerifiedAt,
+		Alias:           (*Alias)(&d),
 	})
 }
 
@@ -145,36 +165,497 @@ func (Session) TableName() string {
 	return "sessions"
 }
 
-// MeetingRoom represents meeting rooms for video calls
+// MeetingRoom represents meeting rooms for video calls. Status moves
+// through a one-way lifecycle: "pending" (created, nobody has joined yet)
+// -> "active" (first MeetingService.Join recorded) -> "ended" (explicitly
+// ended via EndMeeting) or "expired" (swept by MeetingJanitor after
+// sitting idle past config.MeetingConfig.IdleExpiryMinutes with no join).
+// GetByRoomID/GetByLoadID only ever return pending/active rooms - ended
+// and expired are terminal.
 type MeetingRoom struct {
-	ID           uint           `gorm:"primaryKey;column:id" json:"id"`
-	LoadID       uint           `gorm:"column:load_id;not null;index" json:"load_id"`
-	RoomID       string         `gorm:"column:roomId;size:255;not null;uniqueIndex" json:"roomId"`
-	ChannelName  string         `gorm:"column:channelName;size:255;not null;index" json:"channelName"`
-	MeetingLink  string         `gorm:"column:meetingLink;size:500;not null" json:"meetingLink"`
-	LoadNumber   sql.NullString `gorm:"column:load_number;size:100;index" json:"load_number"`
-	SaveType     sql.NullString `gorm:"column:save_type;size:50" json:"save_type"`
-	Status       string         `gorm:"column:status;type:enum('active','ended');default:active;index" json:"status"`
-	CreatedAt    time.Time      `gorm:"column:created_at;index" json:"created_at"`
-	LastJoinedAt sql.NullTime   `gorm:"column:lastJoinedAt" json:"lastJoinedAt"`
+	ID              uint           `gorm:"primaryKey;column:id" json:"id"`
+	LoadID          uint           `gorm:"column:load_id;not null;index" json:"load_id"`
+	RoomID          string         `gorm:"column:roomId;size:255;not null;uniqueIndex" json:"roomId"`
+	ChannelName     string         `gorm:"column:channelName;size:255;not null;index" json:"channelName"`
+	MeetingLink     string         `gorm:"column:meetingLink;size:500;not null" json:"meetingLink"`
+	LoadNumber      sql.NullString `gorm:"column:load_number;size:100;index" json:"load_number"`
+	SaveType        sql.NullString `gorm:"column:save_type;size:50" json:"save_type"`
+	Status          string         `gorm:"column:status;type:enum('pending','active','ended','expired');default:pending;index" json:"status"`
+	MaxParticipants int            `gorm:"column:max_participants;not null;default:4" json:"max_participants"`
+	CreatedAt       time.Time      `gorm:"column:created_at;index" json:"created_at"`
+	LastJoinedAt    sql.NullTime   `gorm:"column:lastJoinedAt" json:"lastJoinedAt"`
 }
 
 func (MeetingRoom) TableName() string {
 	return "meeting_rooms"
 }
 
+// MeetingJoinToken is a short-lived (minutes), single-use token minted
+// whenever a room's info is handed to a client (room creation, or the
+// ACL-gated room fetch) and redeemed exactly once at
+// POST /meetings/:roomId/join for the room's actual Agora RTC token.
+// Because the long-lived MeetingRoom.MeetingLink only ever resolves to a
+// freshly-minted token like this, the link itself can't be replayed to
+// mint a new RTC token once a room has ended or expired.
+type MeetingJoinToken struct {
+	ID            uint         `gorm:"primaryKey;column:id" json:"id"`
+	MeetingRoomID uint         `gorm:"column:meeting_room_id;not null;index" json:"meeting_room_id"`
+	Token         string       `gorm:"column:token;size:64;not null;uniqueIndex" json:"token"`
+	UID           string       `gorm:"column:uid;size:64;not null" json:"uid"`
+	ExpiresAt     time.Time    `gorm:"column:expires_at;not null;index" json:"expires_at"`
+	UsedAt        sql.NullTime `gorm:"column:used_at" json:"used_at"`
+	CreatedAt     time.Time    `gorm:"column:created_at" json:"created_at"`
+}
+
+func (MeetingJoinToken) TableName() string {
+	return "meeting_join_tokens"
+}
+
+// MeetingJoinSession records one participant's live occupancy of a
+// meeting room, created when a MeetingJoinToken is redeemed at
+// POST /meetings/:roomId/join, so MeetingService can enforce
+// MeetingRoom.MaxParticipants by counting rows with no LeftAt. Distinct
+// from MeetingRoomParticipant, which grants invite-time ACL access rather
+// than tracking live presence.
+type MeetingJoinSession struct {
+	ID            uint           `gorm:"primaryKey;column:id" json:"id"`
+	MeetingRoomID uint           `gorm:"column:meeting_room_id;not null;index" json:"meeting_room_id"`
+	AgoraUID      string         `gorm:"column:agora_uid;size:64;not null" json:"agora_uid"`
+	SessionID     sql.NullString `gorm:"column:session_id;size:255;index" json:"session_id"`
+	UserType      sql.NullString `gorm:"column:user_type;size:20" json:"user_type"`
+	JoinedAt      time.Time      `gorm:"column:joined_at;not null" json:"joined_at"`
+	LeftAt        sql.NullTime   `gorm:"column:left_at" json:"left_at"`
+}
+
+func (MeetingJoinSession) TableName() string {
+	return "meeting_join_sessions"
+}
+
+// MeetingRoomParticipant represents a driver or guest explicitly granted
+// access to a meeting room, beyond the load's assigned driver.
+type MeetingRoomParticipant struct {
+	ID               uint           `gorm:"primaryKey;column:id" json:"id"`
+	MeetingRoomID    uint           `gorm:"column:meeting_room_id;not null;index" json:"meeting_room_id"`
+	DriverID         sql.NullInt64  `gorm:"column:driver_id;index" json:"driver_id"`
+	PhoneNumber      sql.NullString `gorm:"column:phone_number;size:50" json:"phone_number"`
+	Role             string         `gorm:"column:role;size:20;not null" json:"role"` // driver, guest, observer
+	InvitedByAdminID int            `gorm:"column:invited_by_admin_id;not null" json:"invited_by_admin_id"`
+	CreatedAt        time.Time      `gorm:"column:created_at" json:"created_at"`
+}
+
+func (MeetingRoomParticipant) TableName() string {
+	return "meeting_room_participants"
+}
+
+// APIToken represents a bearer token issued to a driver so mobile/native
+// clients and automation can call the API without a cookie session.
+type APIToken struct {
+	ID           uint         `gorm:"primaryKey;column:id" json:"id"`
+	DriverID     uint         `gorm:"column:driver_id;not null;index" json:"driver_id"`
+	Name         string       `gorm:"column:name;size:100;not null" json:"name"`
+	TokenHash    string       `gorm:"column:token_hash;size:64;not null;uniqueIndex" json:"-"`
+	Revoked      bool         `gorm:"column:revoked;default:false" json:"revoked"`
+	RequestCount int          `gorm:"column:request_count;default:0" json:"request_count"`
+	LastUsedAt   sql.NullTime `gorm:"column:last_used_at" json:"last_used_at"`
+	CreatedAt    time.Time    `gorm:"column:created_at" json:"created_at"`
+}
+
+func (APIToken) TableName() string {
+	return "api_tokens"
+}
+
+// CourierMessage represents a queued outbound message dispatched by the
+// courier worker. Channel is one of the notification package's channel
+// names ("smtp", "sms", "push"), which all share the same queue and retry
+// machinery.
+type CourierMessage struct {
+	ID            uint           `gorm:"primaryKey;column:id" json:"id"`
+	Channel       string         `gorm:"column:channel;size:20;not null;index" json:"channel"`
+	Recipient     string         `gorm:"column:recipient;size:255;not null" json:"recipient"`
+	Subject       string         `gorm:"column:subject;size:255" json:"subject"`
+	Body          string         `gorm:"column:body;type:text;not null" json:"-"`
+	Status        string         `gorm:"column:status;size:20;not null;default:queued;index" json:"status"` // queued, sending, sent, abandoned
+	Attempts      int            `gorm:"column:attempts;default:0" json:"attempts"`
+	LastError     sql.NullString `gorm:"column:last_error;type:text" json:"last_error"`
+	NextAttemptAt time.Time      `gorm:"column:next_attempt_at;index" json:"next_attempt_at"`
+	SentAt        sql.NullTime   `gorm:"column:sent_at" json:"sent_at"`
+	CreatedAt     time.Time      `gorm:"column:created_at" json:"created_at"`
+	UpdatedAt     time.Time      `gorm:"column:updated_at" json:"updated_at"`
+}
+
+func (CourierMessage) TableName() string {
+	return "courier_messages"
+}
+
+// MarshalJSON customizes JSON serialization for CourierMessage to handle
+// sql.NullString and sql.NullTime properly.
+func (m CourierMessage) MarshalJSON() ([]byte, error) {
+	var lastError *string
+	if m.LastError.Valid {
+		lastError = &m.LastError.String
+	}
+
+	var sentAt *time.Time
+	if m.SentAt.Valid {
+		sentAt = &m.SentAt.Time
+	}
+
+	return json.Marshal(&struct {
+		ID            uint       `json:"id"`
+		Channel       string     `json:"channel"`
+		Recipient     string     `json:"recipient"`
+		Subject       string     `json:"subject"`
+		Status        string     `json:"status"`
+		Attempts      int        `json:"attempts"`
+		LastError     *string    `json:"last_error,omitempty"`
+		NextAttemptAt time.Time  `json:"next_attempt_at"`
+		SentAt        *time.Time `json:"sent_at,omitempty"`
+		CreatedAt     time.Time  `json:"created_at"`
+		UpdatedAt     time.Time  `json:"updated_at"`
+	}{
+		ID:            m.ID,
+		Channel:       m.Channel,
+		Recipient:     m.Recipient,
+		Subject:       m.Subject,
+		Status:        m.Status,
+		Attempts:      m.Attempts,
+		LastError:     lastError,
+		NextAttemptAt: m.NextAttemptAt,
+		SentAt:        sentAt,
+		CreatedAt:     m.CreatedAt,
+		UpdatedAt:     m.UpdatedAt,
+	})
+}
+
 // Gallery represents the gallery table for storing screenshots and recordings
 type Gallery struct {
-	ID                uint      `gorm:"primaryKey;column:id" json:"id"`
-	LoadID            uint      `gorm:"column:load_id;index;not null" json:"load_id"`
-	Load              *Load     `gorm:"foreignKey:LoadID" json:"load,omitempty"`
-	FileName          string    `gorm:"column:file_name;size:500;not null" json:"file_name"`
-	S3Key             string    `gorm:"column:s3_key;size:500;not null" json:"s3_key"`
-	VideoRecordingKey string    `gorm:"column:video_recording_key;size:500" json:"video_recording_key"`
-	CreatedAt         time.Time `gorm:"column:created_at" json:"created_at"`
-	UpdatedAt         time.Time `gorm:"column:updated_at" json:"updated_at"`
+	ID                uint   `gorm:"primaryKey;column:id" json:"id"`
+	LoadID            uint   `gorm:"column:load_id;index;not null" json:"load_id"`
+	Load              *Load  `gorm:"foreignKey:LoadID" json:"load,omitempty"`
+	FileName          string `gorm:"column:file_name;size:500;not null" json:"file_name"`
+	S3Key             string `gorm:"column:s3_key;size:500;not null" json:"s3_key"`
+	VideoRecordingKey string `gorm:"column:video_recording_key;size:500" json:"video_recording_key"`
+	// ManifestS3Key points at the RecordingManifest sidecar object
+	// describing VideoRecordingKey's segments, if one was generated.
+	ManifestS3Key string `gorm:"column:manifest_s3_key;size:500" json:"manifest_s3_key"`
+	// ContentDigest is a combined digest over a recording's segment
+	// digests, used to dedupe re-uploads of the same content (see
+	// GalleryRepository.GetByContentDigest).
+	ContentDigest string `gorm:"column:content_digest;size:64;index" json:"content_digest"`
+	// ScanStatus is one of GalleryScanClean, GalleryScanInfected, or
+	// GalleryScanSkipped (see pkg/scanner). Under async scanning it starts
+	// as GalleryScanSkipped and is updated once the background scan
+	// completes; under sync scanning an infected upload never reaches the
+	// gallery at all (see MediaHandler.SaveScreenshot).
+	ScanStatus sql.NullString `gorm:"column:scan_status;size:20" json:"scan_status"`
+	// Hidden is set by the async scan worker when a gallery row's media is
+	// quarantined after an infected result, so it stops appearing in
+	// gallery listings without the row itself being deleted.
+	Hidden    bool      `gorm:"column:hidden;default:false" json:"hidden"`
+	CreatedAt time.Time `gorm:"column:created_at" json:"created_at"`
+	UpdatedAt time.Time `gorm:"column:updated_at" json:"updated_at"`
 }
 
+// Gallery scan statuses (see pkg/scanner).
+const (
+	GalleryScanClean    = "clean"
+	GalleryScanInfected = "infected"
+	GalleryScanSkipped  = "skipped"
+)
+
 func (Gallery) TableName() string {
 	return "gallery"
 }
+
+// RecordingJob tracks the resumable stop->collect->verify pipeline for a
+// single Agora recording, modeled as a state machine so a crashed backend
+// can resume a job instead of losing the recording. ByteOffset/ExpectedSize
+// let the worker detect partial uploads by comparing against the storage
+// backend's HEAD size before marking the job Complete.
+type RecordingJob struct {
+	ID          uint          `gorm:"primaryKey;column:id" json:"id"`
+	ResourceID  string        `gorm:"column:resource_id;size:255;not null" json:"resource_id"`
+	SID         string        `gorm:"column:sid;size:255;not null;index" json:"sid"`
+	ChannelName string        `gorm:"column:channel_name;size:255;not null" json:"channel_name"`
+	UID         string        `gorm:"column:uid;size:50;not null" json:"uid"`
+	LoadID      sql.NullInt64 `gorm:"column:load_id" json:"load_id"`
+	// Status is one of: stopping, collecting, verifying, complete, failed.
+	Status        string         `gorm:"column:status;size:20;not null;default:stopping;index" json:"status"`
+	S3Key         sql.NullString `gorm:"column:s3_key;size:500" json:"s3_key"`
+	ByteOffset    int64          `gorm:"column:byte_offset;default:0" json:"byte_offset"`
+	ExpectedSize  int64          `gorm:"column:expected_size;default:0" json:"expected_size"`
+	Attempts      int            `gorm:"column:attempts;default:0" json:"attempts"`
+	LastError     sql.NullString `gorm:"column:last_error;type:text" json:"last_error"`
+	NextAttemptAt sql.NullTime   `gorm:"column:next_attempt_at;index" json:"next_attempt_at"`
+	CreatedAt     time.Time      `gorm:"column:created_at" json:"created_at"`
+	UpdatedAt     time.Time      `gorm:"column:updated_at" json:"updated_at"`
+}
+
+func (RecordingJob) TableName() string {
+	return "recording_jobs"
+}
+
+// Recording job states.
+const (
+	RecordingJobStopping   = "stopping"
+	RecordingJobCollecting = "collecting"
+	RecordingJobVerifying  = "verifying"
+	RecordingJobComplete   = "complete"
+	RecordingJobFailed     = "failed"
+)
+
+// Upload tracks a multipart upload's progress so a driver app can resume an
+// interrupted streaming upload (see MediaHandler's POST /media/upload-stream)
+// by its public UploadID instead of restarting from byte zero.
+type Upload struct {
+	ID uint `gorm:"primaryKey;column:id" json:"id"`
+	// UploadID is the public identifier the client polls/resumes with.
+	UploadID string `gorm:"column:upload_id;size:64;not null;uniqueIndex" json:"upload_id"`
+	// S3UploadID is the ID S3 assigned to the underlying CreateMultipartUpload.
+	S3UploadID  string `gorm:"column:s3_upload_id;size:255;not null" json:"-"`
+	S3Key       string `gorm:"column:s3_key;size:500;not null" json:"s3_key"`
+	ContentType string `gorm:"column:content_type;size:100;not null" json:"content_type"`
+	PartSize    int64  `gorm:"column:part_size;not null" json:"part_size"`
+	// TotalSize is unset until the client reports the final size, since a
+	// streamed upload's length isn't known up front.
+	TotalSize     sql.NullInt64 `gorm:"column:total_size" json:"total_size"`
+	BytesUploaded int64         `gorm:"column:bytes_uploaded;default:0" json:"bytes_uploaded"`
+	// CompletedParts is a JSON-encoded []s3.CompletedPart, persisted so a
+	// resumed upload knows which part numbers it can skip re-sending.
+	CompletedParts sql.NullString `gorm:"column:completed_parts;type:text" json:"-"`
+	// Status is one of: uploading, completed, aborted, failed.
+	Status    string         `gorm:"column:status;size:20;not null;default:uploading;index" json:"status"`
+	LastError sql.NullString `gorm:"column:last_error;type:text" json:"last_error"`
+	CreatedAt time.Time      `gorm:"column:created_at" json:"created_at"`
+	UpdatedAt time.Time      `gorm:"column:updated_at" json:"updated_at"`
+}
+
+func (Upload) TableName() string {
+	return "uploads"
+}
+
+// Upload states.
+const (
+	UploadUploading = "uploading"
+	UploadCompleted = "completed"
+	UploadAborted   = "aborted"
+	UploadFailed    = "failed"
+)
+
+// AccessKey is a short-lived, per-driver credential scoped to a single
+// load's upload prefix, used to mint presigned S3 upload URLs without
+// handing the mobile app the master AWS keys. Only SecretHash is persisted;
+// the raw secret is returned once at issuance, mirroring APIToken.
+type AccessKey struct {
+	ID            uint         `gorm:"primaryKey;column:id" json:"id"`
+	DriverID      uint         `gorm:"column:driver_id;not null;index" json:"driver_id"`
+	LoadID        uint         `gorm:"column:load_id;not null;index" json:"load_id"`
+	KeyID         string       `gorm:"column:key_id;size:8;not null;uniqueIndex" json:"key_id"`
+	SecretHash    string       `gorm:"column:secret_hash;size:64;not null" json:"-"`
+	AllowedPrefix string       `gorm:"column:allowed_prefix;size:255;not null" json:"allowed_prefix"`
+	Revoked       bool         `gorm:"column:revoked;default:false" json:"revoked"`
+	LastUsedAt    sql.NullTime `gorm:"column:last_used_at" json:"last_used_at"`
+	ExpiresAt     time.Time    `gorm:"column:expires_at;index;not null" json:"expires_at"`
+	CreatedAt     time.Time    `gorm:"column:created_at" json:"created_at"`
+}
+
+func (AccessKey) TableName() string {
+	return "access_keys"
+}
+
+// MediaShare is a public, revocable link to a single gallery item or a
+// whole load's media, minted by an admin so they can hand a broker a proof-
+// of-delivery link without creating them an account. Only TokenHash is
+// persisted; the raw token is returned once at creation, mirroring
+// AccessKey/APIToken.
+type MediaShare struct {
+	ID        uint   `gorm:"primaryKey;column:id" json:"id"`
+	TokenHash string `gorm:"column:token_hash;size:64;not null;uniqueIndex" json:"-"`
+	// Scope is one of MediaShareScopeGallery or MediaShareScopeLoad,
+	// selecting whether GalleryID or LoadID resolves the share.
+	Scope            string        `gorm:"column:scope;size:20;not null" json:"scope"`
+	GalleryID        sql.NullInt64 `gorm:"column:gallery_id" json:"gallery_id"`
+	LoadID           sql.NullInt64 `gorm:"column:load_id" json:"load_id"`
+	ViewCount        int           `gorm:"column:view_count;default:0" json:"view_count"`
+	MaxViews         sql.NullInt64 `gorm:"column:max_views" json:"max_views"`
+	ExpiresAt        time.Time     `gorm:"column:expires_at;index;not null" json:"expires_at"`
+	RevokedAt        sql.NullTime  `gorm:"column:revoked_at" json:"revoked_at"`
+	CreatedByAdminID int           `gorm:"column:created_by_admin_id;not null" json:"created_by_admin_id"`
+	CreatedAt        time.Time     `gorm:"column:created_at" json:"created_at"`
+	UpdatedAt        time.Time     `gorm:"column:updated_at" json:"updated_at"`
+}
+
+func (MediaShare) TableName() string {
+	return "media_shares"
+}
+
+// Media share scopes.
+const (
+	MediaShareScopeGallery = "gallery"
+	MediaShareScopeLoad    = "load"
+)
+
+// MediaShareAccess is an audit log entry recorded each time a MediaShare
+// link is resolved, so admins can see who (by IP/user agent) viewed a link
+// they sent out.
+type MediaShareAccess struct {
+	ID           uint      `gorm:"primaryKey;column:id" json:"id"`
+	MediaShareID uint      `gorm:"column:media_share_id;not null;index" json:"media_share_id"`
+	AccessedAt   time.Time `gorm:"column:accessed_at" json:"accessed_at"`
+	IPAddress    string    `gorm:"column:ip_address;size:64;not null" json:"ip_address"`
+	UserAgent    string    `gorm:"column:user_agent;size:500;not null" json:"user_agent"`
+}
+
+func (MediaShareAccess) TableName() string {
+	return "media_share_accesses"
+}
+
+// SecurityEvent is an append-only log of security-relevant uploads -
+// currently just malware detections from pkg/scanner - for admins to audit
+// separately from the gallery itself.
+type SecurityEvent struct {
+	ID uint `gorm:"primaryKey;column:id" json:"id"`
+	// EventType is one of SecurityEventInfectedUpload or
+	// SecurityEventQuarantined.
+	EventType string        `gorm:"column:event_type;size:50;not null" json:"event_type"`
+	GalleryID sql.NullInt64 `gorm:"column:gallery_id" json:"gallery_id"`
+	S3Key     string        `gorm:"column:s3_key;size:500" json:"s3_key"`
+	// Detail holds the scanner's signature name and any other
+	// human-readable context for the event.
+	Detail    string    `gorm:"column:detail;type:text" json:"detail"`
+	CreatedAt time.Time `gorm:"column:created_at" json:"created_at"`
+}
+
+func (SecurityEvent) TableName() string {
+	return "security_events"
+}
+
+// Security event types.
+const (
+	SecurityEventInfectedUpload = "infected_upload"
+	SecurityEventQuarantined    = "quarantined"
+)
+
+// NotificationPreference records whether a driver wants a given
+// notification.Notifier channel ("smtp"/"sms"/"push", see the
+// notification package) used for them, and, for channels Driver has no
+// address of its own for (push), the destination to send to.
+type NotificationPreference struct {
+	ID       uint   `gorm:"primaryKey;column:id" json:"id"`
+	DriverID uint   `gorm:"column:driver_id;uniqueIndex:idx_notification_preferences_driver_channel;not null" json:"driver_id"`
+	Channel  string `gorm:"column:channel;size:20;uniqueIndex:idx_notification_preferences_driver_channel;not null" json:"channel"`
+	Enabled  bool   `gorm:"column:enabled;default:true" json:"enabled"`
+	// Destination overrides the channel's default recipient address
+	// (Driver.Email for "smtp", Driver.PhoneNumber for "sms"). Required
+	// for "push", since Driver has no device-token field of its own.
+	Destination sql.NullString `gorm:"column:destination;size:255" json:"destination"`
+	CreatedAt   time.Time      `gorm:"column:created_at" json:"created_at"`
+	UpdatedAt   time.Time      `gorm:"column:updated_at" json:"updated_at"`
+}
+
+func (NotificationPreference) TableName() string {
+	return "notification_preferences"
+}
+
+// Webhook is an admin-registered HTTP endpoint that receives a signed copy
+// of matching events.Event occurrences from the internal/webhook
+// dispatcher (see internal/events). EventFilter is a comma-separated list
+// of events.Type values; an empty filter subscribes to every event.
+type Webhook struct {
+	ID          uint      `gorm:"primaryKey;column:id" json:"id"`
+	URL         string    `gorm:"column:url;size:500;not null" json:"url"`
+	Secret      string    `gorm:"column:secret;size:255;not null" json:"-"`
+	EventFilter string    `gorm:"column:event_filter;size:500" json:"event_filter"`
+	Active      bool      `gorm:"column:active;default:true" json:"active"`
+	CreatedAt   time.Time `gorm:"column:created_at" json:"created_at"`
+	UpdatedAt   time.Time `gorm:"column:updated_at" json:"updated_at"`
+}
+
+func (Webhook) TableName() string {
+	return "webhooks"
+}
+
+// WebhookDelivery records a single attempt (or pending attempt) to deliver
+// an event to a Webhook, so operators can inspect failures without shell
+// access to the database. A delivery that exhausts maxWebhookAttempts
+// stays Abandoned rather than being deleted, acting as the dead-letter
+// record the ticket asked for.
+type WebhookDelivery struct {
+	ID            uint           `gorm:"primaryKey;column:id" json:"id"`
+	WebhookID     uint           `gorm:"column:webhook_id;not null;index" json:"webhook_id"`
+	EventType     string         `gorm:"column:event_type;size:50;not null" json:"event_type"`
+	Payload       string         `gorm:"column:payload;type:text;not null" json:"payload"`
+	Status        string         `gorm:"column:status;size:20;not null;default:queued;index" json:"status"` // queued, sending, delivered, abandoned
+	Attempts      int            `gorm:"column:attempts;default:0" json:"attempts"`
+	LastError     sql.NullString `gorm:"column:last_error;type:text" json:"last_error"`
+	LastStatus    sql.NullInt64  `gorm:"column:last_status" json:"last_status"`
+	NextAttemptAt time.Time      `gorm:"column:next_attempt_at;index" json:"next_attempt_at"`
+	DeliveredAt   sql.NullTime   `gorm:"column:delivered_at" json:"delivered_at"`
+	CreatedAt     time.Time      `gorm:"column:created_at" json:"created_at"`
+	UpdatedAt     time.Time      `gorm:"column:updated_at" json:"updated_at"`
+}
+
+func (WebhookDelivery) TableName() string {
+	return "webhook_deliveries"
+}
+
+// RolePermission grants a single middleware.Permission string to a role
+// ("admin", "dispatcher", "driver"). service.PolicyService reads this
+// table to back middleware.RequirePermission instead of the old hardcoded
+// RequireRole(RoleAdmin) checks, and admins can edit it through
+// PermissionHandler without a deploy.
+type RolePermission struct {
+	ID         uint      `gorm:"primaryKey;column:id" json:"id"`
+	Role       string    `gorm:"column:role;size:50;not null;uniqueIndex:idx_role_permission" json:"role"`
+	Permission string    `gorm:"column:permission;size:100;not null;uniqueIndex:idx_role_permission" json:"permission"`
+	CreatedAt  time.Time `gorm:"column:created_at" json:"created_at"`
+}
+
+func (RolePermission) TableName() string {
+	return "role_permissions"
+}
+
+// ClientLog stores a single structured log entry shipped from the
+// driver-facing client (web/mobile) via handler.ClientLogHandler, for
+// later triage of in-the-field video-call failures. SessionID/UserType/
+// DriverID identify the authenticated caller when present; IPAddress
+// identifies pre-auth callers (e.g. an error before login completes).
+type ClientLog struct {
+	ID         uint           `gorm:"primaryKey;column:id" json:"id"`
+	Level      string         `gorm:"column:level;size:20;not null;index" json:"level"`
+	Message    string         `gorm:"column:message;type:text;not null" json:"message"`
+	Context    sql.NullString `gorm:"column:context;type:text" json:"context"`
+	ClientTime sql.NullTime   `gorm:"column:client_time" json:"client_time"`
+	SessionID  sql.NullString `gorm:"column:session_id;size:255;index" json:"session_id"`
+	UserType   sql.NullString `gorm:"column:user_type;size:20" json:"user_type"`
+	DriverID   sql.NullInt64  `gorm:"column:driver_id;index" json:"driver_id"`
+	LoadID     sql.NullInt64  `gorm:"column:load_id;index" json:"load_id"`
+	IPAddress  string         `gorm:"column:ip_address;size:64;not null" json:"ip_address"`
+	UserAgent  sql.NullString `gorm:"column:user_agent;size:500" json:"user_agent"`
+	StackTrace sql.NullString `gorm:"column:stack_trace;type:text" json:"stack_trace"`
+	CreatedAt  time.Time      `gorm:"column:created_at;index" json:"created_at"`
+}
+
+func (ClientLog) TableName() string {
+	return "client_logs"
+}
+
+// IdempotencyKey caches a mutating endpoint's response for replay under
+// middleware.IdempotencyMiddleware, keyed by the caller (the session ID,
+// or the client IP when the route has no session - e.g. the public Agora
+// recording endpoints) and the client-supplied Idempotency-Key header, so
+// a request retried after a flaky network doesn't re-run a non-idempotent
+// side effect like starting a second recording or re-sending an invite.
+type IdempotencyKey struct {
+	ID             uint      `gorm:"primaryKey;column:id" json:"id"`
+	ScopeKey       string    `gorm:"column:scope_key;size:255;not null;uniqueIndex:idx_idempotency_scope_key" json:"scope_key"`
+	IdempotencyKey string    `gorm:"column:idempotency_key;size:255;not null;uniqueIndex:idx_idempotency_scope_key" json:"idempotency_key"`
+	StatusCode     int       `gorm:"column:status_code;not null" json:"status_code"`
+	ResponseBody   string    `gorm:"column:response_body;type:longtext;not null" json:"response_body"`
+	ExpiresAt      time.Time `gorm:"column:expires_at;not null;index" json:"expires_at"`
+	CreatedAt      time.Time `gorm:"column:created_at" json:"created_at"`
+}
+
+func (IdempotencyKey) TableName() string {
+	return "idempotency_keys"
+}
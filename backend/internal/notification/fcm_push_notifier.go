@@ -0,0 +1,63 @@
+package notification
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+const fcmEndpoint = "https://fcm.googleapis.com/fcm/send"
+
+// FCMPushNotifier delivers notifications as push notifications via
+// Firebase Cloud Messaging's legacy HTTP API. to is the recipient device's
+// FCM registration token.
+type FCMPushNotifier struct {
+	serverKey  string
+	httpClient *http.Client
+}
+
+// NewFCMPushNotifier creates a new FCMPushNotifier.
+func NewFCMPushNotifier(serverKey string) *FCMPushNotifier {
+	return &FCMPushNotifier{serverKey: serverKey, httpClient: &http.Client{Timeout: 10 * time.Second}}
+}
+
+type fcmNotificationPayload struct {
+	Title string `json:"title"`
+	Body  string `json:"body"`
+}
+
+type fcmRequest struct {
+	To           string                 `json:"to"`
+	Notification fcmNotificationPayload `json:"notification"`
+}
+
+// Send implements Notifier. subject becomes the push notification's title.
+func (n *FCMPushNotifier) Send(to, subject, body string) error {
+	payload, err := json.Marshal(fcmRequest{
+		To:           to,
+		Notification: fcmNotificationPayload{Title: subject, Body: body},
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, fcmEndpoint, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "key="+n.serverKey)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := n.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("fcm request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("fcm: unexpected status %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,20 @@
+// Package notification fans a message out to whichever channels a driver
+// has enabled (email, SMS, push), reusing the courier package's persisted
+// outbox for delivery - retries with backoff instead of a failed send
+// turning into a 500 for the caller.
+package notification
+
+// Channel name constants. These double as models.NotificationPreference.Channel
+// and models.CourierMessage.Channel values.
+const (
+	ChannelSMTP = "smtp"
+	ChannelSMS  = "sms"
+	ChannelPush = "push"
+)
+
+// Notifier delivers a single rendered message to a single recipient over
+// one channel. It has the same shape as courier.Channel by design, so any
+// Notifier can be registered directly with a courier.Worker.
+type Notifier interface {
+	Send(to, subject, body string) error
+}
@@ -0,0 +1,125 @@
+package notification
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/securedlinq/backend/internal/courier/templates"
+	"github.com/securedlinq/backend/internal/models"
+	"github.com/securedlinq/backend/internal/repository"
+)
+
+// MeetingInviteData is the template data for the "meeting_invite" message.
+type MeetingInviteData struct {
+	DriverName  string
+	LoadNumber  string
+	MeetingLink string
+}
+
+// AllChannels is every channel SendMeetingInvite considers when the caller
+// doesn't pass an explicit channel list.
+var AllChannels = []string{ChannelSMTP, ChannelSMS, ChannelPush}
+
+// Service fans a MeetingInvite out across a driver's enabled channels,
+// rendering the right template per channel and queuing each as a
+// courier_messages row for the shared retry/backoff worker to deliver -
+// so a failed send is retried instead of turning into a 500 for the
+// caller.
+type Service struct {
+	driverRepo *repository.DriverRepository
+	prefRepo   *repository.NotificationPreferenceRepository
+	courier    *repository.CourierRepository
+	renderer   *templates.Renderer
+}
+
+// NewService creates a new Service.
+func NewService(driverRepo *repository.DriverRepository, prefRepo *repository.NotificationPreferenceRepository, courier *repository.CourierRepository, renderer *templates.Renderer) *Service {
+	return &Service{driverRepo: driverRepo, prefRepo: prefRepo, courier: courier, renderer: renderer}
+}
+
+// SendMeetingInvite queues a meeting-invite message on each of channels (or
+// AllChannels, if channels is empty) that driverID hasn't explicitly
+// disabled, skipping any channel with no resolvable recipient address
+// (e.g. push with no device token on file).
+func (s *Service) SendMeetingInvite(driverID uint, data MeetingInviteData, channels []string) ([]models.CourierMessage, error) {
+	driver, err := s.driverRepo.GetByID(driverID)
+	if err != nil {
+		return nil, fmt.Errorf("load driver: %w", err)
+	}
+
+	prefs, err := s.prefRepo.GetByDriverID(driverID)
+	if err != nil {
+		return nil, fmt.Errorf("load notification preferences: %w", err)
+	}
+
+	if len(channels) == 0 {
+		channels = AllChannels
+	}
+
+	var queued []models.CourierMessage
+	for _, channel := range channels {
+		pref, hasPref := prefs[channel]
+		if hasPref && !pref.Enabled {
+			continue
+		}
+
+		to := recipientFor(channel, driver, pref)
+		if to == "" {
+			continue
+		}
+
+		msg, err := s.enqueue(channel, to, data)
+		if err != nil {
+			return nil, fmt.Errorf("queue %s notification: %w", channel, err)
+		}
+		queued = append(queued, *msg)
+	}
+
+	return queued, nil
+}
+
+// recipientFor resolves the address a channel's message should go to: an
+// explicit per-channel Destination override if the driver has one, else
+// the channel's default address on the driver record (only email and SMS
+// have one - push always needs an explicit Destination).
+func recipientFor(channel string, driver *models.Driver, pref models.NotificationPreference) string {
+	if pref.Destination.Valid && pref.Destination.String != "" {
+		return pref.Destination.String
+	}
+	switch channel {
+	case ChannelSMTP:
+		return driver.Email.String
+	case ChannelSMS:
+		return driver.PhoneNumber
+	default:
+		return ""
+	}
+}
+
+func (s *Service) enqueue(channel, to string, data MeetingInviteData) (*models.CourierMessage, error) {
+	var subject, body string
+	var err error
+
+	if channel == ChannelSMTP {
+		subject, body, err = s.renderer.Render("meeting_invite", data)
+	} else {
+		subject = "SecuredLinQ meeting invite"
+		body, err = s.renderer.RenderText("meeting_invite", data)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	msg := &models.CourierMessage{
+		Channel:       channel,
+		Recipient:     to,
+		Subject:       subject,
+		Body:          body,
+		Status:        "queued",
+		NextAttemptAt: time.Now(),
+	}
+	if err := s.courier.Create(msg); err != nil {
+		return nil, err
+	}
+	return msg, nil
+}
@@ -0,0 +1,18 @@
+package notification
+
+import "github.com/securedlinq/backend/internal/mailer"
+
+// SMTPNotifier delivers notifications over SMTP using the mailer package.
+type SMTPNotifier struct {
+	client *mailer.Client
+}
+
+// NewSMTPNotifier creates a new SMTPNotifier.
+func NewSMTPNotifier(client *mailer.Client) *SMTPNotifier {
+	return &SMTPNotifier{client: client}
+}
+
+// Send implements Notifier.
+func (n *SMTPNotifier) Send(to, subject, body string) error {
+	return n.client.Send(to, subject, body)
+}
@@ -0,0 +1,56 @@
+package notification
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// TwilioSMSNotifier delivers notifications as SMS messages via the Twilio
+// Messages API. subject is ignored - Twilio has no concept of one.
+type TwilioSMSNotifier struct {
+	accountSID string
+	authToken  string
+	fromNumber string
+	httpClient *http.Client
+}
+
+// NewTwilioSMSNotifier creates a new TwilioSMSNotifier.
+func NewTwilioSMSNotifier(accountSID, authToken, fromNumber string) *TwilioSMSNotifier {
+	return &TwilioSMSNotifier{
+		accountSID: accountSID,
+		authToken:  authToken,
+		fromNumber: fromNumber,
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// Send implements Notifier.
+func (n *TwilioSMSNotifier) Send(to, _, body string) error {
+	endpoint := fmt.Sprintf("https://api.twilio.com/2010-04-01/Accounts/%s/Messages.json", n.accountSID)
+
+	form := url.Values{}
+	form.Set("To", to)
+	form.Set("From", n.fromNumber)
+	form.Set("Body", body)
+
+	req, err := http.NewRequest(http.MethodPost, endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return err
+	}
+	req.SetBasicAuth(n.accountSID, n.authToken)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := n.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("twilio request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("twilio: unexpected status %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,88 @@
+// Package ratelimit provides a minimal in-memory token-bucket limiter for
+// gating noisy per-client endpoints (e.g. ClientLogHandler), without
+// pulling in a shared store - each Limiter only rate-limits the process
+// it lives in.
+package ratelimit
+
+import (
+	"sync"
+	"time"
+)
+
+// Limiter is a keyed token-bucket rate limiter: each key (session ID, IP
+// address, ...) gets its own bucket that fills at refillPerSecond tokens/
+// sec up to burstCapacity.
+type Limiter struct {
+	mu       sync.Mutex
+	buckets  map[string]*bucket
+	capacity float64
+	refill   float64
+	calls    int64
+}
+
+type bucket struct {
+	tokens   float64
+	lastSeen time.Time
+}
+
+// staleAfter is how long an idle bucket is kept before a sweep reclaims
+// it, bounding memory use for keys (like IP addresses) an attacker could
+// otherwise churn through indefinitely.
+const staleAfter = 10 * time.Minute
+
+// sweepEvery triggers a stale-bucket sweep every this many Allow calls.
+const sweepEvery = 1000
+
+// NewLimiter creates a Limiter allowing burstCapacity requests immediately
+// per key, refilling at refillPerSecond tokens/sec after that.
+func NewLimiter(burstCapacity, refillPerSecond float64) *Limiter {
+	return &Limiter{
+		buckets:  make(map[string]*bucket),
+		capacity: burstCapacity,
+		refill:   refillPerSecond,
+	}
+}
+
+// Allow reports whether a request keyed by key may proceed, consuming one
+// token from its bucket if so.
+func (l *Limiter) Allow(key string) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	l.calls++
+	if l.calls%sweepEvery == 0 {
+		l.sweepLocked(now)
+	}
+
+	b, ok := l.buckets[key]
+	if !ok {
+		l.buckets[key] = &bucket{tokens: l.capacity - 1, lastSeen: now}
+		return true
+	}
+
+	elapsed := now.Sub(b.lastSeen).Seconds()
+	b.tokens = min(l.capacity, b.tokens+elapsed*l.refill)
+	b.lastSeen = now
+
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
+
+func (l *Limiter) sweepLocked(now time.Time) {
+	for key, b := range l.buckets {
+		if now.Sub(b.lastSeen) > staleAfter {
+			delete(l.buckets, key)
+		}
+	}
+}
+
+func min(a, b float64) float64 {
+	if a < b {
+		return a
+	}
+	return b
+}
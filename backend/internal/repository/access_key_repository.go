@@ -0,0 +1,70 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/securedlinq/backend/internal/models"
+	"gorm.io/gorm"
+)
+
+// AccessKeyRepository handles access key database operations
+type AccessKeyRepository struct {
+	db *gorm.DB
+}
+
+// NewAccessKeyRepository creates a new access key repository
+func NewAccessKeyRepository(db *gorm.DB) *AccessKeyRepository {
+	return &AccessKeyRepository{db: db}
+}
+
+// Create creates a new access key
+func (r *AccessKeyRepository) Create(key *models.AccessKey) error {
+	return r.db.Create(key).Error
+}
+
+// GetByKeyID gets a non-revoked, unexpired access key by its public key ID
+func (r *AccessKeyRepository) GetByKeyID(keyID string) (*models.AccessKey, error) {
+	var key models.AccessKey
+	err := r.db.Where("key_id = ? AND revoked = ? AND expires_at > ?", keyID, false, time.Now()).First(&key).Error
+	if err != nil {
+		return nil, err
+	}
+	return &key, nil
+}
+
+// GetByID gets an access key by ID
+func (r *AccessKeyRepository) GetByID(id uint) (*models.AccessKey, error) {
+	var key models.AccessKey
+	err := r.db.Where("id = ?", id).First(&key).Error
+	if err != nil {
+		return nil, err
+	}
+	return &key, nil
+}
+
+// GetByDriverID gets all access keys belonging to a driver
+func (r *AccessKeyRepository) GetByDriverID(driverID uint) ([]models.AccessKey, error) {
+	var keys []models.AccessKey
+	err := r.db.Where("driver_id = ?", driverID).Order("created_at DESC").Find(&keys).Error
+	return keys, err
+}
+
+// Revoke marks an access key as revoked
+func (r *AccessKeyRepository) Revoke(id uint) error {
+	return r.db.Model(&models.AccessKey{}).Where("id = ?", id).Update("revoked", true).Error
+}
+
+// RecordUsage bumps the last-used timestamp for an access key
+func (r *AccessKeyRepository) RecordUsage(id uint) error {
+	return r.db.Model(&models.AccessKey{}).Where("id = ?", id).Update("last_used_at", time.Now()).Error
+}
+
+// GetExpiredUnrevoked returns expired access keys that haven't been marked
+// revoked yet, so the sweep worker can clean them up.
+func (r *AccessKeyRepository) GetExpiredUnrevoked(limit int) ([]models.AccessKey, error) {
+	var keys []models.AccessKey
+	err := r.db.Where("revoked = ? AND expires_at <= ?", false, time.Now()).
+		Limit(limit).
+		Find(&keys).Error
+	return keys, err
+}
@@ -0,0 +1,63 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/securedlinq/backend/internal/models"
+	"gorm.io/gorm"
+)
+
+// APITokenRepository handles API token database operations
+type APITokenRepository struct {
+	db *gorm.DB
+}
+
+// NewAPITokenRepository creates a new API token repository
+func NewAPITokenRepository(db *gorm.DB) *APITokenRepository {
+	return &APITokenRepository{db: db}
+}
+
+// Create creates a new API token
+func (r *APITokenRepository) Create(token *models.APIToken) error {
+	return r.db.Create(token).Error
+}
+
+// GetByHash gets a non-revoked API token by its hash
+func (r *APITokenRepository) GetByHash(hash string) (*models.APIToken, error) {
+	var token models.APIToken
+	err := r.db.Where("token_hash = ? AND revoked = ?", hash, false).First(&token).Error
+	if err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+// GetByID gets an API token by ID
+func (r *APITokenRepository) GetByID(id uint) (*models.APIToken, error) {
+	var token models.APIToken
+	err := r.db.Where("id = ?", id).First(&token).Error
+	if err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+// GetByDriverID gets all API tokens belonging to a driver
+func (r *APITokenRepository) GetByDriverID(driverID uint) ([]models.APIToken, error) {
+	var tokens []models.APIToken
+	err := r.db.Where("driver_id = ?", driverID).Order("created_at DESC").Find(&tokens).Error
+	return tokens, err
+}
+
+// Revoke marks an API token as revoked
+func (r *APITokenRepository) Revoke(id uint) error {
+	return r.db.Model(&models.APIToken{}).Where("id = ?", id).Update("revoked", true).Error
+}
+
+// RecordUsage bumps the request counter and last-used timestamp for a token
+func (r *APITokenRepository) RecordUsage(id uint) error {
+	return r.db.Model(&models.APIToken{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"last_used_at":  time.Now(),
+		"request_count": gorm.Expr("request_count + 1"),
+	}).Error
+}
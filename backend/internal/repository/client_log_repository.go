@@ -0,0 +1,48 @@
+package repository
+
+import (
+	"github.com/securedlinq/backend/internal/models"
+	"gorm.io/gorm"
+)
+
+// ClientLogRepository handles client_logs database operations
+type ClientLogRepository struct {
+	db *gorm.DB
+}
+
+// NewClientLogRepository creates a new client log repository
+func NewClientLogRepository(db *gorm.DB) *ClientLogRepository {
+	return &ClientLogRepository{db: db}
+}
+
+// Create persists a client-shipped log entry
+func (r *ClientLogRepository) Create(entry *models.ClientLog) error {
+	return r.db.Create(entry).Error
+}
+
+// GetFiltered lists client log entries with pagination, newest first,
+// optionally narrowed to a driver and/or load, for the admin triage
+// endpoint. Either filter may be nil to leave it unconstrained.
+func (r *ClientLogRepository) GetFiltered(driverID, loadID *uint, page, pageSize int) ([]models.ClientLog, int64, error) {
+	query := r.db.Model(&models.ClientLog{})
+	if driverID != nil {
+		query = query.Where("driver_id = ?", *driverID)
+	}
+	if loadID != nil {
+		query = query.Where("load_id = ?", *loadID)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var logs []models.ClientLog
+	offset := (page - 1) * pageSize
+	err := query.Order("created_at DESC").Offset(offset).Limit(pageSize).Find(&logs).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return logs, total, nil
+}
@@ -0,0 +1,85 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/securedlinq/backend/internal/models"
+	"gorm.io/gorm"
+)
+
+// CourierRepository handles courier_messages database operations
+type CourierRepository struct {
+	db *gorm.DB
+}
+
+// NewCourierRepository creates a new courier repository
+func NewCourierRepository(db *gorm.DB) *CourierRepository {
+	return &CourierRepository{db: db}
+}
+
+// Create persists a newly queued message
+func (r *CourierRepository) Create(msg *models.CourierMessage) error {
+	return r.db.Create(msg).Error
+}
+
+// GetDue returns up to limit queued messages whose next_attempt_at has
+// passed, oldest first, for the worker to pick up.
+func (r *CourierRepository) GetDue(limit int) ([]models.CourierMessage, error) {
+	var messages []models.CourierMessage
+	err := r.db.Where("status = ? AND next_attempt_at <= ?", "queued", time.Now()).
+		Order("next_attempt_at ASC").
+		Limit(limit).
+		Find(&messages).Error
+	return messages, err
+}
+
+// MarkSending flags a message as in flight so a slower poll cycle doesn't
+// pick it up a second time.
+func (r *CourierRepository) MarkSending(id uint) error {
+	return r.db.Model(&models.CourierMessage{}).Where("id = ?", id).Update("status", "sending").Error
+}
+
+// MarkSent records a successful delivery.
+func (r *CourierRepository) MarkSent(id uint) error {
+	return r.db.Model(&models.CourierMessage{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"status":  "sent",
+		"sent_at": time.Now(),
+	}).Error
+}
+
+// Reschedule records a failed delivery attempt and either requeues the
+// message for nextAttempt or marks it abandoned once attempts has hit the
+// worker's cap.
+func (r *CourierRepository) Reschedule(id uint, attempts int, nextAttempt time.Time, lastErr string, abandoned bool) error {
+	status := "queued"
+	if abandoned {
+		status = "abandoned"
+	}
+
+	return r.db.Model(&models.CourierMessage{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"status":          status,
+		"attempts":        attempts,
+		"last_error":      lastErr,
+		"next_attempt_at": nextAttempt,
+	}).Error
+}
+
+// GetAll lists courier messages newest-first for the admin queue inspector.
+func (r *CourierRepository) GetAll(page, pageSize int) ([]models.CourierMessage, int64, error) {
+	var messages []models.CourierMessage
+	var total int64
+
+	offset := (page - 1) * pageSize
+
+	err := r.db.Model(&models.CourierMessage{}).Count(&total).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	err = r.db.Order("created_at DESC").Offset(offset).Limit(pageSize).Find(&messages).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return messages, total, nil
+}
@@ -1,6 +1,9 @@
 package repository
 
 import (
+	"strings"
+	"time"
+
 	"github.com/securedlinq/backend/internal/models"
 	"gorm.io/gorm"
 )
@@ -50,24 +53,110 @@ func (r *DriverRepository) GetByPhoneNumber(phoneNumber string) (*models.Driver,
 	return &driver, nil
 }
 
-// GetAll gets all drivers with pagination
-func (r *DriverRepository) GetAll(page, pageSize int) ([]models.Driver, int64, error) {
-	var drivers []models.Driver
+// GetByS3AccessKey gets a driver by their pkg/s3gateway access key ID
+func (r *DriverRepository) GetByS3AccessKey(accessKey string) (*models.Driver, error) {
+	var driver models.Driver
+	err := r.db.Where("s3_access_key = ?", accessKey).First(&driver).Error
+	if err != nil {
+		return nil, err
+	}
+	return &driver, nil
+}
+
+// SetS3Keys stores a driver's pkg/s3gateway access key ID and encrypted
+// secret, replacing any previously issued pair.
+func (r *DriverRepository) SetS3Keys(driverID uint, accessKey, secretEncrypted string) error {
+	return r.db.Model(&models.Driver{}).Where("id = ?", driverID).Updates(map[string]interface{}{
+		"s3_access_key":       accessKey,
+		"s3_secret_encrypted": secretEncrypted,
+	}).Error
+}
+
+// DriverFilters narrows GetAll's result set. The zero value matches every
+// non-deleted driver.
+type DriverFilters struct {
+	// Search matches against username, phone_number, and email (LIKE).
+	Search string
+	// Active filters on is_active when non-nil.
+	Active *bool
+	// CreatedAfter filters to drivers created after this time when non-nil.
+	CreatedAfter *time.Time
+	// Sort is "<column>" (descending) or "<column>:asc" (default
+	// "created_at", descending).
+	// Unrecognized columns are ignored.
+	Sort string
+}
+
+// driverSortColumns whitelists the columns GetAll will sort by, since Sort
+// comes from an untrusted query parameter and is otherwise unsafe to
+// interpolate into an ORDER BY clause.
+var driverSortColumns = map[string]bool{
+	"created_at":   true,
+	"username":     true,
+	"phone_number": true,
+	"is_active":    true,
+}
+
+func (f DriverFilters) orderClause() string {
+	column, direction := "created_at", "DESC"
+
+	parts := strings.SplitN(f.Sort, ":", 2)
+	if driverSortColumns[parts[0]] {
+		column = parts[0]
+	}
+	if len(parts) == 2 && strings.EqualFold(parts[1], "asc") {
+		direction = "ASC"
+	}
+
+	return column + " " + direction
+}
+
+// GetAll gets all non-deleted drivers matching filters, with pagination.
+func (r *DriverRepository) GetAll(page, pageSize int, filters DriverFilters) ([]models.Driver, int64, error) {
+	query := r.db.Model(&models.Driver{}).Where("deleted_at IS NULL")
+
+	if filters.Search != "" {
+		like := "%" + filters.Search + "%"
+		query = query.Where("username LIKE ? OR phone_number LIKE ? OR email LIKE ?", like, like, like)
+	}
+	if filters.Active != nil {
+		query = query.Where("is_active = ?", *filters.Active)
+	}
+	if filters.CreatedAfter != nil {
+		query = query.Where("created_at > ?", *filters.CreatedAfter)
+	}
+
 	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
 
 	offset := (page - 1) * pageSize
 
-	err := r.db.Model(&models.Driver{}).Count(&total).Error
+	var drivers []models.Driver
+	err := query.Order(filters.orderClause()).Offset(offset).Limit(pageSize).Find(&drivers).Error
 	if err != nil {
 		return nil, 0, err
 	}
 
-	err = r.db.Order("created_at DESC").Offset(offset).Limit(pageSize).Find(&drivers).Error
-	if err != nil {
+	return drivers, total, nil
+}
+
+// GetAllCursor gets drivers in ID order using cursor pagination (the v2 API
+// replacement for GetAll's page/pageSize): cursor is the last driver ID seen
+// by the caller (0 for the first page), and nextCursor is 0 once there is no
+// further page.
+func (r *DriverRepository) GetAllCursor(cursor uint, limit int) (drivers []models.Driver, nextCursor uint, err error) {
+	if err := r.db.Where("id > ?", cursor).Order("id ASC").Limit(limit + 1).Find(&drivers).Error; err != nil {
 		return nil, 0, err
 	}
 
-	return drivers, total, nil
+	if len(drivers) > limit {
+		nextCursor = drivers[limit-1].ID
+		drivers = drivers[:limit]
+	}
+
+	return drivers, nextCursor, nil
 }
 
 // Update updates a driver
@@ -75,9 +164,38 @@ func (r *DriverRepository) Update(driver *models.Driver) error {
 	return r.db.Save(driver).Error
 }
 
-// Delete deletes a driver
-func (r *DriverRepository) Delete(id uint) error {
-	return r.db.Delete(&models.Driver{}, id).Error
+// SoftDelete marks a driver deleted without removing the row, so GetAll
+// stops returning it while Restore can still reverse it later.
+func (r *DriverRepository) SoftDelete(id uint) error {
+	result := r.db.Model(&models.Driver{}).Where("id = ? AND deleted_at IS NULL", id).Update("deleted_at", time.Now())
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// Restore reverses a prior SoftDelete.
+func (r *DriverRepository) Restore(id uint) error {
+	result := r.db.Model(&models.Driver{}).Where("id = ? AND deleted_at IS NOT NULL", id).Update("deleted_at", nil)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// Transaction runs fn against a DriverRepository scoped to a single
+// database transaction, for callers (e.g. bulk admin actions) that need
+// several driver mutations to commit or roll back together.
+func (r *DriverRepository) Transaction(fn func(tx *DriverRepository) error) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		return fn(&DriverRepository{db: tx})
+	})
 }
 
 // UsernameExists checks if a username already exists
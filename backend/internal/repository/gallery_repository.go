@@ -20,6 +20,16 @@ func (r *GalleryRepository) Create(gallery *models.Gallery) error {
 	return r.db.Create(gallery).Error
 }
 
+// GetByID gets a single gallery entry by ID
+func (r *GalleryRepository) GetByID(id uint) (*models.Gallery, error) {
+	var gallery models.Gallery
+	err := r.db.Where("id = ?", id).First(&gallery).Error
+	if err != nil {
+		return nil, err
+	}
+	return &gallery, nil
+}
+
 // GetByLoadID gets all gallery entries for a specific load
 func (r *GalleryRepository) GetByLoadID(loadID uint) ([]models.Gallery, error) {
 	var galleries []models.Gallery
@@ -38,6 +48,44 @@ func (r *GalleryRepository) GetByLoadIDs(loadIDs []uint) ([]models.Gallery, erro
 	return galleries, err
 }
 
+// GetByContentDigest finds an existing gallery entry with a matching
+// content digest, used to dedupe a recording Agora re-uploaded on retry.
+func (r *GalleryRepository) GetByContentDigest(digest string) (*models.Gallery, error) {
+	var gallery models.Gallery
+	err := r.db.Where("content_digest = ?", digest).Order("created_at ASC").First(&gallery).Error
+	if err != nil {
+		return nil, err
+	}
+	return &gallery, nil
+}
+
+// UpdateScanStatus sets a gallery entry's scan status (see
+// models.GalleryScan* constants).
+func (r *GalleryRepository) UpdateScanStatus(id uint, status string) error {
+	return r.db.Model(&models.Gallery{}).Where("id = ?", id).Update("scan_status", status).Error
+}
+
+// Quarantine marks a gallery entry hidden and records its new key, once
+// the async scan worker has moved the underlying object to quarantine/.
+func (r *GalleryRepository) Quarantine(id uint, newKey string) error {
+	return r.db.Model(&models.Gallery{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"s3_key":      newKey,
+		"scan_status": models.GalleryScanInfected,
+		"hidden":      true,
+	}).Error
+}
+
+// GetPendingScans returns gallery entries awaiting an async malware scan
+// (see ScannerConfig.Mode == "async"), oldest first.
+func (r *GalleryRepository) GetPendingScans(limit int) ([]models.Gallery, error) {
+	var galleries []models.Gallery
+	err := r.db.Where("scan_status = ?", models.GalleryScanSkipped).
+		Order("created_at ASC").
+		Limit(limit).
+		Find(&galleries).Error
+	return galleries, err
+}
+
 // Delete deletes a gallery entry by ID
 func (r *GalleryRepository) Delete(id uint) error {
 	return r.db.Delete(&models.Gallery{}, id).Error
@@ -47,4 +95,3 @@ func (r *GalleryRepository) Delete(id uint) error {
 func (r *GalleryRepository) DeleteByLoadID(loadID uint) error {
 	return r.db.Where("load_id = ?", loadID).Delete(&models.Gallery{}).Error
 }
-
@@ -0,0 +1,93 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"github.com/securedlinq/backend/internal/models"
+	"gorm.io/gorm"
+)
+
+// idempotencyKeyTTL bounds how long a reserved or cached response stays
+// valid before a new request for the same key is allowed to reclaim it.
+const idempotencyKeyTTL = 24 * time.Hour
+
+// ErrIdempotencyKeyInFlight indicates another request with the same
+// (scopeKey, key) is currently being processed and hasn't completed yet.
+var ErrIdempotencyKeyInFlight = errors.New("idempotency key request is already in flight")
+
+// IdempotencyKeyRepository handles idempotency_keys database operations
+type IdempotencyKeyRepository struct {
+	db *gorm.DB
+}
+
+// NewIdempotencyKeyRepository creates a new idempotency key repository
+func NewIdempotencyKeyRepository(db *gorm.DB) *IdempotencyKeyRepository {
+	return &IdempotencyKeyRepository{db: db}
+}
+
+// Reserve atomically claims (scopeKey, key) for the calling request by
+// inserting a placeholder row under the table's unique constraint - this is
+// what keeps two concurrent requests with the same key from both missing a
+// "completed" check and running the handler's side effect twice. It
+// returns (nil, nil) when the caller won the claim and should run the
+// handler and call Complete; the cached record and a nil error when a prior
+// request already finished and its response can be replayed as-is; or
+// ErrIdempotencyKeyInFlight when a prior request claimed the key and is
+// still running.
+func (r *IdempotencyKeyRepository) Reserve(scopeKey, key string) (*models.IdempotencyKey, error) {
+	placeholder := &models.IdempotencyKey{
+		ScopeKey:       scopeKey,
+		IdempotencyKey: key,
+		ExpiresAt:      time.Now().Add(idempotencyKeyTTL),
+	}
+	err := r.db.Create(placeholder).Error
+	if err == nil {
+		return nil, nil
+	}
+	if !errors.Is(err, gorm.ErrDuplicatedKey) {
+		return nil, err
+	}
+
+	var existing models.IdempotencyKey
+	if err := r.db.Where("scope_key = ? AND idempotency_key = ?", scopeKey, key).First(&existing).Error; err != nil {
+		return nil, err
+	}
+
+	// A placeholder left behind by a request that crashed before calling
+	// Complete, or a completed response past its TTL - reclaim the row for
+	// this request instead of leaving it stuck forever.
+	if existing.ExpiresAt.Before(time.Now()) {
+		if err := r.db.Model(&existing).Updates(map[string]interface{}{
+			"status_code":   0,
+			"response_body": "",
+			"expires_at":    time.Now().Add(idempotencyKeyTTL),
+		}).Error; err != nil {
+			return nil, err
+		}
+		return nil, nil
+	}
+
+	if existing.StatusCode == 0 {
+		return nil, ErrIdempotencyKeyInFlight
+	}
+
+	return &existing, nil
+}
+
+// Complete fills in a row this request previously won via Reserve with the
+// handler's actual response, so later Reserve calls for the same key replay
+// it instead of re-running the handler.
+func (r *IdempotencyKeyRepository) Complete(scopeKey, key string, statusCode int, responseBody string) error {
+	return r.db.Model(&models.IdempotencyKey{}).
+		Where("scope_key = ? AND idempotency_key = ?", scopeKey, key).
+		Updates(map[string]interface{}{
+			"status_code":   statusCode,
+			"response_body": responseBody,
+		}).Error
+}
+
+// DeleteExpired deletes all expired idempotency key records
+func (r *IdempotencyKeyRepository) DeleteExpired() error {
+	return r.db.Where("expires_at < ?", time.Now()).Delete(&models.IdempotencyKey{}).Error
+}
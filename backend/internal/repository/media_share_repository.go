@@ -0,0 +1,61 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/securedlinq/backend/internal/models"
+	"gorm.io/gorm"
+)
+
+// MediaShareRepository handles media share database operations
+type MediaShareRepository struct {
+	db *gorm.DB
+}
+
+// NewMediaShareRepository creates a new media share repository
+func NewMediaShareRepository(db *gorm.DB) *MediaShareRepository {
+	return &MediaShareRepository{db: db}
+}
+
+// Create creates a new media share
+func (r *MediaShareRepository) Create(share *models.MediaShare) error {
+	return r.db.Create(share).Error
+}
+
+// GetByTokenHash gets a media share by its token hash, regardless of
+// revoked/expired/exhausted state - callers decide how to treat it.
+func (r *MediaShareRepository) GetByTokenHash(tokenHash string) (*models.MediaShare, error) {
+	var share models.MediaShare
+	err := r.db.Where("token_hash = ?", tokenHash).First(&share).Error
+	if err != nil {
+		return nil, err
+	}
+	return &share, nil
+}
+
+// GetByID gets a media share by ID
+func (r *MediaShareRepository) GetByID(id uint) (*models.MediaShare, error) {
+	var share models.MediaShare
+	err := r.db.Where("id = ?", id).First(&share).Error
+	if err != nil {
+		return nil, err
+	}
+	return &share, nil
+}
+
+// IncrementViewCount bumps a media share's view count by one.
+func (r *MediaShareRepository) IncrementViewCount(id uint) error {
+	return r.db.Model(&models.MediaShare{}).Where("id = ?", id).
+		UpdateColumn("view_count", gorm.Expr("view_count + 1")).Error
+}
+
+// Revoke marks a media share revoked as of now, so it stops resolving even
+// if it hasn't expired or run out of views yet.
+func (r *MediaShareRepository) Revoke(id uint) error {
+	return r.db.Model(&models.MediaShare{}).Where("id = ?", id).Update("revoked_at", time.Now()).Error
+}
+
+// RecordAccess appends an audit log entry for a resolved media share.
+func (r *MediaShareRepository) RecordAccess(access *models.MediaShareAccess) error {
+	return r.db.Create(access).Error
+}
@@ -0,0 +1,32 @@
+package repository
+
+import (
+	"github.com/securedlinq/backend/internal/models"
+	"gorm.io/gorm"
+)
+
+// MeetingJoinSessionRepository handles meeting_join_sessions database operations
+type MeetingJoinSessionRepository struct {
+	*Repository
+}
+
+// NewMeetingJoinSessionRepository creates a new meeting join session repository
+func NewMeetingJoinSessionRepository(db *gorm.DB) *MeetingJoinSessionRepository {
+	return &MeetingJoinSessionRepository{
+		Repository: NewRepository(db),
+	}
+}
+
+// Create records a participant's live occupancy of a meeting room
+func (r *MeetingJoinSessionRepository) Create(session *models.MeetingJoinSession) error {
+	return r.db.Create(session).Error
+}
+
+// CountActive counts participants currently occupying a room (no LeftAt yet)
+func (r *MeetingJoinSessionRepository) CountActive(meetingRoomID uint) (int64, error) {
+	var count int64
+	err := r.db.Model(&models.MeetingJoinSession{}).
+		Where("meeting_room_id = ? AND left_at IS NULL", meetingRoomID).
+		Count(&count).Error
+	return count, err
+}
@@ -0,0 +1,54 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/securedlinq/backend/internal/models"
+	"gorm.io/gorm"
+)
+
+// MeetingJoinTokenRepository handles meeting_join_tokens database operations
+type MeetingJoinTokenRepository struct {
+	*Repository
+}
+
+// NewMeetingJoinTokenRepository creates a new meeting join token repository
+func NewMeetingJoinTokenRepository(db *gorm.DB) *MeetingJoinTokenRepository {
+	return &MeetingJoinTokenRepository{
+		Repository: NewRepository(db),
+	}
+}
+
+// Create stores a newly-minted join token
+func (r *MeetingJoinTokenRepository) Create(token *models.MeetingJoinToken) error {
+	token.CreatedAt = time.Now()
+	return r.db.Create(token).Error
+}
+
+// GetValid retrieves an unused, unexpired join token for a room
+func (r *MeetingJoinTokenRepository) GetValid(meetingRoomID uint, token string) (*models.MeetingJoinToken, error) {
+	var joinToken models.MeetingJoinToken
+	err := r.db.Where("meeting_room_id = ? AND token = ? AND used_at IS NULL AND expires_at > ?", meetingRoomID, token, time.Now()).
+		First(&joinToken).Error
+	if err != nil {
+		return nil, err
+	}
+	return &joinToken, nil
+}
+
+// MarkUsed marks a join token as redeemed so it can't be replayed. It only
+// updates a token that hasn't been used yet, returning gorm.ErrRecordNotFound
+// if it's already been redeemed, so a caller racing a concurrent Join for
+// the same token can tell it lost instead of proceeding as if it won.
+func (r *MeetingJoinTokenRepository) MarkUsed(id uint) error {
+	result := r.db.Model(&models.MeetingJoinToken{}).
+		Where("id = ? AND used_at IS NULL", id).
+		Update("used_at", time.Now())
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
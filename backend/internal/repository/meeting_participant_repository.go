@@ -0,0 +1,44 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/securedlinq/backend/internal/models"
+	"gorm.io/gorm"
+)
+
+// MeetingParticipantRepository handles meeting room participant database operations
+type MeetingParticipantRepository struct {
+	*Repository
+}
+
+// NewMeetingParticipantRepository creates a new meeting participant repository
+func NewMeetingParticipantRepository(db *gorm.DB) *MeetingParticipantRepository {
+	return &MeetingParticipantRepository{
+		Repository: NewRepository(db),
+	}
+}
+
+// Create creates a new meeting room participant
+func (r *MeetingParticipantRepository) Create(participant *models.MeetingRoomParticipant) error {
+	participant.CreatedAt = time.Now()
+	return r.db.Create(participant).Error
+}
+
+// IsDriverInvited reports whether a driver has been explicitly invited to a meeting room
+func (r *MeetingParticipantRepository) IsDriverInvited(meetingRoomID uint, driverID uint) (bool, error) {
+	var count int64
+	err := r.db.Model(&models.MeetingRoomParticipant{}).
+		Where("meeting_room_id = ? AND driver_id = ?", meetingRoomID, driverID).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// GetByMeetingRoomID gets all participants invited to a meeting room
+func (r *MeetingParticipantRepository) GetByMeetingRoomID(meetingRoomID uint) ([]models.MeetingRoomParticipant, error) {
+	var participants []models.MeetingRoomParticipant
+	err := r.db.Where("meeting_room_id = ?", meetingRoomID).
+		Order("created_at DESC").
+		Find(&participants).Error
+	return participants, err
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/securedlinq/backend/internal/models"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // MeetingRepository handles meeting room database operations
@@ -30,20 +31,20 @@ func (r *MeetingRepository) GetByID(id uint) (*models.MeetingRoom, error) {
 	return &meeting, nil
 }
 
-// GetByRoomID retrieves an active meeting room by room ID
+// GetByRoomID retrieves a not-yet-ended meeting room (pending or active) by room ID
 func (r *MeetingRepository) GetByRoomID(roomID string) (*models.MeetingRoom, error) {
 	var meeting models.MeetingRoom
-	err := r.db.Where("roomId = ? AND status = ?", roomID, "active").First(&meeting).Error
+	err := r.db.Where("roomId = ? AND status IN ?", roomID, []string{"pending", "active"}).First(&meeting).Error
 	if err != nil {
 		return nil, err
 	}
 	return &meeting, nil
 }
 
-// GetByLoadID retrieves an active meeting room by load ID
+// GetByLoadID retrieves a not-yet-ended meeting room (pending or active) by load ID
 func (r *MeetingRepository) GetByLoadID(loadID uint) (*models.MeetingRoom, error) {
 	var meeting models.MeetingRoom
-	err := r.db.Where("load_id = ? AND status = ?", loadID, "active").
+	err := r.db.Where("load_id = ? AND status IN ?", loadID, []string{"pending", "active"}).
 		Order("created_at DESC").First(&meeting).Error
 	if err != nil {
 		return nil, err
@@ -51,6 +52,21 @@ func (r *MeetingRepository) GetByLoadID(loadID uint) (*models.MeetingRoom, error
 	return &meeting, nil
 }
 
+// GetByRoomIDForUpdate is GetByRoomID but takes a row lock (SELECT ... FOR
+// UPDATE), for callers (MeetingService.Join) that need to serialize
+// concurrent joins against the same room inside a transaction so the
+// capacity check and the rest of the join can't race each other.
+func (r *MeetingRepository) GetByRoomIDForUpdate(roomID string) (*models.MeetingRoom, error) {
+	var meeting models.MeetingRoom
+	err := r.db.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("roomId = ? AND status IN ?", roomID, []string{"pending", "active"}).
+		First(&meeting).Error
+	if err != nil {
+		return nil, err
+	}
+	return &meeting, nil
+}
+
 // GetByGuestID retrieves an active meeting room by guest ID (legacy method)
 func (r *MeetingRepository) GetByGuestID(guestID int) (*models.MeetingRoom, error) {
 	var meeting models.MeetingRoom
@@ -62,10 +78,10 @@ func (r *MeetingRepository) GetByGuestID(guestID int) (*models.MeetingRoom, erro
 	return &meeting, nil
 }
 
-// GetByChannelName retrieves an active meeting room by channel name
+// GetByChannelName retrieves a not-yet-ended meeting room (pending or active) by channel name
 func (r *MeetingRepository) GetByChannelName(channelName string) (*models.MeetingRoom, error) {
 	var meeting models.MeetingRoom
-	err := r.db.Where("channelName LIKE ? AND status = ?", channelName+"%", "active").
+	err := r.db.Where("channelName LIKE ? AND status IN ?", channelName+"%", []string{"pending", "active"}).
 		Order("created_at DESC").First(&meeting).Error
 	if err != nil {
 		return nil, err
@@ -147,15 +163,17 @@ func (r *MeetingRepository) EndMeeting(roomID string) error {
 		Update("status", "ended").Error
 }
 
-// CreateByLoadID creates a new meeting room for a load
-func (r *MeetingRepository) CreateByLoadID(loadID uint, roomID, channelName, meetingLink, loadNumber string) (*models.MeetingRoom, error) {
+// CreateByLoadID creates a new meeting room for a load, starting in the
+// "pending" state until someone actually joins it via ActivateAndTouch.
+func (r *MeetingRepository) CreateByLoadID(loadID uint, roomID, channelName, meetingLink, loadNumber string, maxParticipants int) (*models.MeetingRoom, error) {
 	newRoom := &models.MeetingRoom{
-		LoadID:      loadID,
-		RoomID:      roomID,
-		ChannelName: channelName,
-		MeetingLink: meetingLink,
-		Status:      "active",
-		CreatedAt:   time.Now(),
+		LoadID:          loadID,
+		RoomID:          roomID,
+		ChannelName:     channelName,
+		MeetingLink:     meetingLink,
+		Status:          "pending",
+		MaxParticipants: maxParticipants,
+		CreatedAt:       time.Now(),
 	}
 	if loadNumber != "" {
 		newRoom.LoadNumber = sql.NullString{String: loadNumber, Valid: true}
@@ -166,6 +184,37 @@ func (r *MeetingRepository) CreateByLoadID(loadID uint, roomID, channelName, mee
 	return newRoom, nil
 }
 
+// ActivateAndTouch marks a pending-or-active room "active" and bumps its
+// lastJoinedAt, so MeetingJanitor's idle check resets on every join.
+func (r *MeetingRepository) ActivateAndTouch(roomID string) error {
+	return r.db.Model(&models.MeetingRoom{}).
+		Where("roomId = ? AND status IN ?", roomID, []string{"pending", "active"}).
+		Updates(map[string]interface{}{
+			"status":       "active",
+			"lastJoinedAt": time.Now(),
+		}).Error
+}
+
+// GetIdle returns pending/active rooms that have had no activity since
+// idleSince, for MeetingJanitor to sweep into the "expired" state.
+func (r *MeetingRepository) GetIdle(idleSince time.Time) ([]models.MeetingRoom, error) {
+	var rooms []models.MeetingRoom
+	err := r.db.Where("status IN ?", []string{"pending", "active"}).
+		Where("(lastJoinedAt IS NOT NULL AND lastJoinedAt < ?) OR (lastJoinedAt IS NULL AND created_at < ?)", idleSince, idleSince).
+		Find(&rooms).Error
+	if err != nil {
+		return nil, err
+	}
+	return rooms, nil
+}
+
+// ExpireRoom marks a room "expired" after MeetingJanitor finds it idle.
+func (r *MeetingRepository) ExpireRoom(roomID string) error {
+	return r.db.Model(&models.MeetingRoom{}).
+		Where("roomId = ?", roomID).
+		Update("status", "expired").Error
+}
+
 // InvalidateAllActiveMeetings invalidates all active meetings for a guest (legacy method)
 func (r *MeetingRepository) InvalidateAllActiveMeetings(guestID int) (int64, error) {
 	result := r.db.Model(&models.MeetingRoom{}).
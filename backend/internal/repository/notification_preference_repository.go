@@ -0,0 +1,51 @@
+package repository
+
+import (
+	"github.com/securedlinq/backend/internal/models"
+	"gorm.io/gorm"
+)
+
+// NotificationPreferenceRepository handles notification_preferences
+// database operations.
+type NotificationPreferenceRepository struct {
+	db *gorm.DB
+}
+
+// NewNotificationPreferenceRepository creates a new notification
+// preference repository.
+func NewNotificationPreferenceRepository(db *gorm.DB) *NotificationPreferenceRepository {
+	return &NotificationPreferenceRepository{db: db}
+}
+
+// GetByDriverID returns every configured preference row for driverID,
+// keyed by channel. A channel with no row is treated by
+// notification.Service as enabled by default with no Destination
+// override.
+func (r *NotificationPreferenceRepository) GetByDriverID(driverID uint) (map[string]models.NotificationPreference, error) {
+	var prefs []models.NotificationPreference
+	if err := r.db.Where("driver_id = ?", driverID).Find(&prefs).Error; err != nil {
+		return nil, err
+	}
+
+	byChannel := make(map[string]models.NotificationPreference, len(prefs))
+	for _, pref := range prefs {
+		byChannel[pref.Channel] = pref
+	}
+	return byChannel, nil
+}
+
+// Upsert creates or updates a driver's preference for a single channel.
+func (r *NotificationPreferenceRepository) Upsert(pref *models.NotificationPreference) error {
+	var existing models.NotificationPreference
+	err := r.db.Where("driver_id = ? AND channel = ?", pref.DriverID, pref.Channel).First(&existing).Error
+	if err == gorm.ErrRecordNotFound {
+		return r.db.Create(pref).Error
+	}
+	if err != nil {
+		return err
+	}
+
+	existing.Enabled = pref.Enabled
+	existing.Destination = pref.Destination
+	return r.db.Save(&existing).Error
+}
@@ -0,0 +1,87 @@
+package repository
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/securedlinq/backend/internal/models"
+	"gorm.io/gorm"
+)
+
+// RecordingJobRepository handles recording_jobs database operations
+type RecordingJobRepository struct {
+	db *gorm.DB
+}
+
+// NewRecordingJobRepository creates a new recording job repository
+func NewRecordingJobRepository(db *gorm.DB) *RecordingJobRepository {
+	return &RecordingJobRepository{db: db}
+}
+
+// Create persists a newly created job, normally in the Stopping state.
+func (r *RecordingJobRepository) Create(job *models.RecordingJob) error {
+	return r.db.Create(job).Error
+}
+
+// GetByID loads a job by its primary key, for ResumeRecordingJob.
+func (r *RecordingJobRepository) GetByID(id uint) (*models.RecordingJob, error) {
+	var job models.RecordingJob
+	if err := r.db.First(&job, id).Error; err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
+
+// GetBySID loads a job by its Agora recording SID.
+func (r *RecordingJobRepository) GetBySID(sid string) (*models.RecordingJob, error) {
+	var job models.RecordingJob
+	if err := r.db.Where("sid = ?", sid).First(&job).Error; err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
+
+// GetStuck returns up to limit jobs that are not yet Complete/Failed and
+// whose next_attempt_at has passed, for the background worker to resume.
+func (r *RecordingJobRepository) GetStuck(limit int) ([]models.RecordingJob, error) {
+	var jobs []models.RecordingJob
+	err := r.db.Where("status NOT IN ? AND (next_attempt_at IS NULL OR next_attempt_at <= ?)",
+		[]string{models.RecordingJobComplete, models.RecordingJobFailed}, time.Now()).
+		Order("next_attempt_at ASC").
+		Limit(limit).
+		Find(&jobs).Error
+	return jobs, err
+}
+
+// UpdateProgress advances a job's state and byte offset.
+func (r *RecordingJobRepository) UpdateProgress(id uint, status string, byteOffset, expectedSize int64) error {
+	return r.db.Model(&models.RecordingJob{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"status":        status,
+		"byte_offset":   byteOffset,
+		"expected_size": expectedSize,
+	}).Error
+}
+
+// MarkComplete records the final S3 key and transitions the job to Complete.
+func (r *RecordingJobRepository) MarkComplete(id uint, s3Key string) error {
+	return r.db.Model(&models.RecordingJob{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"status": models.RecordingJobComplete,
+		"s3_key": s3Key,
+	}).Error
+}
+
+// RecordAttemptError records a failed attempt and schedules the next retry,
+// or marks the job Failed once attempts has hit the caller's cap.
+func (r *RecordingJobRepository) RecordAttemptError(id uint, attempts int, nextAttempt time.Time, lastErr string, failed bool) error {
+	status := models.RecordingJobStopping
+	if failed {
+		status = models.RecordingJobFailed
+	}
+
+	return r.db.Model(&models.RecordingJob{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"status":          status,
+		"attempts":        attempts,
+		"last_error":      lastErr,
+		"next_attempt_at": sql.NullTime{Time: nextAttempt, Valid: !failed},
+	}).Error
+}
@@ -0,0 +1,45 @@
+package repository
+
+import (
+	"github.com/securedlinq/backend/internal/models"
+	"gorm.io/gorm"
+)
+
+// RolePermissionRepository handles role_permissions database operations
+type RolePermissionRepository struct {
+	db *gorm.DB
+}
+
+// NewRolePermissionRepository creates a new role permission repository
+func NewRolePermissionRepository(db *gorm.DB) *RolePermissionRepository {
+	return &RolePermissionRepository{db: db}
+}
+
+// GetByRole returns every permission granted to role
+func (r *RolePermissionRepository) GetByRole(role string) ([]models.RolePermission, error) {
+	var grants []models.RolePermission
+	err := r.db.Where("role = ?", role).Find(&grants).Error
+	return grants, err
+}
+
+// Exists reports whether role has been granted permission
+func (r *RolePermissionRepository) Exists(role, permission string) (bool, error) {
+	var count int64
+	err := r.db.Model(&models.RolePermission{}).
+		Where("role = ? AND permission = ?", role, permission).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// Grant adds permission to role, a no-op if already granted
+func (r *RolePermissionRepository) Grant(role, permission string) error {
+	grant := models.RolePermission{Role: role, Permission: permission}
+	return r.db.Where("role = ? AND permission = ?", role, permission).
+		FirstOrCreate(&grant).Error
+}
+
+// Revoke removes permission from role
+func (r *RolePermissionRepository) Revoke(role, permission string) error {
+	return r.db.Where("role = ? AND permission = ?", role, permission).
+		Delete(&models.RolePermission{}).Error
+}
@@ -0,0 +1,21 @@
+package repository
+
+import (
+	"github.com/securedlinq/backend/internal/models"
+	"gorm.io/gorm"
+)
+
+// SecurityEventRepository handles security event database operations
+type SecurityEventRepository struct {
+	db *gorm.DB
+}
+
+// NewSecurityEventRepository creates a new security event repository
+func NewSecurityEventRepository(db *gorm.DB) *SecurityEventRepository {
+	return &SecurityEventRepository{db: db}
+}
+
+// Create logs a new security event
+func (r *SecurityEventRepository) Create(event *models.SecurityEvent) error {
+	return r.db.Create(event).Error
+}
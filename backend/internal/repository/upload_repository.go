@@ -0,0 +1,58 @@
+package repository
+
+import (
+	"github.com/securedlinq/backend/internal/models"
+	"gorm.io/gorm"
+)
+
+// UploadRepository handles uploads database operations
+type UploadRepository struct {
+	db *gorm.DB
+}
+
+// NewUploadRepository creates a new upload repository
+func NewUploadRepository(db *gorm.DB) *UploadRepository {
+	return &UploadRepository{db: db}
+}
+
+// Create persists a newly initiated multipart upload.
+func (r *UploadRepository) Create(upload *models.Upload) error {
+	return r.db.Create(upload).Error
+}
+
+// GetByUploadID loads an upload by its public UploadID, for resuming it.
+func (r *UploadRepository) GetByUploadID(uploadID string) (*models.Upload, error) {
+	var upload models.Upload
+	if err := r.db.Where("upload_id = ?", uploadID).First(&upload).Error; err != nil {
+		return nil, err
+	}
+	return &upload, nil
+}
+
+// UpdateProgress records how much of the upload has completed so far.
+func (r *UploadRepository) UpdateProgress(uploadID string, bytesUploaded int64, completedPartsJSON string) error {
+	return r.db.Model(&models.Upload{}).Where("upload_id = ?", uploadID).Updates(map[string]interface{}{
+		"bytes_uploaded":  bytesUploaded,
+		"completed_parts": completedPartsJSON,
+	}).Error
+}
+
+// MarkComplete marks the upload finished.
+func (r *UploadRepository) MarkComplete(uploadID string) error {
+	return r.db.Model(&models.Upload{}).Where("upload_id = ?", uploadID).Updates(map[string]interface{}{
+		"status": models.UploadCompleted,
+	}).Error
+}
+
+// MarkFailed records why an upload could not complete, leaving it resumable
+// unless aborted was true (S3 already discarded the multipart upload).
+func (r *UploadRepository) MarkFailed(uploadID string, failErr error, aborted bool) error {
+	status := models.UploadFailed
+	if aborted {
+		status = models.UploadAborted
+	}
+	return r.db.Model(&models.Upload{}).Where("upload_id = ?", uploadID).Updates(map[string]interface{}{
+		"status":     status,
+		"last_error": failErr.Error(),
+	}).Error
+}
@@ -0,0 +1,88 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/securedlinq/backend/internal/models"
+	"gorm.io/gorm"
+)
+
+// WebhookDeliveryRepository handles webhook_deliveries database operations
+type WebhookDeliveryRepository struct {
+	db *gorm.DB
+}
+
+// NewWebhookDeliveryRepository creates a new webhook delivery repository
+func NewWebhookDeliveryRepository(db *gorm.DB) *WebhookDeliveryRepository {
+	return &WebhookDeliveryRepository{db: db}
+}
+
+// Create persists a newly queued delivery attempt
+func (r *WebhookDeliveryRepository) Create(delivery *models.WebhookDelivery) error {
+	return r.db.Create(delivery).Error
+}
+
+// GetDue returns up to limit queued deliveries whose next_attempt_at has
+// passed, oldest first, for the worker to pick up.
+func (r *WebhookDeliveryRepository) GetDue(limit int) ([]models.WebhookDelivery, error) {
+	var deliveries []models.WebhookDelivery
+	err := r.db.Where("status = ? AND next_attempt_at <= ?", "queued", time.Now()).
+		Order("next_attempt_at ASC").
+		Limit(limit).
+		Find(&deliveries).Error
+	return deliveries, err
+}
+
+// MarkSending flags a delivery as in flight so a slower poll cycle doesn't
+// pick it up a second time.
+func (r *WebhookDeliveryRepository) MarkSending(id uint) error {
+	return r.db.Model(&models.WebhookDelivery{}).Where("id = ?", id).Update("status", "sending").Error
+}
+
+// MarkDelivered records a successful delivery.
+func (r *WebhookDeliveryRepository) MarkDelivered(id uint, statusCode int) error {
+	return r.db.Model(&models.WebhookDelivery{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"status":       "delivered",
+		"last_status":  statusCode,
+		"delivered_at": time.Now(),
+	}).Error
+}
+
+// Reschedule records a failed delivery attempt and either requeues it for
+// nextAttempt or marks it abandoned (the dead-letter state) once attempts
+// has hit the worker's cap.
+func (r *WebhookDeliveryRepository) Reschedule(id uint, attempts int, nextAttempt time.Time, lastErr string, statusCode int, abandoned bool) error {
+	status := "queued"
+	if abandoned {
+		status = "abandoned"
+	}
+
+	return r.db.Model(&models.WebhookDelivery{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"status":          status,
+		"attempts":        attempts,
+		"last_error":      lastErr,
+		"last_status":     statusCode,
+		"next_attempt_at": nextAttempt,
+	}).Error
+}
+
+// GetByWebhookID lists delivery attempts for a single webhook with
+// pagination, newest first, so operators can inspect failures.
+func (r *WebhookDeliveryRepository) GetByWebhookID(webhookID uint, page, pageSize int) ([]models.WebhookDelivery, int64, error) {
+	var deliveries []models.WebhookDelivery
+	var total int64
+
+	offset := (page - 1) * pageSize
+
+	err := r.db.Model(&models.WebhookDelivery{}).Where("webhook_id = ?", webhookID).Count(&total).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	err = r.db.Where("webhook_id = ?", webhookID).Order("created_at DESC").Offset(offset).Limit(pageSize).Find(&deliveries).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return deliveries, total, nil
+}
@@ -0,0 +1,70 @@
+package repository
+
+import (
+	"github.com/securedlinq/backend/internal/models"
+	"gorm.io/gorm"
+)
+
+// WebhookRepository handles webhooks database operations
+type WebhookRepository struct {
+	db *gorm.DB
+}
+
+// NewWebhookRepository creates a new webhook repository
+func NewWebhookRepository(db *gorm.DB) *WebhookRepository {
+	return &WebhookRepository{db: db}
+}
+
+// Create registers a new webhook subscription
+func (r *WebhookRepository) Create(webhook *models.Webhook) error {
+	return r.db.Create(webhook).Error
+}
+
+// GetByID gets a webhook by ID
+func (r *WebhookRepository) GetByID(id uint) (*models.Webhook, error) {
+	var webhook models.Webhook
+	err := r.db.Where("id = ?", id).First(&webhook).Error
+	if err != nil {
+		return nil, err
+	}
+	return &webhook, nil
+}
+
+// GetAllActive returns every active webhook subscription, for the
+// dispatcher to filter by event on publish.
+func (r *WebhookRepository) GetAllActive() ([]models.Webhook, error) {
+	var webhooks []models.Webhook
+	err := r.db.Where("active = ?", true).Find(&webhooks).Error
+	return webhooks, err
+}
+
+// GetAll lists webhook subscriptions with pagination, newest first, for
+// the admin management UI.
+func (r *WebhookRepository) GetAll(page, pageSize int) ([]models.Webhook, int64, error) {
+	var webhooks []models.Webhook
+	var total int64
+
+	offset := (page - 1) * pageSize
+
+	err := r.db.Model(&models.Webhook{}).Count(&total).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	err = r.db.Order("created_at DESC").Offset(offset).Limit(pageSize).Find(&webhooks).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return webhooks, total, nil
+}
+
+// Update saves changes to a webhook subscription
+func (r *WebhookRepository) Update(webhook *models.Webhook) error {
+	return r.db.Save(webhook).Error
+}
+
+// Delete removes a webhook subscription
+func (r *WebhookRepository) Delete(id uint) error {
+	return r.db.Delete(&models.Webhook{}, id).Error
+}
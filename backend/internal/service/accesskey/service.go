@@ -0,0 +1,283 @@
+// Package accesskey issues short-lived, per-driver credentials for
+// direct-to-S3 uploads of client-side screenshots and recordings, so the
+// mobile app never sees the master AWS keys held by pkg/s3.Client. Each key
+// is scoped to a single load's upload prefix and expires on its own even if
+// never explicitly revoked.
+package accesskey
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/securedlinq/backend/internal/models"
+	"github.com/securedlinq/backend/internal/repository"
+	"github.com/securedlinq/backend/pkg/s3"
+)
+
+var (
+	// ErrKeyNotFound covers an unknown, revoked, or expired key ID.
+	ErrKeyNotFound = errors.New("access key not found, revoked, or expired")
+	// ErrInvalidSecret is returned when the supplied secret doesn't match
+	// the key's stored hash.
+	ErrInvalidSecret = errors.New("invalid access key secret")
+	// ErrKeyNotOwned is returned when a driver tries to manage another
+	// driver's access key.
+	ErrKeyNotOwned = errors.New("access key not owned by driver")
+	// ErrPrefixMismatch is returned when an object key falls outside the
+	// access key's allowed upload prefix.
+	ErrPrefixMismatch = errors.New("object key is outside the access key's allowed prefix")
+	// ErrLoadNotOwned is returned when a driver requests an access key for a
+	// load that isn't assigned to them.
+	ErrLoadNotOwned = errors.New("load is not assigned to this driver")
+	// ErrEmptyPrefix is returned when a load number yields an empty
+	// AllowedPrefix (cleanAlphanumeric strips it to ""), which would let a
+	// key's prefix check match any object key.
+	ErrEmptyPrefix = errors.New("load number does not yield a usable access key prefix")
+)
+
+// Service mints, validates, and revokes AccessKeys, and presigns S3 uploads
+// on their behalf.
+type Service struct {
+	keyRepo       *repository.AccessKeyRepository
+	loadRepo      *repository.LoadRepository
+	s3Client      *s3.Client
+	secret        string
+	ttl           time.Duration
+	presignExpiry time.Duration
+}
+
+// NewService creates a new Service. secret keys the HMAC used to hash
+// issued secrets, mirroring AuthService.hashAPIToken so a stolen database
+// dump can't be rainbow-tabled back into usable credentials.
+func NewService(keyRepo *repository.AccessKeyRepository, loadRepo *repository.LoadRepository, s3Client *s3.Client, secret string, ttl, presignExpiry time.Duration) *Service {
+	return &Service{
+		keyRepo:       keyRepo,
+		loadRepo:      loadRepo,
+		s3Client:      s3Client,
+		secret:        secret,
+		ttl:           ttl,
+		presignExpiry: presignExpiry,
+	}
+}
+
+// Issue mints a new AccessKey for driverID scoped to loadNumber's upload
+// prefix. The raw secret is returned once for the caller to display; only
+// its hash is persisted. loadNumber must resolve to a load assigned to
+// driverID - without this check a driver could mint a key scoped to another
+// driver's load and plant or overwrite objects in that driver's gallery.
+func (s *Service) Issue(driverID uint, loadNumber string) (secret string, key *models.AccessKey, err error) {
+	load, err := s.loadRepo.GetByLoadNumber(loadNumber)
+	if err != nil {
+		return "", nil, fmt.Errorf("load %q not found: %w", loadNumber, err)
+	}
+	if !load.DriverID.Valid || uint(load.DriverID.Int64) != driverID {
+		return "", nil, ErrLoadNotOwned
+	}
+
+	allowedPrefix := cleanAlphanumeric(loadNumber)
+	if allowedPrefix == "" {
+		return "", nil, ErrEmptyPrefix
+	}
+
+	keyID, err := generateKeyID()
+	if err != nil {
+		return "", nil, err
+	}
+	secret, err = generateSecret()
+	if err != nil {
+		return "", nil, err
+	}
+
+	key = &models.AccessKey{
+		DriverID:      driverID,
+		LoadID:        load.ID,
+		KeyID:         keyID,
+		SecretHash:    s.hashSecret(secret),
+		AllowedPrefix: allowedPrefix,
+		ExpiresAt:     time.Now().Add(s.ttl),
+	}
+
+	if err := s.keyRepo.Create(key); err != nil {
+		return "", nil, err
+	}
+
+	return secret, key, nil
+}
+
+// PresignUpload validates keyID/secret and mints a presigned PUT URL for
+// objectKey, after confirming objectKey falls within the key's allowed
+// prefix.
+func (s *Service) PresignUpload(keyID, secret, objectKey, contentType string) (string, error) {
+	key, err := s.authenticate(keyID, secret)
+	if err != nil {
+		return "", err
+	}
+
+	if !strings.HasPrefix(objectKey, key.AllowedPrefix+"_") && !strings.HasPrefix(objectKey, key.AllowedPrefix+"/") {
+		return "", ErrPrefixMismatch
+	}
+
+	if s.s3Client == nil {
+		return "", errors.New("S3 client is not configured")
+	}
+
+	url, err := s.s3Client.PresignedUploadURL(objectKey, contentType, int64(s.presignExpiry.Seconds()))
+	if err != nil {
+		return "", err
+	}
+
+	_ = s.keyRepo.RecordUsage(key.ID)
+
+	return url, nil
+}
+
+// VerifyUpload confirms objectKey falls within the allowed prefix for the
+// access key that minted it, returning the key (so its LoadID can be used
+// to record the Gallery row) or ErrPrefixMismatch if the client uploaded
+// outside its scoped prefix.
+func (s *Service) VerifyUpload(keyID, objectKey string) (*models.AccessKey, error) {
+	key, err := s.keyRepo.GetByKeyID(keyID)
+	if err != nil {
+		return nil, ErrKeyNotFound
+	}
+
+	if !strings.HasPrefix(objectKey, key.AllowedPrefix+"_") && !strings.HasPrefix(objectKey, key.AllowedPrefix+"/") {
+		return nil, ErrPrefixMismatch
+	}
+
+	return key, nil
+}
+
+// GetKeys lists a driver's access keys
+func (s *Service) GetKeys(driverID uint) ([]models.AccessKey, error) {
+	return s.keyRepo.GetByDriverID(driverID)
+}
+
+// Revoke revokes a driver's access key
+func (s *Service) Revoke(driverID, keyDBID uint) error {
+	key, err := s.keyRepo.GetByID(keyDBID)
+	if err != nil {
+		return ErrKeyNotFound
+	}
+	if key.DriverID != driverID {
+		return ErrKeyNotOwned
+	}
+	return s.keyRepo.Revoke(keyDBID)
+}
+
+// Rotate revokes a driver's existing access key and issues a fresh one with
+// the same scope, so a leaked secret can be replaced without re-deriving
+// the upload prefix.
+func (s *Service) Rotate(driverID, keyDBID uint) (newSecret string, newKey *models.AccessKey, err error) {
+	old, err := s.keyRepo.GetByID(keyDBID)
+	if err != nil {
+		return "", nil, ErrKeyNotFound
+	}
+	if old.DriverID != driverID {
+		return "", nil, ErrKeyNotOwned
+	}
+
+	if err := s.keyRepo.Revoke(keyDBID); err != nil {
+		return "", nil, err
+	}
+
+	keyID, err := generateKeyID()
+	if err != nil {
+		return "", nil, err
+	}
+	newSecret, err = generateSecret()
+	if err != nil {
+		return "", nil, err
+	}
+
+	newKey = &models.AccessKey{
+		DriverID:      old.DriverID,
+		LoadID:        old.LoadID,
+		KeyID:         keyID,
+		SecretHash:    s.hashSecret(newSecret),
+		AllowedPrefix: old.AllowedPrefix,
+		ExpiresAt:     time.Now().Add(s.ttl),
+	}
+
+	if err := s.keyRepo.Create(newKey); err != nil {
+		return "", nil, err
+	}
+
+	return newSecret, newKey, nil
+}
+
+// SweepExpired revokes access keys past their ExpiresAt that weren't
+// explicitly revoked, returning how many it cleaned up. Called by Worker.
+func (s *Service) SweepExpired(limit int) (int, error) {
+	expired, err := s.keyRepo.GetExpiredUnrevoked(limit)
+	if err != nil {
+		return 0, err
+	}
+
+	swept := 0
+	for _, key := range expired {
+		if err := s.keyRepo.Revoke(key.ID); err != nil {
+			continue
+		}
+		swept++
+	}
+
+	return swept, nil
+}
+
+func (s *Service) authenticate(keyID, secret string) (*models.AccessKey, error) {
+	key, err := s.keyRepo.GetByKeyID(keyID)
+	if err != nil {
+		return nil, ErrKeyNotFound
+	}
+
+	if !hmac.Equal([]byte(s.hashSecret(secret)), []byte(key.SecretHash)) {
+		return nil, ErrInvalidSecret
+	}
+
+	return key, nil
+}
+
+// hashSecret derives a lookup hash for a raw access key secret, keyed with
+// the service secret so a stolen database dump alone can't be
+// rainbow-tabled back to usable credentials.
+func (s *Service) hashSecret(raw string) string {
+	mac := hmac.New(sha256.New, []byte(s.secret))
+	mac.Write([]byte(raw))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func generateKeyID() (string, error) {
+	bytes := make([]byte, 4)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(bytes), nil
+}
+
+func generateSecret() (string, error) {
+	bytes := make([]byte, 32)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(bytes), nil
+}
+
+// cleanAlphanumeric removes all non-alphanumeric characters from a string,
+// mirroring agora.cleanAlphanumeric so a load number produces the same
+// folder-safe prefix whether it ends up in Agora's storage config or here.
+func cleanAlphanumeric(s string) string {
+	var result strings.Builder
+	for _, r := range s {
+		if (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') {
+			result.WriteRune(r)
+		}
+	}
+	return result.String()
+}
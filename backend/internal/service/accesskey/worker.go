@@ -0,0 +1,45 @@
+package accesskey
+
+import (
+	"log"
+	"time"
+)
+
+// Worker periodically revokes access keys that have passed their TTL but
+// were never explicitly revoked, so a key can't be presigned against
+// forever just because nobody called Revoke.
+type Worker struct {
+	service  *Service
+	interval time.Duration
+}
+
+// NewWorker creates a new Worker.
+func NewWorker(service *Service, interval time.Duration) *Worker {
+	return &Worker{service: service, interval: interval}
+}
+
+// Run blocks, sweeping for expired keys every interval until stop is closed.
+func (w *Worker) Run(stop <-chan struct{}) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			w.tick()
+		}
+	}
+}
+
+func (w *Worker) tick() {
+	swept, err := w.service.SweepExpired(50)
+	if err != nil {
+		log.Printf("accesskey: failed to sweep expired keys: %v", err)
+		return
+	}
+	if swept > 0 {
+		log.Printf("accesskey: revoked %d expired access key(s)", swept)
+	}
+}
@@ -0,0 +1,126 @@
+package service
+
+import "errors"
+
+// ErrUnknownProvider is returned when a handler asks the registry for an
+// auth provider name that was never registered.
+var ErrUnknownProvider = errors.New("unknown auth provider")
+
+// LoginProvider authenticates a username/password pair and, on success,
+// establishes a session for the resulting principal. AuthService registers
+// one per first-party credential scheme (config-based admin, config-based
+// dispatcher, bcrypt-based driver); handlers look providers up by name
+// instead of branching on hardcoded admin/driver logic.
+type LoginProvider interface {
+	Name() string
+	AttemptLogin(username, password string) (*SessionInfo, error)
+}
+
+// OAuthProvider authenticates a principal that has already proven its
+// identity to a third-party identity provider (e.g. via an OIDC
+// authorization-code flow) and establishes a session for it. subject is the
+// provider's stable user identifier (typically the "sub" claim) and claims
+// is the full userinfo response.
+type OAuthProvider interface {
+	Name() string
+	AttemptLogin(subject string, claims map[string]any) (*SessionInfo, error)
+}
+
+// AuthProviderRegistry looks up the configured LoginProvider/OAuthProvider
+// by name so deployments can add or swap identity providers (Google, Okta,
+// Azure AD, ...) without touching handler code.
+type AuthProviderRegistry struct {
+	loginProviders map[string]LoginProvider
+	oauthProviders map[string]OAuthProvider
+}
+
+// NewAuthProviderRegistry creates an empty provider registry.
+func NewAuthProviderRegistry() *AuthProviderRegistry {
+	return &AuthProviderRegistry{
+		loginProviders: make(map[string]LoginProvider),
+		oauthProviders: make(map[string]OAuthProvider),
+	}
+}
+
+// RegisterLoginProvider adds (or replaces) a LoginProvider under its Name().
+func (r *AuthProviderRegistry) RegisterLoginProvider(p LoginProvider) {
+	r.loginProviders[p.Name()] = p
+}
+
+// RegisterOAuthProvider adds (or replaces) an OAuthProvider under its Name().
+func (r *AuthProviderRegistry) RegisterOAuthProvider(p OAuthProvider) {
+	r.oauthProviders[p.Name()] = p
+}
+
+// LoginProvider looks up a registered LoginProvider by name.
+func (r *AuthProviderRegistry) LoginProvider(name string) (LoginProvider, error) {
+	p, ok := r.loginProviders[name]
+	if !ok {
+		return nil, ErrUnknownProvider
+	}
+	return p, nil
+}
+
+// OAuthProvider looks up a registered OAuthProvider by name.
+func (r *AuthProviderRegistry) OAuthProvider(name string) (OAuthProvider, error) {
+	p, ok := r.oauthProviders[name]
+	if !ok {
+		return nil, ErrUnknownProvider
+	}
+	return p, nil
+}
+
+// OAuthProviderNames returns the names of every registered OAuthProvider,
+// e.g. for a login page to list available SSO options.
+func (r *AuthProviderRegistry) OAuthProviderNames() []string {
+	names := make([]string, 0, len(r.oauthProviders))
+	for name := range r.oauthProviders {
+		names = append(names, name)
+	}
+	return names
+}
+
+// adminLoginProvider authenticates the single config-based admin account.
+type adminLoginProvider struct {
+	auth *AuthService
+}
+
+func (p *adminLoginProvider) Name() string { return "admin" }
+
+func (p *adminLoginProvider) AttemptLogin(username, password string) (*SessionInfo, error) {
+	if err := p.auth.ValidateAdminCredentials(username, password); err != nil {
+		return nil, err
+	}
+	return p.auth.CreateSession(0, "admin")
+}
+
+// dispatcherLoginProvider authenticates the single config-based dispatcher
+// account, the "middle" role between admin and driver.
+type dispatcherLoginProvider struct {
+	auth *AuthService
+}
+
+func (p *dispatcherLoginProvider) Name() string { return "dispatcher" }
+
+func (p *dispatcherLoginProvider) AttemptLogin(username, password string) (*SessionInfo, error) {
+	if err := p.auth.ValidateDispatcherCredentials(username, password); err != nil {
+		return nil, err
+	}
+	return p.auth.CreateSession(0, "dispatcher")
+}
+
+// driverLoginProvider authenticates drivers against bcrypt-hashed passwords
+// stored in the database.
+type driverLoginProvider struct {
+	auth *AuthService
+}
+
+func (p *driverLoginProvider) Name() string { return "driver" }
+
+func (p *driverLoginProvider) AttemptLogin(username, password string) (*SessionInfo, error) {
+	driver, err := p.auth.ValidateDriverCredentials(username, password)
+	if err != nil {
+		return nil, err
+	}
+	return p.auth.CreateSession(int(driver.ID), "driver")
+}
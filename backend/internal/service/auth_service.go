@@ -1,7 +1,9 @@
 package service
 
 import (
+	"crypto/hmac"
 	"crypto/rand"
+	"crypto/sha256"
 	"encoding/hex"
 	"errors"
 	"time"
@@ -19,22 +21,50 @@ var (
 	ErrInvalidPassword    = errors.New("invalid password")
 	ErrAccountDeactivated = errors.New("account is deactivated")
 	ErrInvalidSession     = errors.New("invalid or expired session")
+	ErrInvalidAPIToken    = errors.New("invalid or revoked API token")
+	ErrTokenNotOwned      = errors.New("token does not belong to driver")
 )
 
 // AuthService handles authentication business logic
 type AuthService struct {
-	sessionRepo *repository.SessionRepository
-	driverRepo  *repository.DriverRepository
-	config      *config.Config
+	sessionStore SessionStore
+	driverRepo   *repository.DriverRepository
+	apiTokenRepo *repository.APITokenRepository
+	config       *config.Config
+	providers    *AuthProviderRegistry
 }
 
-// NewAuthService creates a new auth service
-func NewAuthService(sessionRepo *repository.SessionRepository, driverRepo *repository.DriverRepository, cfg *config.Config) *AuthService {
-	return &AuthService{
-		sessionRepo: sessionRepo,
-		driverRepo:  driverRepo,
-		config:      cfg,
+// NewAuthService creates a new auth service. It also builds the
+// AuthProviderRegistry: the config-based admin and dispatcher providers and
+// the bcrypt-based driver LoginProvider are always registered (dispatcher
+// login simply always fails until cfg.Dispatcher.Username is set), and one
+// OIDC OAuthProvider is registered per entry in cfg.OAuth.Providers so
+// operators can enable SSO (Google, Okta, Azure AD, ...) purely through
+// configuration.
+func NewAuthService(sessionStore SessionStore, driverRepo *repository.DriverRepository, apiTokenRepo *repository.APITokenRepository, cfg *config.Config) *AuthService {
+	s := &AuthService{
+		sessionStore: sessionStore,
+		driverRepo:   driverRepo,
+		apiTokenRepo: apiTokenRepo,
+		config:       cfg,
 	}
+
+	s.providers = NewAuthProviderRegistry()
+	s.providers.RegisterLoginProvider(&adminLoginProvider{auth: s})
+	s.providers.RegisterLoginProvider(&dispatcherLoginProvider{auth: s})
+	s.providers.RegisterLoginProvider(&driverLoginProvider{auth: s})
+	for name, oidcCfg := range cfg.OAuth.Providers {
+		s.providers.RegisterOAuthProvider(NewOIDCProvider(name, oidcCfg, driverRepo, s))
+	}
+
+	return s
+}
+
+// Providers returns the registry of LoginProvider/OAuthProvider
+// implementations available to this AuthService, so handlers can dispatch
+// logins by provider name instead of hardcoding admin/driver branches.
+func (s *AuthService) Providers() *AuthProviderRegistry {
+	return s.providers
 }
 
 // LoginCredentials represents login request data
@@ -66,6 +96,26 @@ func (s *AuthService) ValidateAdminCredentials(username, password string) error
 	return ErrUserNotFound
 }
 
+// ValidateDispatcherCredentials validates dispatcher username and password
+// using the single config-based account, mirroring
+// ValidateAdminCredentials. Returns ErrUserNotFound if no dispatcher
+// account is configured (config.Dispatcher.Username is empty).
+func (s *AuthService) ValidateDispatcherCredentials(username, password string) error {
+	if s.config.Dispatcher.Username == "" {
+		return ErrUserNotFound
+	}
+
+	if username == s.config.Dispatcher.Username && password == s.config.Dispatcher.Password {
+		return nil
+	}
+
+	if username == s.config.Dispatcher.Username {
+		return ErrInvalidPassword
+	}
+
+	return ErrUserNotFound
+}
+
 // ValidateDriverCredentials validates driver username and password
 func (s *AuthService) ValidateDriverCredentials(username, password string) (*models.Driver, error) {
 	if s.driverRepo == nil {
@@ -116,7 +166,7 @@ func (s *AuthService) CreateSession(userID int, userType string) (*SessionInfo,
 		ExpiresAt: expiresAt,
 	}
 
-	if err := s.sessionRepo.Create(session); err != nil {
+	if err := s.sessionStore.Create(session); err != nil {
 		return nil, err
 	}
 
@@ -130,13 +180,13 @@ func (s *AuthService) CreateSession(userID int, userType string) (*SessionInfo,
 
 // ValidateSession validates a session and returns session info
 func (s *AuthService) ValidateSession(sessionID string) (*SessionInfo, error) {
-	session, err := s.sessionRepo.GetBySessionID(sessionID)
+	session, err := s.sessionStore.Get(sessionID)
 	if err != nil {
 		return nil, ErrInvalidSession
 	}
 
 	if session.ExpiresAt.Before(time.Now()) {
-		s.sessionRepo.Delete(sessionID)
+		s.sessionStore.Delete(sessionID)
 		return nil, ErrInvalidSession
 	}
 
@@ -151,22 +201,97 @@ func (s *AuthService) ValidateSession(sessionID string) (*SessionInfo, error) {
 // RefreshSession extends a session's expiration time
 func (s *AuthService) RefreshSession(sessionID string) error {
 	duration := time.Duration(s.config.Session.MaxAge) * time.Second
-	return s.sessionRepo.ExtendSession(sessionID, duration)
+	return s.sessionStore.Refresh(sessionID, duration)
 }
 
 // InvalidateSession invalidates a session (logout)
 func (s *AuthService) InvalidateSession(sessionID string) error {
-	return s.sessionRepo.Delete(sessionID)
+	return s.sessionStore.Delete(sessionID)
 }
 
 // InvalidateAllUserSessions invalidates all sessions for a user
 func (s *AuthService) InvalidateAllUserSessions(userID int, userType string) error {
-	return s.sessionRepo.DeleteByUserID(userID, userType)
+	return s.sessionStore.DeleteByUserID(userID, userType)
 }
 
-// CleanupExpiredSessions removes all expired sessions
+// CleanupExpiredSessions removes all expired sessions. This is a no-op when
+// the configured SessionStore expires entries natively (e.g. Redis).
 func (s *AuthService) CleanupExpiredSessions() error {
-	return s.sessionRepo.DeleteExpired()
+	return s.sessionStore.DeleteExpired()
+}
+
+// CreateAPIToken issues a new bearer token for a driver. The raw token is
+// returned once for the caller to display; only its hash is persisted.
+func (s *AuthService) CreateAPIToken(driverID uint, name string) (string, *models.APIToken, error) {
+	raw, err := generateAPIToken()
+	if err != nil {
+		return "", nil, err
+	}
+
+	token := &models.APIToken{
+		DriverID:  driverID,
+		Name:      name,
+		TokenHash: s.hashAPIToken(raw),
+	}
+
+	if err := s.apiTokenRepo.Create(token); err != nil {
+		return "", nil, err
+	}
+
+	return raw, token, nil
+}
+
+// ValidateAPIToken resolves a raw bearer token to session info, mirroring
+// ValidateSession so downstream handlers need no changes.
+func (s *AuthService) ValidateAPIToken(raw string) (*SessionInfo, error) {
+	token, err := s.apiTokenRepo.GetByHash(s.hashAPIToken(raw))
+	if err != nil {
+		return nil, ErrInvalidAPIToken
+	}
+
+	_ = s.apiTokenRepo.RecordUsage(token.ID)
+
+	return &SessionInfo{
+		UserID:   int(token.DriverID),
+		UserType: "driver",
+	}, nil
+}
+
+// GetAPITokens lists a driver's API tokens
+func (s *AuthService) GetAPITokens(driverID uint) ([]models.APIToken, error) {
+	return s.apiTokenRepo.GetByDriverID(driverID)
+}
+
+// RevokeAPIToken revokes a driver's API token
+func (s *AuthService) RevokeAPIToken(driverID, tokenID uint) error {
+	token, err := s.apiTokenRepo.GetByID(tokenID)
+	if err != nil {
+		return ErrUserNotFound
+	}
+
+	if token.DriverID != driverID {
+		return ErrTokenNotOwned
+	}
+
+	return s.apiTokenRepo.Revoke(tokenID)
+}
+
+// hashAPIToken derives a lookup hash for a raw API token, keyed with the
+// session secret so a stolen database dump alone can't be rainbow-tabled
+// back to usable tokens.
+func (s *AuthService) hashAPIToken(raw string) string {
+	mac := hmac.New(sha256.New, []byte(s.config.Session.Secret))
+	mac.Write([]byte(raw))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// generateAPIToken generates a random raw API token
+func generateAPIToken() (string, error) {
+	bytes := make([]byte, 32)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(bytes), nil
 }
 
 // HashPassword hashes a password using bcrypt
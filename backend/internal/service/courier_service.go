@@ -0,0 +1,21 @@
+package service
+
+import (
+	"github.com/securedlinq/backend/internal/models"
+	"github.com/securedlinq/backend/internal/repository"
+)
+
+// CourierService exposes courier queue state to handlers.
+type CourierService struct {
+	courierRepo *repository.CourierRepository
+}
+
+// NewCourierService creates a new courier service
+func NewCourierService(courierRepo *repository.CourierRepository) *CourierService {
+	return &CourierService{courierRepo: courierRepo}
+}
+
+// GetAllMessages lists courier_messages with pagination, newest first
+func (s *CourierService) GetAllMessages(page, pageSize int) ([]models.CourierMessage, int64, error) {
+	return s.courierRepo.GetAll(page, pageSize)
+}
@@ -1,6 +1,8 @@
 package service
 
 import (
+	"crypto/rand"
+	"encoding/hex"
 	"errors"
 
 	"github.com/securedlinq/backend/internal/models"
@@ -92,9 +94,16 @@ func (s *DriverService) GetDriverByID(id uint) (*models.Driver, error) {
 	return s.driverRepo.GetByID(id)
 }
 
-// GetAllDrivers gets all drivers with pagination
-func (s *DriverService) GetAllDrivers(page, pageSize int) ([]models.Driver, int64, error) {
-	return s.driverRepo.GetAll(page, pageSize)
+// GetAllDrivers gets all non-deleted drivers matching filters, with pagination
+func (s *DriverService) GetAllDrivers(page, pageSize int, filters repository.DriverFilters) ([]models.Driver, int64, error) {
+	return s.driverRepo.GetAll(page, pageSize, filters)
+}
+
+// GetAllDriversCursor lists drivers using cursor pagination (v2 API),
+// ordered by ID ascending. cursor is the last ID seen by the caller (0 for
+// the first page); nextCursor is 0 once there is no further page.
+func (s *DriverService) GetAllDriversCursor(cursor uint, limit int) ([]models.Driver, uint, error) {
+	return s.driverRepo.GetAllCursor(cursor, limit)
 }
 
 // UpdateDriver updates a driver's information
@@ -122,3 +131,121 @@ func (s *DriverService) ActivateDriver(id uint) error {
 	return s.driverRepo.Update(driver)
 }
 
+// DeleteDriver soft-deletes a driver account: it stops appearing in
+// GetAllDrivers but the row is kept around so RestoreDriver can reverse it.
+// Callers should still gate this behind UIAuthService, same as before.
+func (s *DriverService) DeleteDriver(id uint) error {
+	return s.driverRepo.SoftDelete(id)
+}
+
+// RestoreDriver reverses a prior soft-delete.
+func (s *DriverService) RestoreDriver(id uint) error {
+	return s.driverRepo.Restore(id)
+}
+
+// BulkActionResult reports the outcome of a single driver ID within a
+// BulkAction call.
+type BulkActionResult struct {
+	ID      uint   `json:"id"`
+	Success bool   `json:"success"`
+	Error   string `json:"error,omitempty"`
+}
+
+// Bulk admin actions accepted by BulkAction.
+const (
+	BulkActionActivate   = "activate"
+	BulkActionDeactivate = "deactivate"
+	BulkActionDelete     = "delete"
+)
+
+// ErrInvalidBulkAction is returned for an action outside BulkActivate/
+// BulkDeactivate/BulkDelete.
+var ErrInvalidBulkAction = errors.New("invalid bulk action")
+
+// BulkAction applies action to every driver in ids inside a single
+// transaction. A per-ID failure (e.g. an unknown ID) is recorded in that
+// ID's result rather than aborting the whole batch; the transaction only
+// rolls back on an unexpected database error.
+func (s *DriverService) BulkAction(ids []uint, action string) ([]BulkActionResult, error) {
+	switch action {
+	case BulkActionActivate, BulkActionDeactivate, BulkActionDelete:
+	default:
+		return nil, ErrInvalidBulkAction
+	}
+
+	results := make([]BulkActionResult, 0, len(ids))
+
+	err := s.driverRepo.Transaction(func(tx *repository.DriverRepository) error {
+		for _, id := range ids {
+			var actionErr error
+			switch action {
+			case BulkActionActivate:
+				driver, err := tx.GetByID(id)
+				if err == nil {
+					driver.IsActive = true
+					actionErr = tx.Update(driver)
+				} else {
+					actionErr = err
+				}
+			case BulkActionDeactivate:
+				driver, err := tx.GetByID(id)
+				if err == nil {
+					driver.IsActive = false
+					actionErr = tx.Update(driver)
+				} else {
+					actionErr = err
+				}
+			case BulkActionDelete:
+				actionErr = tx.SoftDelete(id)
+			}
+
+			result := BulkActionResult{ID: id, Success: actionErr == nil}
+			if actionErr != nil {
+				result.Error = actionErr.Error()
+			}
+			results = append(results, result)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+// ResetDriverPassword generates a new random password for a driver and
+// persists its bcrypt hash. The raw password is returned once for the
+// caller to display; it cannot be recovered afterward.
+func (s *DriverService) ResetDriverPassword(id uint) (string, error) {
+	driver, err := s.driverRepo.GetByID(id)
+	if err != nil {
+		return "", err
+	}
+
+	raw, err := generateRandomPassword()
+	if err != nil {
+		return "", err
+	}
+
+	hashed, err := bcrypt.GenerateFromPassword([]byte(raw), bcrypt.DefaultCost)
+	if err != nil {
+		return "", errors.New("failed to hash password")
+	}
+
+	driver.PasswordHash = string(hashed)
+	if err := s.driverRepo.Update(driver); err != nil {
+		return "", err
+	}
+
+	return raw, nil
+}
+
+// generateRandomPassword generates a random hex password for ResetDriverPassword.
+func generateRandomPassword() (string, error) {
+	bytes := make([]byte, 12)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(bytes), nil
+}
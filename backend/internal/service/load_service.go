@@ -5,6 +5,7 @@ import (
 	"errors"
 	"time"
 
+	"github.com/securedlinq/backend/internal/events"
 	"github.com/securedlinq/backend/internal/models"
 	"github.com/securedlinq/backend/internal/repository"
 )
@@ -13,16 +14,26 @@ import (
 type LoadService struct {
 	loadRepo   *repository.LoadRepository
 	driverRepo *repository.DriverRepository
+	events     *events.Bus
 }
 
 // NewLoadService creates a new load service
-func NewLoadService(loadRepo *repository.LoadRepository, driverRepo *repository.DriverRepository) *LoadService {
+func NewLoadService(loadRepo *repository.LoadRepository, driverRepo *repository.DriverRepository, eventsBus *events.Bus) *LoadService {
 	return &LoadService{
 		loadRepo:   loadRepo,
 		driverRepo: driverRepo,
+		events:     eventsBus,
 	}
 }
 
+// LoadEventPayload is the events.Event payload published for load.assigned
+// and load.completed.
+type LoadEventPayload struct {
+	LoadID     uint   `json:"load_id"`
+	LoadNumber string `json:"load_number"`
+	DriverID   uint   `json:"driver_id,omitempty"`
+}
+
 // CreateLoadRequest represents load creation data
 type CreateLoadRequest struct {
 	LoadNumber      string     `json:"load_number" binding:"required"`
@@ -113,7 +124,16 @@ func (s *LoadService) AssignDriverToLoad(loadID uint, driverID uint) error {
 		return errors.New("cannot assign driver to completed load")
 	}
 
-	return s.loadRepo.AssignDriver(loadID, driverID)
+	if err := s.loadRepo.AssignDriver(loadID, driverID); err != nil {
+		return err
+	}
+
+	s.events.Publish(events.LoadAssigned, LoadEventPayload{
+		LoadID:     loadID,
+		LoadNumber: load.LoadNumber,
+		DriverID:   driverID,
+	})
+	return nil
 }
 
 // UpdateLoadStatus updates a load's status
@@ -152,7 +172,15 @@ func (s *LoadService) UpdateLoadStatus(loadID uint, status string, driverID uint
 	}
 
 	if status == "Completed" {
-		return s.loadRepo.MarkCompleted(loadID)
+		if err := s.loadRepo.MarkCompleted(loadID); err != nil {
+			return err
+		}
+		s.events.Publish(events.LoadCompleted, LoadEventPayload{
+			LoadID:     loadID,
+			LoadNumber: load.LoadNumber,
+			DriverID:   driverID,
+		})
+		return nil
 	}
 
 	return s.loadRepo.UpdateStatus(loadID, status)
@@ -0,0 +1,96 @@
+package service
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/securedlinq/backend/internal/models"
+	"github.com/securedlinq/backend/internal/repository"
+)
+
+// Matrix-style ACL error codes, so handlers can return a stable reason
+// instead of leaking a plain 404 for rooms the caller isn't permitted to see.
+var (
+	ErrMeetingForbidden = errors.New("FORBIDDEN")
+	ErrMeetingNotJoined = errors.New("NOT_JOINED")
+)
+
+// MeetingACLService resolves the load behind a meeting room and decides
+// whether a session user is permitted to access it: admins always are;
+// drivers only if they are the load's assigned driver or have been
+// explicitly invited as a participant.
+type MeetingACLService struct {
+	meetingRepo     *repository.MeetingRepository
+	loadRepo        *repository.LoadRepository
+	participantRepo *repository.MeetingParticipantRepository
+}
+
+// NewMeetingACLService creates a new meeting ACL service
+func NewMeetingACLService(
+	meetingRepo *repository.MeetingRepository,
+	loadRepo *repository.LoadRepository,
+	participantRepo *repository.MeetingParticipantRepository,
+) *MeetingACLService {
+	return &MeetingACLService{
+		meetingRepo:     meetingRepo,
+		loadRepo:        loadRepo,
+		participantRepo: participantRepo,
+	}
+}
+
+// CheckAccess verifies that the given session user may access the meeting
+// room identified by roomID, returning the underlying room on success.
+func (s *MeetingACLService) CheckAccess(roomID, userType string, userID int) (*models.MeetingRoom, error) {
+	room, err := s.meetingRepo.GetByRoomID(roomID)
+	if err != nil {
+		return nil, ErrMeetingNotJoined
+	}
+
+	if userType == "admin" {
+		return room, nil
+	}
+
+	if room.LoadID == 0 {
+		return nil, ErrMeetingForbidden
+	}
+
+	load, err := s.loadRepo.GetByID(room.LoadID)
+	if err != nil {
+		return nil, ErrMeetingForbidden
+	}
+
+	if load.DriverID.Valid && uint(load.DriverID.Int64) == uint(userID) {
+		return room, nil
+	}
+
+	invited, err := s.participantRepo.IsDriverInvited(room.ID, uint(userID))
+	if err == nil && invited {
+		return room, nil
+	}
+
+	return nil, ErrMeetingForbidden
+}
+
+// InviteParticipant grants an additional driver or guest access to a meeting
+// room. Either driverID or phoneNumber must identify the invitee.
+func (s *MeetingACLService) InviteParticipant(roomID string, driverID uint, phoneNumber, role string, invitedByAdminID int) error {
+	room, err := s.meetingRepo.GetByRoomID(roomID)
+	if err != nil {
+		return ErrMeetingNotJoined
+	}
+
+	participant := &models.MeetingRoomParticipant{
+		MeetingRoomID:    room.ID,
+		Role:             role,
+		InvitedByAdminID: invitedByAdminID,
+	}
+
+	if driverID > 0 {
+		participant.DriverID = sql.NullInt64{Int64: int64(driverID), Valid: true}
+	}
+	if phoneNumber != "" {
+		participant.PhoneNumber = sql.NullString{String: phoneNumber, Valid: true}
+	}
+
+	return s.participantRepo.Create(participant)
+}
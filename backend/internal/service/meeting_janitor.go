@@ -0,0 +1,53 @@
+package service
+
+import (
+	"log"
+	"time"
+
+	"github.com/securedlinq/backend/internal/repository"
+)
+
+// MeetingJanitor periodically sweeps meeting rooms that have sat idle past
+// config.MeetingConfig.IdleExpiryMinutes into the "expired" state. Agora RTC
+// tokens are stateless and can't be revoked server-side once issued, so this
+// is the only real revocation lever: once a room is expired, no further join
+// tokens are minted for it and Join refuses to hand out a new RTC token.
+type MeetingJanitor struct {
+	meetingRepo *repository.MeetingRepository
+	idleAfter   time.Duration
+	interval    time.Duration
+}
+
+// NewMeetingJanitor creates a new MeetingJanitor.
+func NewMeetingJanitor(meetingRepo *repository.MeetingRepository, idleAfter, interval time.Duration) *MeetingJanitor {
+	return &MeetingJanitor{meetingRepo: meetingRepo, idleAfter: idleAfter, interval: interval}
+}
+
+// Run blocks, sweeping idle rooms every interval until stop is closed.
+func (w *MeetingJanitor) Run(stop <-chan struct{}) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			w.tick()
+		}
+	}
+}
+
+func (w *MeetingJanitor) tick() {
+	idleRooms, err := w.meetingRepo.GetIdle(time.Now().Add(-w.idleAfter))
+	if err != nil {
+		log.Printf("meeting: failed to load idle rooms: %v", err)
+		return
+	}
+
+	for _, room := range idleRooms {
+		if err := w.meetingRepo.ExpireRoom(room.RoomID); err != nil {
+			log.Printf("meeting: failed to expire room %s: %v", room.RoomID, err)
+		}
+	}
+}
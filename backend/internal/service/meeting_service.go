@@ -1,47 +1,91 @@
 package service
 
 import (
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/securedlinq/backend/internal/config"
+	"github.com/securedlinq/backend/internal/events"
 	"github.com/securedlinq/backend/internal/models"
 	"github.com/securedlinq/backend/internal/repository"
+	"gorm.io/gorm"
 )
 
+// ErrJoinTokenInvalid is returned when a join token is missing, expired,
+// already used, or doesn't belong to the room it was presented for.
+var ErrJoinTokenInvalid = errors.New("join token is invalid or has expired")
+
+// ErrRoomNotJoinable is returned when a room has already ended or expired.
+var ErrRoomNotJoinable = errors.New("meeting room is no longer joinable")
+
+// ErrRoomFull is returned when a room is already at MaxParticipants.
+var ErrRoomFull = errors.New("meeting room is full")
+
 // MeetingService handles meeting business logic
 type MeetingService struct {
-	meetingRepo *repository.MeetingRepository
-	loadRepo    *repository.LoadRepository
-	config      *config.Config
+	meetingRepo     *repository.MeetingRepository
+	loadRepo        *repository.LoadRepository
+	joinTokenRepo   *repository.MeetingJoinTokenRepository
+	joinSessionRepo *repository.MeetingJoinSessionRepository
+	config          *config.Config
+	events          *events.Bus
 }
 
 // NewMeetingService creates a new meeting service
 func NewMeetingService(
 	meetingRepo *repository.MeetingRepository,
 	loadRepo *repository.LoadRepository,
+	joinTokenRepo *repository.MeetingJoinTokenRepository,
+	joinSessionRepo *repository.MeetingJoinSessionRepository,
 	cfg *config.Config,
+	eventsBus *events.Bus,
 ) *MeetingService {
 	return &MeetingService{
-		meetingRepo: meetingRepo,
-		loadRepo:    loadRepo,
-		config:      cfg,
+		meetingRepo:     meetingRepo,
+		loadRepo:        loadRepo,
+		joinTokenRepo:   joinTokenRepo,
+		joinSessionRepo: joinSessionRepo,
+		config:          cfg,
+		events:          eventsBus,
 	}
 }
 
+// MeetingEventPayload is the events.Event payload published for
+// meeting.created, meeting.joined, and meeting.ended.
+type MeetingEventPayload struct {
+	RoomID      string `json:"room_id"`
+	LoadID      uint   `json:"load_id"`
+	LoadNumber  string `json:"load_number,omitempty"`
+	ChannelName string `json:"channel_name,omitempty"`
+}
+
 // MeetingRoomInfo represents meeting room information for API responses
 type MeetingRoomInfo struct {
-	ID           uint   `json:"id"`
-	LoadID       uint   `json:"load_id"`
-	RoomID       string `json:"roomId"`
-	ChannelName  string `json:"channelName"`
-	MeetingLink  string `json:"meetingLink"`
-	LoadNumber   string `json:"load_number,omitempty"`
-	SaveType     string `json:"save_type,omitempty"`
-	Status       string `json:"status"`
-	CreatedAt    string `json:"created_at"`
-	LastJoinedAt string `json:"lastJoinedAt,omitempty"`
+	ID                 uint   `json:"id"`
+	LoadID             uint   `json:"load_id"`
+	RoomID             string `json:"roomId"`
+	ChannelName        string `json:"channelName"`
+	MeetingLink        string `json:"meetingLink"`
+	LoadNumber         string `json:"load_number,omitempty"`
+	SaveType           string `json:"save_type,omitempty"`
+	Status             string `json:"status"`
+	MaxParticipants    int    `json:"max_participants"`
+	CreatedAt          string `json:"created_at"`
+	LastJoinedAt       string `json:"lastJoinedAt,omitempty"`
+	JoinToken          string `json:"joinToken,omitempty"`
+	JoinTokenExpiresAt string `json:"joinTokenExpiresAt,omitempty"`
+}
+
+// JoinResult is what POST /meetings/:roomId/join exchanges a join token for.
+type JoinResult struct {
+	ChannelName string
+	UID         string
 }
 
 // GetOrCreateMeetingRoom gets an existing meeting room or creates a new one based on load_id
@@ -54,8 +98,8 @@ func (s *MeetingService) GetOrCreateMeetingRoom(loadID uint) (*MeetingRoomInfo,
 
 	// Check if meeting room already exists for this load
 	existingRoom, err := s.meetingRepo.GetByLoadID(loadID)
-	if err == nil && existingRoom != nil && existingRoom.Status == "active" {
-		return s.roomToInfo(existingRoom), nil
+	if err == nil && existingRoom != nil {
+		return s.attachJoinToken(existingRoom)
 	}
 
 	// Generate room_id: load_id + random identifier
@@ -72,12 +116,19 @@ func (s *MeetingService) GetOrCreateMeetingRoom(loadID uint) (*MeetingRoomInfo,
 	loadNumber := load.LoadNumber
 
 	// Create new meeting room
-	room, err := s.meetingRepo.CreateByLoadID(loadID, roomID, channelName, meetingLink, loadNumber)
+	room, err := s.meetingRepo.CreateByLoadID(loadID, roomID, channelName, meetingLink, loadNumber, s.config.Meeting.MaxParticipants)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create meeting room: %w", err)
 	}
 
-	return s.roomToInfo(room), nil
+	s.events.Publish(events.MeetingCreated, MeetingEventPayload{
+		RoomID:      room.RoomID,
+		LoadID:      room.LoadID,
+		LoadNumber:  loadNumber,
+		ChannelName: room.ChannelName,
+	})
+
+	return s.attachJoinToken(room)
 }
 
 // GetMeetingRoomByRoomID gets a meeting room by room ID
@@ -86,30 +137,161 @@ func (s *MeetingService) GetMeetingRoomByRoomID(roomID string) (*MeetingRoomInfo
 	if err != nil {
 		return nil, fmt.Errorf("meeting room not found or expired: %w", err)
 	}
-	return s.roomToInfo(room), nil
+	return s.attachJoinToken(room)
 }
 
 // UpdateLastJoined updates the last joined timestamp
 func (s *MeetingService) UpdateLastJoined(roomID string) error {
-	return s.meetingRepo.UpdateLastJoined(roomID)
+	if err := s.meetingRepo.ActivateAndTouch(roomID); err != nil {
+		return err
+	}
+
+	if room, err := s.meetingRepo.GetByRoomID(roomID); err == nil {
+		s.events.Publish(events.MeetingJoined, s.roomEventPayload(room))
+	}
+	return nil
+}
+
+// IssueJoinToken mints a fresh, single-use join token for a room, to be
+// redeemed exactly once via Join in place of handing out the Agora RTC
+// token directly.
+func (s *MeetingService) IssueJoinToken(room *models.MeetingRoom) (*models.MeetingJoinToken, error) {
+	rawUID, err := generateAgoraUID()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate agora uid: %w", err)
+	}
+	rawToken, err := generateJoinToken()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate join token: %w", err)
+	}
+
+	joinToken := &models.MeetingJoinToken{
+		MeetingRoomID: room.ID,
+		Token:         rawToken,
+		UID:           rawUID,
+		ExpiresAt:     time.Now().Add(time.Duration(s.config.Meeting.JoinTokenTTLSeconds) * time.Second),
+	}
+	if err := s.joinTokenRepo.Create(joinToken); err != nil {
+		return nil, fmt.Errorf("failed to store join token: %w", err)
+	}
+	return joinToken, nil
+}
+
+// Join redeems a single-use join token for live entry into a room: it
+// validates the token and room lifecycle, enforces MaxParticipants, and
+// records a MeetingJoinSession. The caller mints the actual Agora RTC
+// token from the returned ChannelName/UID.
+//
+// The whole sequence runs inside a transaction that takes a row lock on the
+// room via GetByRoomIDForUpdate, so two concurrent Join calls for the same
+// room can't both pass the MaxParticipants check, and MarkUsed rejects a
+// token a concurrent call already redeemed - closing both the capacity race
+// and the token-reuse race instead of just one of them.
+func (s *MeetingService) Join(roomID, token string, sessionInfo *SessionInfo) (*JoinResult, error) {
+	var result *JoinResult
+
+	err := s.meetingRepo.DB().Transaction(func(tx *gorm.DB) error {
+		meetingRepo := repository.NewMeetingRepository(tx)
+		joinTokenRepo := repository.NewMeetingJoinTokenRepository(tx)
+		joinSessionRepo := repository.NewMeetingJoinSessionRepository(tx)
+
+		room, err := meetingRepo.GetByRoomIDForUpdate(roomID)
+		if err != nil {
+			return ErrRoomNotJoinable
+		}
+
+		joinToken, err := joinTokenRepo.GetValid(room.ID, token)
+		if err != nil {
+			return ErrJoinTokenInvalid
+		}
+
+		activeCount, err := joinSessionRepo.CountActive(room.ID)
+		if err != nil {
+			return fmt.Errorf("failed to count active participants: %w", err)
+		}
+		if int(activeCount) >= room.MaxParticipants {
+			return ErrRoomFull
+		}
+
+		if err := joinTokenRepo.MarkUsed(joinToken.ID); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrJoinTokenInvalid
+			}
+			return fmt.Errorf("failed to mark join token used: %w", err)
+		}
+
+		session := &models.MeetingJoinSession{
+			MeetingRoomID: room.ID,
+			AgoraUID:      joinToken.UID,
+			JoinedAt:      time.Now(),
+		}
+		if sessionInfo != nil {
+			session.SessionID = nullString(sessionInfo.SessionID)
+			session.UserType = nullString(sessionInfo.UserType)
+		}
+		if err := joinSessionRepo.Create(session); err != nil {
+			return fmt.Errorf("failed to record join session: %w", err)
+		}
+
+		if err := meetingRepo.ActivateAndTouch(roomID); err != nil {
+			return err
+		}
+
+		result = &JoinResult{ChannelName: room.ChannelName, UID: joinToken.UID}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if room, err := s.meetingRepo.GetByRoomID(roomID); err == nil {
+		s.events.Publish(events.MeetingJoined, s.roomEventPayload(room))
+	}
+
+	return result, nil
 }
 
 // EndMeeting ends a meeting room
 func (s *MeetingService) EndMeeting(roomID string) error {
-	return s.meetingRepo.EndMeeting(roomID)
+	room, err := s.meetingRepo.GetByRoomID(roomID)
+	if err != nil {
+		return s.meetingRepo.EndMeeting(roomID)
+	}
+
+	if err := s.meetingRepo.EndMeeting(roomID); err != nil {
+		return err
+	}
+
+	s.events.Publish(events.MeetingEnded, s.roomEventPayload(room))
+	return nil
+}
+
+// EndMeetingForLoad ends the active meeting room associated with a load.
+func (s *MeetingService) EndMeetingForLoad(loadID uint) error {
+	room, err := s.meetingRepo.GetByLoadID(loadID)
+	if err != nil {
+		return fmt.Errorf("meeting room not found: %w", err)
+	}
+	if err := s.meetingRepo.EndMeeting(room.RoomID); err != nil {
+		return err
+	}
+
+	s.events.Publish(events.MeetingEnded, s.roomEventPayload(room))
+	return nil
 }
 
 // Helper functions
 
 func (s *MeetingService) roomToInfo(room *models.MeetingRoom) *MeetingRoomInfo {
 	info := &MeetingRoomInfo{
-		ID:          room.ID,
-		LoadID:      room.LoadID,
-		RoomID:      room.RoomID,
-		ChannelName: room.ChannelName,
-		MeetingLink: room.MeetingLink,
-		Status:      room.Status,
-		CreatedAt:   room.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+		ID:              room.ID,
+		LoadID:          room.LoadID,
+		RoomID:          room.RoomID,
+		ChannelName:     room.ChannelName,
+		MeetingLink:     room.MeetingLink,
+		Status:          room.Status,
+		MaxParticipants: room.MaxParticipants,
+		CreatedAt:       room.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
 	}
 	if room.LoadNumber.Valid {
 		info.LoadNumber = room.LoadNumber.String
@@ -123,6 +305,62 @@ func (s *MeetingService) roomToInfo(room *models.MeetingRoom) *MeetingRoomInfo {
 	return info
 }
 
+// attachJoinToken builds a MeetingRoomInfo for an ended/expired room as-is,
+// or for a still-joinable room mints a fresh join token and attaches it so
+// the caller can exchange it for an Agora RTC token via Join.
+func (s *MeetingService) attachJoinToken(room *models.MeetingRoom) (*MeetingRoomInfo, error) {
+	info := s.roomToInfo(room)
+	if room.Status != "pending" && room.Status != "active" {
+		return info, nil
+	}
+
+	joinToken, err := s.IssueJoinToken(room)
+	if err != nil {
+		return nil, err
+	}
+	info.JoinToken = joinToken.Token
+	info.JoinTokenExpiresAt = joinToken.ExpiresAt.Format("2006-01-02T15:04:05Z07:00")
+	return info, nil
+}
+
+// generateAgoraUID generates a random numeric-looking Agora UID distinct
+// from the join token itself.
+func generateAgoraUID() (string, error) {
+	bytes := make([]byte, 8)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(bytes), nil
+}
+
+// generateJoinToken generates a random single-use join token
+func generateJoinToken() (string, error) {
+	bytes := make([]byte, 32)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(bytes), nil
+}
+
+func nullString(s string) sql.NullString {
+	if s == "" {
+		return sql.NullString{}
+	}
+	return sql.NullString{String: s, Valid: true}
+}
+
+func (s *MeetingService) roomEventPayload(room *models.MeetingRoom) MeetingEventPayload {
+	payload := MeetingEventPayload{
+		RoomID:      room.RoomID,
+		LoadID:      room.LoadID,
+		ChannelName: room.ChannelName,
+	}
+	if room.LoadNumber.Valid {
+		payload.LoadNumber = room.LoadNumber.String
+	}
+	return payload
+}
+
 func generateRoomID() string {
 	id := uuid.New().String()
 	return strings.ReplaceAll(id, "-", "")[:12]
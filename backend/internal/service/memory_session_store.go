@@ -0,0 +1,50 @@
+package service
+
+import (
+	"time"
+
+	"github.com/securedlinq/backend/internal/models"
+	"github.com/securedlinq/backend/internal/repository"
+)
+
+// MemorySessionStore is the default SessionStore, backed by the sessions
+// table via SessionRepository. It is called "memory" to mirror the
+// gin-contrib/sessions naming convention, even though it persists to the
+// database rather than process memory - the behavior the config option
+// `Backend: "memory"` toggles is "no external cache required".
+type MemorySessionStore struct {
+	sessionRepo *repository.SessionRepository
+}
+
+// NewMemorySessionStore creates a new database-backed session store.
+func NewMemorySessionStore(sessionRepo *repository.SessionRepository) *MemorySessionStore {
+	return &MemorySessionStore{sessionRepo: sessionRepo}
+}
+
+func (s *MemorySessionStore) Create(session *models.Session) error {
+	return s.sessionRepo.Create(session)
+}
+
+func (s *MemorySessionStore) Get(sessionID string) (*models.Session, error) {
+	session, err := s.sessionRepo.GetBySessionID(sessionID)
+	if err != nil {
+		return nil, ErrSessionNotFound
+	}
+	return session, nil
+}
+
+func (s *MemorySessionStore) Refresh(sessionID string, maxAge time.Duration) error {
+	return s.sessionRepo.ExtendSession(sessionID, maxAge)
+}
+
+func (s *MemorySessionStore) Delete(sessionID string) error {
+	return s.sessionRepo.Delete(sessionID)
+}
+
+func (s *MemorySessionStore) DeleteByUserID(userID int, userType string) error {
+	return s.sessionRepo.DeleteByUserID(userID, userType)
+}
+
+func (s *MemorySessionStore) DeleteExpired() error {
+	return s.sessionRepo.DeleteExpired()
+}
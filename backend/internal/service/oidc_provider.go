@@ -0,0 +1,242 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/securedlinq/backend/internal/config"
+	"github.com/securedlinq/backend/internal/models"
+	"github.com/securedlinq/backend/internal/repository"
+)
+
+var (
+	ErrOIDCExchangeFailed = errors.New("failed to exchange authorization code")
+	ErrOIDCUserInfoFailed = errors.New("failed to fetch userinfo")
+	ErrOIDCEmailMissing   = errors.New("identity provider did not return an email claim")
+)
+
+// OIDCProvider implements OAuthProvider for an OpenID Connect / OAuth2
+// identity provider (Google, Okta, Azure AD, ...) configured by an operator
+// via config.OIDCProviderConfig. It drives the authorization-code flow end
+// to end: building the redirect URL with state and a PKCE code_challenge,
+// exchanging the callback code for an access token, fetching the userinfo
+// endpoint, mapping claims to a role, and provisioning a driver record on
+// first login.
+type OIDCProvider struct {
+	name       string
+	cfg        config.OIDCProviderConfig
+	driverRepo *repository.DriverRepository
+	auth       *AuthService
+	httpClient *http.Client
+}
+
+// NewOIDCProvider creates an OIDCProvider for the given provider name and
+// config, e.g. NewOIDCProvider("google", cfg.OAuth.Providers["google"], ...).
+func NewOIDCProvider(name string, cfg config.OIDCProviderConfig, driverRepo *repository.DriverRepository, auth *AuthService) *OIDCProvider {
+	return &OIDCProvider{
+		name:       name,
+		cfg:        cfg,
+		driverRepo: driverRepo,
+		auth:       auth,
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+func (p *OIDCProvider) Name() string { return p.name }
+
+// AuthorizationURL builds the redirect URL for the authorization-code flow,
+// binding the given state and PKCE code_challenge (S256) to the request.
+func (p *OIDCProvider) AuthorizationURL(state, codeChallenge string) string {
+	q := url.Values{}
+	q.Set("client_id", p.cfg.ClientID)
+	q.Set("redirect_uri", p.cfg.RedirectURL)
+	q.Set("response_type", "code")
+	q.Set("scope", strings.Join(p.cfg.Scopes, " "))
+	q.Set("state", state)
+	q.Set("code_challenge", codeChallenge)
+	q.Set("code_challenge_method", "S256")
+	return p.cfg.AuthURL + "?" + q.Encode()
+}
+
+// ExchangeCode trades an authorization code for an access token at the
+// provider's token endpoint, authenticating the callback with the PKCE
+// code_verifier the caller generated alongside the original state.
+func (p *OIDCProvider) ExchangeCode(ctx context.Context, code, codeVerifier string) (string, error) {
+	form := url.Values{}
+	form.Set("grant_type", "authorization_code")
+	form.Set("code", code)
+	form.Set("redirect_uri", p.cfg.RedirectURL)
+	form.Set("client_id", p.cfg.ClientID)
+	form.Set("client_secret", p.cfg.ClientSecret)
+	form.Set("code_verifier", codeVerifier)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.cfg.TokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", ErrOIDCExchangeFailed, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("%w: status %d", ErrOIDCExchangeFailed, resp.StatusCode)
+	}
+
+	var body struct {
+		AccessToken string `json:"access_token"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return "", fmt.Errorf("%w: %v", ErrOIDCExchangeFailed, err)
+	}
+	if body.AccessToken == "" {
+		return "", ErrOIDCExchangeFailed
+	}
+
+	return body.AccessToken, nil
+}
+
+// FetchUserInfo calls the provider's userinfo endpoint with the given
+// access token and returns the claims as a generic map, the shape
+// OAuthProvider.AttemptLogin expects.
+func (p *OIDCProvider) FetchUserInfo(ctx context.Context, accessToken string) (map[string]any, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.cfg.UserInfoURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrOIDCUserInfoFailed, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%w: status %d", ErrOIDCUserInfoFailed, resp.StatusCode)
+	}
+
+	raw, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrOIDCUserInfoFailed, err)
+	}
+
+	var claims map[string]any
+	if err := json.Unmarshal(raw, &claims); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrOIDCUserInfoFailed, err)
+	}
+
+	return claims, nil
+}
+
+// Session roles an OIDC login can resolve to, matching the UserType strings
+// AuthService.CreateSession already uses for config-based admin and
+// bcrypt-based driver logins.
+const (
+	oidcRoleAdmin  = "admin"
+	oidcRoleDriver = "driver"
+)
+
+// AttemptLogin maps the identity provider's claims to a local driver
+// account, provisioning one on first login, and establishes a session for
+// it. subject is the provider's stable user identifier (the "sub" claim).
+func (p *OIDCProvider) AttemptLogin(subject string, claims map[string]any) (*SessionInfo, error) {
+	email, _ := claims["email"].(string)
+	if email == "" {
+		return nil, ErrOIDCEmailMissing
+	}
+
+	if p.mapRole(claims) == oidcRoleAdmin {
+		return p.auth.CreateSession(0, oidcRoleAdmin)
+	}
+
+	driver, err := p.driverRepo.GetByUsername(p.username(subject))
+	if err != nil {
+		driver, err = p.provisionDriver(subject, email, claims)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if !driver.IsActive {
+		return nil, ErrAccountDeactivated
+	}
+
+	return p.auth.CreateSession(int(driver.ID), oidcRoleDriver)
+}
+
+// mapRole resolves the identity provider's claims to a session role using
+// the provider's configured RoleClaim/RoleMapping (e.g. RoleClaim "hd" with
+// RoleMapping {"ourcompany.com": "admin"}), defaulting to "driver" when no
+// mapping applies.
+func (p *OIDCProvider) mapRole(claims map[string]any) string {
+	if p.cfg.RoleClaim == "" {
+		return oidcRoleDriver
+	}
+
+	value, _ := claims[p.cfg.RoleClaim].(string)
+	if mapped, ok := p.cfg.RoleMapping[value]; ok {
+		return mapped
+	}
+
+	return oidcRoleDriver
+}
+
+// username derives the local driver username for an OIDC subject, namespaced
+// by provider so the same subject from two providers can't collide.
+func (p *OIDCProvider) username(subject string) string {
+	return fmt.Sprintf("oidc:%s:%s", p.name, subject)
+}
+
+// provisionDriver creates a driver record for a first-time OIDC login. The
+// account has no usable local password: PasswordHash is set to the hash of
+// a random value so password-based login always fails for it.
+func (p *OIDCProvider) provisionDriver(subject, email string, claims map[string]any) (*models.Driver, error) {
+	unusablePassword, err := randomHex(32)
+	if err != nil {
+		return nil, err
+	}
+	passwordHash, err := HashPassword(unusablePassword)
+	if err != nil {
+		return nil, err
+	}
+
+	driver := &models.Driver{
+		Username:     p.username(subject),
+		PasswordHash: passwordHash,
+		IsActive:     true,
+	}
+	driver.Email.String = email
+	driver.Email.Valid = true
+
+	if name, _ := claims["name"].(string); name != "" {
+		driver.FirstName = name
+	}
+
+	if err := p.driverRepo.Create(driver); err != nil {
+		return nil, fmt.Errorf("failed to provision driver for %s login: %w", p.name, err)
+	}
+
+	return driver, nil
+}
+
+func randomHex(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
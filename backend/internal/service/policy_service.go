@@ -0,0 +1,41 @@
+package service
+
+import (
+	"github.com/securedlinq/backend/internal/models"
+	"github.com/securedlinq/backend/internal/repository"
+)
+
+// PolicyService backs middleware.RequirePermission: it answers whether a
+// role has been granted a given permission string, and lets admins edit
+// the grant table (PermissionHandler) instead of redeploying to change
+// who can do what. Like MeetingACLService, it checks the database on
+// every call rather than caching, since role_permissions changes are rare
+// and the lookup is a single indexed row scan.
+type PolicyService struct {
+	rolePermissionRepo *repository.RolePermissionRepository
+}
+
+// NewPolicyService creates a new policy service
+func NewPolicyService(rolePermissionRepo *repository.RolePermissionRepository) *PolicyService {
+	return &PolicyService{rolePermissionRepo: rolePermissionRepo}
+}
+
+// HasPermission reports whether role has been granted permission.
+func (s *PolicyService) HasPermission(role, permission string) (bool, error) {
+	return s.rolePermissionRepo.Exists(role, permission)
+}
+
+// GetRolePermissions lists every permission granted to role.
+func (s *PolicyService) GetRolePermissions(role string) ([]models.RolePermission, error) {
+	return s.rolePermissionRepo.GetByRole(role)
+}
+
+// GrantPermission adds permission to role.
+func (s *PolicyService) GrantPermission(role, permission string) error {
+	return s.rolePermissionRepo.Grant(role, permission)
+}
+
+// RevokePermission removes permission from role.
+func (s *PolicyService) RevokePermission(role, permission string) error {
+	return s.rolePermissionRepo.Revoke(role, permission)
+}
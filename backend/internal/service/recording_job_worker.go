@@ -0,0 +1,51 @@
+package service
+
+import (
+	"log"
+	"time"
+
+	"github.com/securedlinq/backend/internal/repository"
+)
+
+// RecordingJobWorker periodically scans for RecordingJobs stuck short of a
+// terminal state (e.g. the backend crashed mid-upload-verification) and
+// resumes them via RecordingService.ResumeRecordingJob.
+type RecordingJobWorker struct {
+	jobRepo   *repository.RecordingJobRepository
+	recording *RecordingService
+	interval  time.Duration
+}
+
+// NewRecordingJobWorker creates a new RecordingJobWorker.
+func NewRecordingJobWorker(jobRepo *repository.RecordingJobRepository, recording *RecordingService, interval time.Duration) *RecordingJobWorker {
+	return &RecordingJobWorker{jobRepo: jobRepo, recording: recording, interval: interval}
+}
+
+// Run blocks, polling for stuck jobs every interval until stop is closed.
+func (w *RecordingJobWorker) Run(stop <-chan struct{}) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			w.tick()
+		}
+	}
+}
+
+func (w *RecordingJobWorker) tick() {
+	jobs, err := w.jobRepo.GetStuck(20)
+	if err != nil {
+		log.Printf("recording: failed to load stuck jobs: %v", err)
+		return
+	}
+
+	for _, job := range jobs {
+		if _, err := w.recording.ResumeRecordingJob(job.ID); err != nil {
+			log.Printf("recording: failed to resume job %d: %v", job.ID, err)
+		}
+	}
+}
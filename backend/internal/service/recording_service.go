@@ -1,22 +1,52 @@
 package service
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
+	"strings"
 	"sync"
+	"time"
 
+	"github.com/securedlinq/backend/internal/events"
 	"github.com/securedlinq/backend/internal/models"
 	"github.com/securedlinq/backend/internal/repository"
 	"github.com/securedlinq/backend/pkg/agora"
+	"github.com/securedlinq/backend/pkg/s3"
 )
 
+// maxJobAttempts is how many times the stop->collect->verify pipeline
+// retries a RecordingJob (across worker ticks) before marking it Failed.
+const maxJobAttempts = 5
+
 // RecordingService handles recording business logic
 type RecordingService struct {
 	meetingRepo *repository.MeetingRepository
 	galleryRepo *repository.GalleryRepository
+	jobRepo     *repository.RecordingJobRepository
 	agoraClient *agora.Client
+	// s3Client, if set, is used to verify an upload's byte size via HEAD
+	// before a job is marked Complete. Nil disables verification (the job
+	// completes as soon as Agora reports the stop succeeded).
+	s3Client *s3.Client
+	// retentionPolicy is the lifecycle tag value applied to recordings via
+	// agoraClient.ApplyLifecycleTag; empty disables tagging.
+	retentionPolicy string
 	// In-memory storage for active recordings (resourceId -> recordingInfo)
 	activeRecordings map[string]*ActiveRecording
 	mu               sync.RWMutex
+	events           *events.Bus
+}
+
+// RecordingEventPayload is the events.Event payload published for
+// recording.started and recording.stopped.
+type RecordingEventPayload struct {
+	LoadID      uint   `json:"load_id,omitempty"`
+	LoadNumber  string `json:"load_number,omitempty"`
+	ChannelName string `json:"channel_name"`
+	ResourceID  string `json:"resource_id"`
+	SID         string `json:"sid"`
+	Status      string `json:"status,omitempty"`
 }
 
 // ActiveRecording stores info about an active recording session
@@ -33,13 +63,21 @@ type ActiveRecording struct {
 func NewRecordingService(
 	meetingRepo *repository.MeetingRepository,
 	galleryRepo *repository.GalleryRepository,
+	jobRepo *repository.RecordingJobRepository,
 	agoraClient *agora.Client,
+	s3Client *s3.Client,
+	retentionPolicy string,
+	eventsBus *events.Bus,
 ) *RecordingService {
 	return &RecordingService{
 		meetingRepo:      meetingRepo,
 		galleryRepo:      galleryRepo,
+		jobRepo:          jobRepo,
 		agoraClient:      agoraClient,
+		s3Client:         s3Client,
+		retentionPolicy:  retentionPolicy,
 		activeRecordings: make(map[string]*ActiveRecording),
+		events:           eventsBus,
 	}
 }
 
@@ -72,6 +110,7 @@ type StopRecordingRequest struct {
 // StopRecordingResponse represents a response from stopping recording
 type StopRecordingResponse struct {
 	Success  bool     `json:"success"`
+	JobID    uint     `json:"jobId,omitempty"`
 	FileName string   `json:"fileName,omitempty"`
 	S3Key    string   `json:"s3Key,omitempty"`
 	S3URL    string   `json:"s3Url,omitempty"`
@@ -114,6 +153,14 @@ func (s *RecordingService) StartRecording(req *StartRecordingRequest) (*StartRec
 	}
 	s.mu.Unlock()
 
+	s.events.Publish(events.RecordingStarted, RecordingEventPayload{
+		LoadID:      meeting.LoadID,
+		LoadNumber:  loadNumber,
+		ChannelName: req.ChannelName,
+		ResourceID:  result.ResourceID,
+		SID:         result.SID,
+	})
+
 	return &StartRecordingResponse{
 		Success:     true,
 		ResourceID:  result.ResourceID,
@@ -124,7 +171,9 @@ func (s *RecordingService) StartRecording(req *StartRecordingRequest) (*StartRec
 	}, nil
 }
 
-// StopRecording stops an active recording
+// StopRecording stops an active recording. The stop->collect->verify
+// pipeline is tracked as a RecordingJob row so a crashed backend can resume
+// it with ResumeRecordingJob instead of losing the recording.
 func (s *RecordingService) StopRecording(req *StopRecordingRequest) (*StopRecordingResponse, error) {
 	// Get recording info from memory using SID as key
 	s.mu.RLock()
@@ -151,12 +200,103 @@ func (s *RecordingService) StopRecording(req *StopRecordingRequest) (*StopRecord
 		return nil, fmt.Errorf("UID mismatch. The UID used to stop recording (%s) must match the UID used to start recording (%s)", req.UID, recording.UID)
 	}
 
-	// Stop recording via Agora using the provided UID
-	result, err := s.agoraClient.StopRecording(req.ResourceID, req.SID, req.UID, req.ChannelName)
+	job := &models.RecordingJob{
+		ResourceID:  req.ResourceID,
+		SID:         req.SID,
+		ChannelName: req.ChannelName,
+		UID:         req.UID,
+		Status:      models.RecordingJobStopping,
+	}
+	if recording.LoadID > 0 {
+		job.LoadID.Int64, job.LoadID.Valid = int64(recording.LoadID), true
+	}
+	if err := s.jobRepo.Create(job); err != nil {
+		return nil, fmt.Errorf("failed to create recording job: %w", err)
+	}
+
+	resp, err := s.runStopPipeline(job, recording)
 	if err != nil {
+		return nil, err
+	}
+
+	// Remove from active recordings once the job has reached a terminal state
+	s.mu.Lock()
+	delete(s.activeRecordings, req.SID)
+	s.mu.Unlock()
+
+	return resp, nil
+}
+
+// StopAllActive force-stops every recording still tracked in
+// activeRecordings, for use during graceful shutdown so an in-flight Agora
+// cloud recording doesn't keep burning credit after the backend exits. Each
+// stop goes through the normal StopRecording pipeline, so it's persisted as
+// a RecordingJob and can be resumed by RecordingJobWorker on the next boot
+// if it doesn't finish before the process is killed. Errors are collected
+// rather than aborting the sweep, so one stuck recording doesn't block the
+// rest from being stopped.
+func (s *RecordingService) StopAllActive() []error {
+	s.mu.RLock()
+	snapshot := make([]*ActiveRecording, 0, len(s.activeRecordings))
+	for _, recording := range s.activeRecordings {
+		snapshot = append(snapshot, recording)
+	}
+	s.mu.RUnlock()
+
+	var errs []error
+	for _, recording := range snapshot {
+		_, err := s.StopRecording(&StopRecordingRequest{
+			ResourceID:  recording.ResourceID,
+			SID:         recording.SID,
+			ChannelName: recording.ChannelName,
+			UID:         recording.UID,
+		})
+		if err != nil {
+			errs = append(errs, fmt.Errorf("sid %s: %w", recording.SID, err))
+		}
+	}
+	return errs
+}
+
+// ResumeRecordingJob re-drives a job that didn't reach a terminal state,
+// e.g. after a crashed backend restarts. It re-queries Agora and re-reads
+// S3 rather than assuming anything from the previous attempt succeeded.
+func (s *RecordingService) ResumeRecordingJob(jobID uint) (*StopRecordingResponse, error) {
+	job, err := s.jobRepo.GetByID(jobID)
+	if err != nil {
+		return nil, fmt.Errorf("recording job %d not found: %w", jobID, err)
+	}
+	if job.Status == models.RecordingJobComplete || job.Status == models.RecordingJobFailed {
+		return nil, fmt.Errorf("recording job %d is already %s", jobID, job.Status)
+	}
+
+	recording := &ActiveRecording{
+		ResourceID:  job.ResourceID,
+		SID:         job.SID,
+		ChannelName: job.ChannelName,
+		UID:         job.UID,
+	}
+	if job.LoadID.Valid {
+		recording.LoadID = uint(job.LoadID.Int64)
+	}
+
+	return s.runStopPipeline(job, recording)
+}
+
+// runStopPipeline drives job through Stopping -> Collecting -> Verifying ->
+// Complete, persisting progress at each step so ResumeRecordingJob can pick
+// up wherever a prior attempt left off.
+func (s *RecordingService) runStopPipeline(job *models.RecordingJob, recording *ActiveRecording) (*StopRecordingResponse, error) {
+	result, err := s.agoraClient.StopRecording(job.ResourceID, job.SID, job.UID, job.ChannelName)
+	if err != nil {
+		s.recordJobAttemptError(job, err)
 		return nil, fmt.Errorf("failed to stop Agora recording: %w", err)
 	}
 
+	if err := s.jobRepo.UpdateProgress(job.ID, models.RecordingJobCollecting, 0, result.FileSize); err != nil {
+		fmt.Printf("Warning: Failed to update recording job %d progress: %v\n", job.ID, err)
+	}
+
 	// Save video recording to gallery if S3 key is available
 	if result.S3Key != "" && recording.LoadID > 0 {
 		gallery := &models.Gallery{
@@ -165,29 +305,159 @@ func (s *RecordingService) StopRecording(req *StopRecordingRequest) (*StopRecord
 			S3Key:             "", // Empty for video recordings (screenshots use this)
 			VideoRecordingKey: result.S3Key,
 		}
+
+		if s.s3Client != nil && len(result.FileList) > 0 {
+			s.attachManifestAndDedupe(job, gallery, result, recording.LoadNumber)
+		}
+
 		if err := s.galleryRepo.Create(gallery); err != nil {
 			// Log error but don't fail the request - recording is already stopped
 			fmt.Printf("Warning: Failed to save video recording to gallery: %v\n", err)
 		}
+
+		if s.retentionPolicy != "" && gallery.VideoRecordingKey == result.S3Key {
+			if err := s.agoraClient.ApplyLifecycleTag(result.S3Key, s.retentionPolicy); err != nil {
+				// Log error but don't fail the request - recording and gallery save already succeeded
+				fmt.Printf("Warning: Failed to apply lifecycle tag to recording: %v\n", err)
+			}
+		}
 	}
 
-	// Remove from active recordings
-	s.mu.Lock()
-	delete(s.activeRecordings, req.SID)
-	s.mu.Unlock()
+	status := "completed"
+	warning := ""
+	if result.S3Key != "" && s.s3Client != nil {
+		if err := s.jobRepo.UpdateProgress(job.ID, models.RecordingJobVerifying, 0, result.FileSize); err != nil {
+			fmt.Printf("Warning: Failed to update recording job %d progress: %v\n", job.ID, err)
+		}
+
+		size, err := s.s3Client.HeadObjectSize(result.S3Key)
+		if err != nil {
+			// The recording and gallery entry already succeeded; leave the
+			// job in Verifying so the background worker retries the HEAD
+			// check rather than losing the recording over a transient S3 error.
+			s.recordJobAttemptError(job, err)
+			warning = fmt.Sprintf("recording stopped but upload could not be verified yet: %v", err)
+			status = "verifying"
+		} else if result.FileSize > 0 && size < result.FileSize {
+			s.recordJobAttemptError(job, fmt.Errorf("uploaded %d of %d bytes", size, result.FileSize))
+			warning = fmt.Sprintf("upload incomplete: %d of %d bytes", size, result.FileSize)
+			status = "verifying"
+		} else if err := s.jobRepo.UpdateProgress(job.ID, models.RecordingJobVerifying, size, result.FileSize); err != nil {
+			fmt.Printf("Warning: Failed to update recording job %d progress: %v\n", job.ID, err)
+		}
+	}
+
+	if status == "completed" {
+		if err := s.jobRepo.MarkComplete(job.ID, result.S3Key); err != nil {
+			fmt.Printf("Warning: Failed to mark recording job %d complete: %v\n", job.ID, err)
+		}
+
+		payload := RecordingEventPayload{
+			ChannelName: job.ChannelName,
+			ResourceID:  job.ResourceID,
+			SID:         job.SID,
+			Status:      status,
+		}
+		if job.LoadID.Valid {
+			payload.LoadID = uint(job.LoadID.Int64)
+		}
+		payload.LoadNumber = recording.LoadNumber
+		s.events.Publish(events.RecordingStopped, payload)
+	}
 
 	return &StopRecordingResponse{
 		Success:  true,
+		JobID:    job.ID,
 		FileName: result.FileName,
 		S3Key:    result.S3Key,
 		S3URL:    result.S3URL,
 		FileList: result.FileList,
 		FileSize: result.FileSize,
 		Duration: result.Duration,
-		Status:   "completed",
+		Status:   status,
+		Warning:  warning,
 	}, nil
 }
 
+// attachManifestAndDedupe hashes every file Agora wrote for job's recording,
+// uploads a RecordingManifest sidecar describing them, and checks whether an
+// identical recording was already saved (Agora re-uploads the same content
+// on a retried stop, or a session gets stopped twice). If a match is found,
+// gallery is pointed at the existing row's keys instead of the newly
+// uploaded duplicate, and the duplicate object is deleted; otherwise
+// gallery.ManifestS3Key/ContentDigest are populated for the new upload.
+func (s *RecordingService) attachManifestAndDedupe(job *models.RecordingJob, gallery *models.Gallery, result *agora.RecordingResult, loadNumber string) {
+	segments := make([]agora.ManifestSegment, 0, len(result.FileList))
+	combined := sha256.New()
+	for _, fileName := range result.FileList {
+		digest, size, err := s.s3Client.HashObjectSHA256(fileName)
+		if err != nil {
+			// Leave the recording alone - a failed hash shouldn't block saving
+			// the gallery entry, it just means this stop won't be dedupe-able.
+			fmt.Printf("Warning: Failed to hash recording segment %s: %v\n", fileName, err)
+			return
+		}
+		duration := 0
+		if fileName == result.FileName {
+			duration = result.Duration
+		}
+		segments = append(segments, agora.ManifestSegment{Digest: digest, Size: size, Duration: duration})
+		combined.Write([]byte(digest))
+	}
+	contentDigest := hex.EncodeToString(combined.Sum(nil))
+
+	mediaType := "video/mp4"
+	if strings.HasSuffix(result.FileName, ".m3u8") {
+		mediaType = "application/vnd.apple.mpegurl"
+	}
+	manifest := &agora.RecordingManifest{
+		SchemaVersion: 1,
+		MediaType:     mediaType,
+		Segments:      segments,
+	}
+
+	if existing, err := s.galleryRepo.GetByContentDigest(contentDigest); err == nil {
+		// Same content already saved under a prior gallery row - point at it
+		// instead of keeping the duplicate bytes this stop just uploaded.
+		gallery.VideoRecordingKey = existing.VideoRecordingKey
+		gallery.ManifestS3Key = existing.ManifestS3Key
+		gallery.ContentDigest = existing.ContentDigest
+		if err := s.s3Client.DeleteObject(result.S3Key); err != nil {
+			fmt.Printf("Warning: Failed to delete duplicate recording %s: %v\n", result.S3Key, err)
+		}
+		return
+	}
+
+	if err := s.agoraClient.PutRecordingManifest(job.SID, loadNumber, manifest); err != nil {
+		// The recording itself is already saved; a missing manifest just
+		// means this recording can't be deduped against later.
+		fmt.Printf("Warning: Failed to upload recording manifest for %s: %v\n", job.SID, err)
+		return
+	}
+	gallery.ManifestS3Key = agora.ManifestKey(job.SID)
+	gallery.ContentDigest = contentDigest
+}
+
+// recordJobAttemptError persists a failed pipeline attempt and schedules
+// the next retry, or marks the job Failed once maxJobAttempts is hit.
+func (s *RecordingService) recordJobAttemptError(job *models.RecordingJob, attemptErr error) {
+	attempts := job.Attempts + 1
+	failed := attempts >= maxJobAttempts
+	next := time.Now().Add(jobBackoffFor(attempts))
+
+	if err := s.jobRepo.RecordAttemptError(job.ID, attempts, next, attemptErr.Error(), failed); err != nil {
+		fmt.Printf("Warning: Failed to record recording job %d attempt error: %v\n", job.ID, err)
+	}
+	job.Attempts = attempts
+}
+
+// jobBackoffFor returns the retry delay for a job's Nth failed attempt,
+// mirroring the jitter-based backoff agora.Client uses for Agora's error
+// code 65 so a flapping pipeline doesn't hammer Agora or S3.
+func jobBackoffFor(attempts int) time.Duration {
+	return agora.NewDefaultRetryer(maxJobAttempts).RetryDelay(attempts - 1)
+}
+
 // QueryRecording queries the status of a recording
 func (s *RecordingService) QueryRecording(resourceID, sid string) (map[string]interface{}, error) {
 	return s.agoraClient.QueryRecording(resourceID, sid)
@@ -0,0 +1,131 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/securedlinq/backend/internal/config"
+	"github.com/securedlinq/backend/internal/models"
+)
+
+// RedisSessionStore is a SessionStore backed by Redis, so sessions survive
+// backend restarts and are shared across replicas behind a load balancer.
+// Keys are stored as "{KeyPrefix}{sessionID}" with a native TTL, mirroring
+// the gin-contrib/sessions Redis store pattern.
+type RedisSessionStore struct {
+	client    *redis.Client
+	keyPrefix string
+}
+
+// NewRedisSessionStore creates a new Redis-backed session store.
+func NewRedisSessionStore(cfg *config.RedisConfig) *RedisSessionStore {
+	client := redis.NewClient(&redis.Options{
+		Addr:     cfg.Addr,
+		Password: cfg.Password,
+		DB:       cfg.DB,
+		PoolSize: cfg.PoolSize,
+	})
+
+	return &RedisSessionStore{
+		client:    client,
+		keyPrefix: cfg.KeyPrefix,
+	}
+}
+
+func (s *RedisSessionStore) key(sessionID string) string {
+	return s.keyPrefix + sessionID
+}
+
+func (s *RedisSessionStore) Create(session *models.Session) error {
+	ctx := context.Background()
+	session.CreatedAt = time.Now()
+	session.UpdatedAt = time.Now()
+
+	ttl := time.Until(session.ExpiresAt)
+	if ttl <= 0 {
+		return fmt.Errorf("session expiry %s is not in the future", session.ExpiresAt)
+	}
+
+	data, err := json.Marshal(session)
+	if err != nil {
+		return fmt.Errorf("failed to marshal session: %w", err)
+	}
+
+	return s.client.Set(ctx, s.key(session.SessionID), data, ttl).Err()
+}
+
+func (s *RedisSessionStore) Get(sessionID string) (*models.Session, error) {
+	ctx := context.Background()
+
+	data, err := s.client.Get(ctx, s.key(sessionID)).Bytes()
+	if err == redis.Nil {
+		return nil, ErrSessionNotFound
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to read session from redis: %w", err)
+	}
+
+	var session models.Session
+	if err := json.Unmarshal(data, &session); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal session: %w", err)
+	}
+
+	return &session, nil
+}
+
+func (s *RedisSessionStore) Refresh(sessionID string, maxAge time.Duration) error {
+	session, err := s.Get(sessionID)
+	if err != nil {
+		return err
+	}
+
+	session.ExpiresAt = time.Now().Add(maxAge)
+	session.UpdatedAt = time.Now()
+
+	return s.Create(session)
+}
+
+func (s *RedisSessionStore) Delete(sessionID string) error {
+	ctx := context.Background()
+	return s.client.Del(ctx, s.key(sessionID)).Err()
+}
+
+func (s *RedisSessionStore) DeleteByUserID(userID int, userType string) error {
+	ctx := context.Background()
+
+	var cursor uint64
+	for {
+		keys, next, err := s.client.Scan(ctx, cursor, s.keyPrefix+"*", 100).Result()
+		if err != nil {
+			return fmt.Errorf("failed to scan sessions: %w", err)
+		}
+
+		for _, k := range keys {
+			data, err := s.client.Get(ctx, k).Bytes()
+			if err != nil {
+				continue
+			}
+			var session models.Session
+			if err := json.Unmarshal(data, &session); err != nil {
+				continue
+			}
+			if session.UserID == userID && session.UserType == userType {
+				s.client.Del(ctx, k)
+			}
+		}
+
+		cursor = next
+		if cursor == 0 {
+			break
+		}
+	}
+
+	return nil
+}
+
+// DeleteExpired is a no-op: Redis evicts expired keys natively via TTL.
+func (s *RedisSessionStore) DeleteExpired() error {
+	return nil
+}
@@ -0,0 +1,56 @@
+package service
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/securedlinq/backend/internal/config"
+	"github.com/securedlinq/backend/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestRedisSessionStoreSurvivesRestart verifies that a session written by one
+// RedisSessionStore instance (simulating the backend before a restart) is
+// still readable from a brand new instance pointed at the same Redis server
+// (simulating the backend after a restart) - the property that motivates
+// moving sessions out of process memory in the first place.
+//
+// Requires a reachable Redis instance; set REDIS_TEST_ADDR to run it.
+func TestRedisSessionStoreSurvivesRestart(t *testing.T) {
+	addr := os.Getenv("REDIS_TEST_ADDR")
+	if addr == "" {
+		t.Skip("REDIS_TEST_ADDR not set, skipping Redis integration test")
+	}
+
+	cfg := &config.RedisConfig{
+		Addr:      addr,
+		DB:        0,
+		PoolSize:  5,
+		KeyPrefix: "test:sess:",
+	}
+
+	// Simulate the backend instance before restart.
+	before := NewRedisSessionStore(cfg)
+
+	session := &models.Session{
+		SessionID: "restart-test-session",
+		UserID:    42,
+		UserType:  "driver",
+		ExpiresAt: time.Now().Add(time.Minute),
+	}
+	require.NoError(t, before.Create(session))
+
+	// Simulate the backend restarting by constructing a fresh store.
+	after := NewRedisSessionStore(cfg)
+
+	got, err := after.Get(session.SessionID)
+	require.NoError(t, err)
+	assert.Equal(t, session.UserID, got.UserID)
+	assert.Equal(t, session.UserType, got.UserType)
+
+	require.NoError(t, after.Delete(session.SessionID))
+	_, err = after.Get(session.SessionID)
+	assert.Equal(t, ErrSessionNotFound, err)
+}
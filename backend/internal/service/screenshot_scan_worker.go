@@ -0,0 +1,130 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"time"
+
+	"github.com/securedlinq/backend/internal/models"
+	"github.com/securedlinq/backend/internal/repository"
+	"github.com/securedlinq/backend/pkg/filestore"
+	"github.com/securedlinq/backend/pkg/scanner"
+)
+
+// quarantinePrefix is prepended to an infected object's key when it's moved
+// out of normal reach, mirroring the load-number prefix MediaHandler already
+// uses for screenshot keys.
+const quarantinePrefix = "quarantine/"
+
+// ScreenshotScanWorker periodically scans Gallery rows uploaded under
+// ScannerConfig.Mode == "async" (so ScanStatus is still GalleryScanSkipped),
+// moving infected media to quarantine and recording a SecurityEvent either
+// way.
+type ScreenshotScanWorker struct {
+	galleryRepo       *repository.GalleryRepository
+	securityEventRepo *repository.SecurityEventRepository
+	fileStore         filestore.FileStore
+	scanner           scanner.Scanner
+	interval          time.Duration
+}
+
+// NewScreenshotScanWorker creates a new ScreenshotScanWorker.
+func NewScreenshotScanWorker(galleryRepo *repository.GalleryRepository, securityEventRepo *repository.SecurityEventRepository, fileStore filestore.FileStore, mediaScanner scanner.Scanner, interval time.Duration) *ScreenshotScanWorker {
+	return &ScreenshotScanWorker{
+		galleryRepo:       galleryRepo,
+		securityEventRepo: securityEventRepo,
+		fileStore:         fileStore,
+		scanner:           mediaScanner,
+		interval:          interval,
+	}
+}
+
+// Run blocks, polling for pending scans every interval until stop is closed.
+func (w *ScreenshotScanWorker) Run(stop <-chan struct{}) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			w.tick()
+		}
+	}
+}
+
+func (w *ScreenshotScanWorker) tick() {
+	galleries, err := w.galleryRepo.GetPendingScans(20)
+	if err != nil {
+		log.Printf("screenshot scan: failed to load pending scans: %v", err)
+		return
+	}
+
+	for _, gallery := range galleries {
+		if err := w.scanOne(gallery); err != nil {
+			log.Printf("screenshot scan: failed to scan gallery %d: %v", gallery.ID, err)
+		}
+	}
+}
+
+func (w *ScreenshotScanWorker) scanOne(gallery models.Gallery) error {
+	ctx := context.Background()
+
+	reader, err := w.fileStore.Get(ctx, gallery.S3Key)
+	if err != nil {
+		return fmt.Errorf("fetch object: %w", err)
+	}
+	data, err := io.ReadAll(reader)
+	reader.Close()
+	if err != nil {
+		return fmt.Errorf("read object: %w", err)
+	}
+
+	result, err := w.scanner.Scan(ctx, bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("scan object: %w", err)
+	}
+
+	if result.Verdict == scanner.VerdictClean {
+		return w.galleryRepo.UpdateScanStatus(gallery.ID, models.GalleryScanClean)
+	}
+
+	if result.Verdict == scanner.VerdictError {
+		// A scan that errored isn't known-clean and isn't known-infected -
+		// leave ScanStatus as GalleryScanSkipped so the next tick retries it
+		// instead of either serving it as verified-clean or quarantining a
+		// file that was never actually confirmed infected.
+		log.Printf("screenshot scan: gallery %d scan errored, will retry: %s", gallery.ID, result.Signature)
+		return nil
+	}
+
+	quarantineKey := quarantinePrefix + gallery.S3Key
+	if _, err := w.fileStore.Upload(ctx, quarantineKey, bytes.NewReader(data), "application/octet-stream"); err != nil {
+		return fmt.Errorf("quarantine upload: %w", err)
+	}
+	if err := w.fileStore.Delete(ctx, gallery.S3Key); err != nil {
+		log.Printf("screenshot scan: failed to delete infected object %s after quarantine: %v", gallery.S3Key, err)
+	}
+
+	if err := w.galleryRepo.Quarantine(gallery.ID, quarantineKey); err != nil {
+		return fmt.Errorf("mark quarantined: %w", err)
+	}
+
+	galleryID := gallery.ID
+	event := &models.SecurityEvent{
+		EventType: models.SecurityEventQuarantined,
+		S3Key:     quarantineKey,
+		Detail:    result.Signature,
+	}
+	event.GalleryID.Int64 = int64(galleryID)
+	event.GalleryID.Valid = true
+	if err := w.securityEventRepo.Create(event); err != nil {
+		log.Printf("screenshot scan: failed to record security event for gallery %d: %v", gallery.ID, err)
+	}
+
+	return nil
+}
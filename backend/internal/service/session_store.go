@@ -0,0 +1,31 @@
+package service
+
+import (
+	"errors"
+	"time"
+
+	"github.com/securedlinq/backend/internal/models"
+)
+
+// ErrSessionNotFound is returned by a SessionStore when no session exists for the given ID.
+var ErrSessionNotFound = errors.New("session not found")
+
+// SessionStore abstracts session persistence so AuthService can run against
+// either the database (default, single-process friendly) or a shared cache
+// like Redis so sessions survive process restarts and are visible across
+// replicas behind a load balancer.
+type SessionStore interface {
+	// Create persists a new session.
+	Create(session *models.Session) error
+	// Get retrieves a non-expired session by its session ID.
+	Get(sessionID string) (*models.Session, error)
+	// Refresh extends a session's expiration by maxAge from now.
+	Refresh(sessionID string, maxAge time.Duration) error
+	// Delete removes a session.
+	Delete(sessionID string) error
+	// DeleteByUserID removes all sessions belonging to a user.
+	DeleteByUserID(userID int, userType string) error
+	// DeleteExpired removes expired sessions. Stores with native TTL support
+	// (e.g. Redis) may implement this as a no-op.
+	DeleteExpired() error
+}
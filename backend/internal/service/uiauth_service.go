@@ -0,0 +1,257 @@
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/pquerna/otp"
+	"github.com/pquerna/otp/totp"
+	"github.com/securedlinq/backend/internal/config"
+	"github.com/securedlinq/backend/internal/models"
+)
+
+// Matrix-style user-interactive auth stage identifiers.
+const (
+	StagePassword  = "m.login.password"
+	StageRecaptcha = "m.login.recaptcha"
+	StageTOTP      = "x.login.totp"
+)
+
+// uiAuthUserType tags session-store rows holding UI-auth progress so they
+// can't be picked up by AuthService's session lookups or DeleteByUserID
+// sweeps, which key on "admin"/"driver".
+const uiAuthUserType = "uiauth"
+
+// uiAuthSessionTTL bounds how long an in-progress auth flow stays valid.
+const uiAuthSessionTTL = 10 * time.Minute
+
+var (
+	ErrUIAuthStageFailed  = errors.New("user-interactive auth stage failed")
+	ErrUIAuthUnknownStage = errors.New("unknown user-interactive auth stage")
+)
+
+// UIAuthFlow lists one acceptable sequence of stages.
+type UIAuthFlow struct {
+	Stages []string `json:"stages"`
+}
+
+// UIAuthChallenge is the body returned to the client when a protected action
+// requires (further) user-interactive authentication, modeled on Matrix's
+// /auth flow negotiation: the caller resubmits the same request with an
+// `auth` block until a full flow's stages are all completed.
+type UIAuthChallenge struct {
+	Session   string         `json:"session"`
+	Flows     []UIAuthFlow   `json:"flows"`
+	Params    map[string]any `json:"params"`
+	Completed []string       `json:"completed"`
+}
+
+// UIAuthSubmission is the `auth` block a client resubmits a protected
+// request with to complete one stage of a flow.
+type UIAuthSubmission struct {
+	Session  string `json:"session" binding:"required"`
+	Type     string `json:"type" binding:"required"`
+	Password string `json:"password,omitempty"`
+	Response string `json:"response,omitempty"`
+	Code     string `json:"code,omitempty"`
+}
+
+// uiAuthState is the per-session progress persisted as JSON in
+// models.Session.Data.
+type uiAuthState struct {
+	Completed []string `json:"completed"`
+}
+
+// UIAuthService gates sensitive actions (driver deletion, password resets)
+// behind a Matrix-style user-interactive auth negotiation: the first call
+// is challenged with a session ID and the configured flows, and the caller
+// resubmits one stage at a time until a full flow is satisfied. Per-session
+// progress lives in the same pluggable SessionStore as login sessions, so it
+// survives behind a load balancer the same way.
+type UIAuthService struct {
+	sessionStore SessionStore
+	config       *config.Config
+}
+
+// NewUIAuthService creates a new UI-auth service.
+func NewUIAuthService(sessionStore SessionStore, cfg *config.Config) *UIAuthService {
+	return &UIAuthService{sessionStore: sessionStore, config: cfg}
+}
+
+// Authenticate checks whether actorID has satisfied one full auth flow for
+// the action being guarded. If submission is nil (the caller's first
+// attempt) or names an expired/unknown session, it starts a fresh flow and
+// returns ok=false with the challenge to present. Once every stage of some
+// configured flow has been completed it returns ok=true and challenge=nil.
+func (s *UIAuthService) Authenticate(actorID int, submission *UIAuthSubmission) (ok bool, challenge *UIAuthChallenge, err error) {
+	flows := s.flows()
+
+	if submission == nil || submission.Session == "" {
+		ok, challenge, err := s.startSession(actorID, flows)
+		return ok, challenge, err
+	}
+
+	state, err := s.loadState(submission.Session, actorID)
+	if err != nil {
+		ok, challenge, err := s.startSession(actorID, flows)
+		return ok, challenge, err
+	}
+
+	if err := s.verifyStage(submission); err != nil {
+		return false, s.challenge(submission.Session, flows, state.Completed), nil
+	}
+
+	if !contains(state.Completed, submission.Type) {
+		state.Completed = append(state.Completed, submission.Type)
+	}
+
+	if flowSatisfied(flows, state.Completed) {
+		_ = s.sessionStore.Delete(submission.Session)
+		return true, nil, nil
+	}
+
+	if err := s.saveState(submission.Session, actorID, state); err != nil {
+		return false, nil, err
+	}
+
+	return false, s.challenge(submission.Session, flows, state.Completed), nil
+}
+
+// flows returns the configured UI-auth flows, falling back to a single
+// password stage if none are configured.
+func (s *UIAuthService) flows() [][]string {
+	if len(s.config.UIAuth.Flows) == 0 {
+		return [][]string{{StagePassword}}
+	}
+	return s.config.UIAuth.Flows
+}
+
+// startSession begins a new flow with no stages completed.
+func (s *UIAuthService) startSession(actorID int, flows [][]string) (bool, *UIAuthChallenge, error) {
+	sessionID, err := generateSessionID()
+	if err != nil {
+		return false, nil, err
+	}
+	if err := s.saveState(sessionID, actorID, &uiAuthState{}); err != nil {
+		return false, nil, err
+	}
+	return false, s.challenge(sessionID, flows, nil), nil
+}
+
+// verifyStage validates a submission against the stage it claims to complete.
+func (s *UIAuthService) verifyStage(sub *UIAuthSubmission) error {
+	switch sub.Type {
+	case StagePassword:
+		if sub.Password == "" || sub.Password != s.config.Admin.Password {
+			return ErrUIAuthStageFailed
+		}
+		return nil
+	case StageRecaptcha:
+		// No recaptcha provider is wired up yet; require a non-empty
+		// response token so the stage can't be satisfied by an empty block.
+		if sub.Response == "" {
+			return ErrUIAuthStageFailed
+		}
+		return nil
+	case StageTOTP:
+		if s.config.Admin.TOTPSecret == "" {
+			return ErrUIAuthUnknownStage
+		}
+		valid, err := totp.ValidateCustom(sub.Code, s.config.Admin.TOTPSecret, time.Now(), totp.ValidateOpts{
+			Period:    30,
+			Skew:      1,
+			Digits:    otp.DigitsSix,
+			Algorithm: otp.AlgorithmSHA1,
+		})
+		if err != nil || !valid {
+			return ErrUIAuthStageFailed
+		}
+		return nil
+	default:
+		return ErrUIAuthUnknownStage
+	}
+}
+
+// loadState retrieves in-progress state for sessionID, rejecting sessions
+// that don't belong to actorID or weren't created by this service.
+func (s *UIAuthService) loadState(sessionID string, actorID int) (*uiAuthState, error) {
+	session, err := s.sessionStore.Get(sessionID)
+	if err != nil {
+		return nil, err
+	}
+	if session.UserType != uiAuthUserType || session.UserID != actorID {
+		return nil, ErrInvalidSession
+	}
+
+	var state uiAuthState
+	if session.Data != "" {
+		if err := json.Unmarshal([]byte(session.Data), &state); err != nil {
+			return nil, err
+		}
+	}
+	return &state, nil
+}
+
+// saveState persists progress for sessionID, replacing any prior row since
+// SessionStore has no in-place update.
+func (s *UIAuthService) saveState(sessionID string, actorID int, state *uiAuthState) error {
+	data, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+
+	_ = s.sessionStore.Delete(sessionID)
+	return s.sessionStore.Create(&models.Session{
+		SessionID: sessionID,
+		UserID:    actorID,
+		UserType:  uiAuthUserType,
+		Data:      string(data),
+		ExpiresAt: time.Now().Add(uiAuthSessionTTL),
+	})
+}
+
+// challenge builds the JSON body returned to the client for an incomplete flow.
+func (s *UIAuthService) challenge(sessionID string, flows [][]string, completed []string) *UIAuthChallenge {
+	uiFlows := make([]UIAuthFlow, 0, len(flows))
+	for _, stages := range flows {
+		uiFlows = append(uiFlows, UIAuthFlow{Stages: stages})
+	}
+	if completed == nil {
+		completed = []string{}
+	}
+
+	return &UIAuthChallenge{
+		Session:   sessionID,
+		Flows:     uiFlows,
+		Params:    map[string]any{},
+		Completed: completed,
+	}
+}
+
+// flowSatisfied reports whether completed covers every stage of at least
+// one of flows.
+func flowSatisfied(flows [][]string, completed []string) bool {
+	for _, stages := range flows {
+		satisfied := true
+		for _, stage := range stages {
+			if !contains(completed, stage) {
+				satisfied = false
+				break
+			}
+		}
+		if satisfied {
+			return true
+		}
+	}
+	return false
+}
+
+func contains(list []string, item string) bool {
+	for _, v := range list {
+		if v == item {
+			return true
+		}
+	}
+	return false
+}
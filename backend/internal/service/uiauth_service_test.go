@@ -0,0 +1,145 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/securedlinq/backend/internal/config"
+	"github.com/securedlinq/backend/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeSessionStore is an in-memory SessionStore double, standing in for the
+// database/Redis-backed implementations so UIAuthService can be unit tested
+// without a live backend.
+type fakeSessionStore struct {
+	sessions map[string]*models.Session
+}
+
+func newFakeSessionStore() *fakeSessionStore {
+	return &fakeSessionStore{sessions: make(map[string]*models.Session)}
+}
+
+func (f *fakeSessionStore) Create(session *models.Session) error {
+	f.sessions[session.SessionID] = session
+	return nil
+}
+
+func (f *fakeSessionStore) Get(sessionID string) (*models.Session, error) {
+	session, ok := f.sessions[sessionID]
+	if !ok || session.ExpiresAt.Before(time.Now()) {
+		return nil, ErrSessionNotFound
+	}
+	return session, nil
+}
+
+func (f *fakeSessionStore) Refresh(sessionID string, maxAge time.Duration) error {
+	session, ok := f.sessions[sessionID]
+	if !ok {
+		return ErrSessionNotFound
+	}
+	session.ExpiresAt = time.Now().Add(maxAge)
+	return nil
+}
+
+func (f *fakeSessionStore) Delete(sessionID string) error {
+	delete(f.sessions, sessionID)
+	return nil
+}
+
+func (f *fakeSessionStore) DeleteByUserID(userID int, userType string) error {
+	for id, session := range f.sessions {
+		if session.UserID == userID && session.UserType == userType {
+			delete(f.sessions, id)
+		}
+	}
+	return nil
+}
+
+func (f *fakeSessionStore) DeleteExpired() error {
+	for id, session := range f.sessions {
+		if session.ExpiresAt.Before(time.Now()) {
+			delete(f.sessions, id)
+		}
+	}
+	return nil
+}
+
+func TestUIAuthServiceSingleStageFlow(t *testing.T) {
+	cfg := &config.Config{Admin: config.AdminConfig{Password: "hunter2"}}
+	svc := NewUIAuthService(newFakeSessionStore(), cfg)
+
+	ok, challenge, err := svc.Authenticate(1, nil)
+	require.NoError(t, err)
+	assert.False(t, ok)
+	require.NotNil(t, challenge)
+	assert.Equal(t, []UIAuthFlow{{Stages: []string{StagePassword}}}, challenge.Flows)
+	assert.Empty(t, challenge.Completed)
+
+	ok, challenge, err = svc.Authenticate(1, &UIAuthSubmission{
+		Session:  challenge.Session,
+		Type:     StagePassword,
+		Password: "wrong",
+	})
+	require.NoError(t, err)
+	assert.False(t, ok, "wrong password should not satisfy the stage")
+	assert.Empty(t, challenge.Completed)
+
+	ok, challenge, err = svc.Authenticate(1, &UIAuthSubmission{
+		Session:  challenge.Session,
+		Type:     StagePassword,
+		Password: "hunter2",
+	})
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Nil(t, challenge)
+}
+
+func TestUIAuthServiceMultiStageFlowRequiresAllStages(t *testing.T) {
+	cfg := &config.Config{
+		Admin:  config.AdminConfig{Password: "hunter2"},
+		UIAuth: config.UIAuthConfig{Flows: [][]string{{StagePassword, StageRecaptcha}}},
+	}
+	svc := NewUIAuthService(newFakeSessionStore(), cfg)
+
+	_, challenge, err := svc.Authenticate(1, nil)
+	require.NoError(t, err)
+
+	ok, challenge, err := svc.Authenticate(1, &UIAuthSubmission{
+		Session:  challenge.Session,
+		Type:     StagePassword,
+		Password: "hunter2",
+	})
+	require.NoError(t, err)
+	assert.False(t, ok, "completing one of two stages should not finish the flow")
+	assert.Equal(t, []string{StagePassword}, challenge.Completed)
+
+	ok, challenge, err = svc.Authenticate(1, &UIAuthSubmission{
+		Session:  challenge.Session,
+		Type:     StageRecaptcha,
+		Response: "captcha-token",
+	})
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Nil(t, challenge)
+}
+
+func TestUIAuthServiceRejectsSessionFromAnotherActor(t *testing.T) {
+	cfg := &config.Config{Admin: config.AdminConfig{Password: "hunter2"}}
+	svc := NewUIAuthService(newFakeSessionStore(), cfg)
+
+	_, challenge, err := svc.Authenticate(1, nil)
+	require.NoError(t, err)
+
+	// A different actor reusing actor 1's session ID should get a fresh
+	// challenge rather than being able to ride their progress.
+	ok, otherChallenge, err := svc.Authenticate(2, &UIAuthSubmission{
+		Session:  challenge.Session,
+		Type:     StagePassword,
+		Password: "hunter2",
+	})
+	require.NoError(t, err)
+	assert.False(t, ok)
+	assert.NotEqual(t, challenge.Session, otherChallenge.Session)
+}
@@ -0,0 +1,119 @@
+package service
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/securedlinq/backend/internal/config"
+	"github.com/securedlinq/backend/internal/repository"
+)
+
+// verificationTokenTTL bounds how long a driver has to click the
+// verification link before they need a new one issued.
+const verificationTokenTTL = 24 * time.Hour
+
+var (
+	ErrVerificationTokenInvalid = errors.New("invalid verification token")
+	ErrVerificationTokenExpired = errors.New("verification token expired")
+	ErrEmailAlreadyVerified     = errors.New("email already verified")
+)
+
+// VerificationService issues and redeems HMAC-signed driver email
+// verification tokens. The token itself is never persisted - it signs
+// driverID|email|exp with EmailConfig.VerificationSecret (the same
+// HMAC-SHA256 construction as agora.hmacSign) so redemption only needs the
+// secret, not a database lookup, to check authenticity.
+type VerificationService struct {
+	driverRepo *repository.DriverRepository
+	secret     string
+}
+
+// NewVerificationService creates a new verification service.
+func NewVerificationService(driverRepo *repository.DriverRepository, cfg *config.EmailConfig) *VerificationService {
+	return &VerificationService{driverRepo: driverRepo, secret: cfg.VerificationSecret}
+}
+
+// IssueToken returns a base64url-encoded, HMAC-signed token binding
+// driverID to email, valid for verificationTokenTTL.
+func (s *VerificationService) IssueToken(driverID uint, email string) string {
+	exp := time.Now().Add(verificationTokenTTL).Unix()
+	payload := fmt.Sprintf("%d|%s|%d", driverID, email, exp)
+	sig := s.sign(payload)
+	return base64.RawURLEncoding.EncodeToString([]byte(payload)) + "." + base64.RawURLEncoding.EncodeToString(sig)
+}
+
+// VerifyToken validates token's signature and expiry, then marks the
+// referenced driver's email as verified. It returns ErrEmailAlreadyVerified
+// if the driver had already been verified (a valid, non-replay outcome the
+// caller should treat as idempotent success rather than a hard failure).
+func (s *VerificationService) VerifyToken(token string) (uint, error) {
+	payload, sig, ok := strings.Cut(token, ".")
+	if !ok {
+		return 0, ErrVerificationTokenInvalid
+	}
+
+	payloadBytes, err := base64.RawURLEncoding.DecodeString(payload)
+	if err != nil {
+		return 0, ErrVerificationTokenInvalid
+	}
+	sigBytes, err := base64.RawURLEncoding.DecodeString(sig)
+	if err != nil {
+		return 0, ErrVerificationTokenInvalid
+	}
+
+	if !hmac.Equal(sigBytes, s.sign(string(payloadBytes))) {
+		return 0, ErrVerificationTokenInvalid
+	}
+
+	fields := strings.SplitN(string(payloadBytes), "|", 3)
+	if len(fields) != 3 {
+		return 0, ErrVerificationTokenInvalid
+	}
+
+	driverID, err := strconv.ParseUint(fields[0], 10, 64)
+	if err != nil {
+		return 0, ErrVerificationTokenInvalid
+	}
+
+	exp, err := strconv.ParseInt(fields[2], 10, 64)
+	if err != nil {
+		return 0, ErrVerificationTokenInvalid
+	}
+	if time.Now().Unix() > exp {
+		return 0, ErrVerificationTokenExpired
+	}
+
+	driver, err := s.driverRepo.GetByID(uint(driverID))
+	if err != nil {
+		return 0, ErrVerificationTokenInvalid
+	}
+	if !driver.Email.Valid || driver.Email.String != fields[1] {
+		return 0, ErrVerificationTokenInvalid
+	}
+
+	if driver.EmailVerifiedAt.Valid {
+		return driver.ID, ErrEmailAlreadyVerified
+	}
+
+	driver.EmailVerifiedAt = sql.NullTime{Time: time.Now(), Valid: true}
+	if err := s.driverRepo.Update(driver); err != nil {
+		return 0, err
+	}
+
+	return driver.ID, nil
+}
+
+// sign computes the HMAC-SHA256 of payload keyed by the verification
+// secret, mirroring agora.hmacSign's construction.
+func (s *VerificationService) sign(payload string) []byte {
+	h := hmac.New(sha256.New, []byte(s.secret))
+	h.Write([]byte(payload))
+	return h.Sum(nil)
+}
@@ -0,0 +1,32 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVerificationServiceSignIsDeterministicAndKeyed(t *testing.T) {
+	s1 := &VerificationService{secret: "secret-a"}
+	s2 := &VerificationService{secret: "secret-b"}
+
+	sig1 := s1.sign("1|driver@example.com|9999999999")
+	sig1Again := s1.sign("1|driver@example.com|9999999999")
+	sig2 := s2.sign("1|driver@example.com|9999999999")
+
+	assert.Equal(t, sig1, sig1Again)
+	assert.NotEqual(t, sig1, sig2)
+}
+
+func TestVerifyTokenRejectsMalformedTokens(t *testing.T) {
+	s := &VerificationService{secret: "secret"}
+
+	_, err := s.VerifyToken("not-a-valid-token")
+	assert.ErrorIs(t, err, ErrVerificationTokenInvalid)
+
+	_, err = s.VerifyToken("")
+	assert.ErrorIs(t, err, ErrVerificationTokenInvalid)
+}
+
+// Note: Tests for VerifyToken's success/expiry/already-verified paths
+// require database integration and are not included in this package.
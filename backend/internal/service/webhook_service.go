@@ -0,0 +1,104 @@
+package service
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/securedlinq/backend/internal/models"
+	"github.com/securedlinq/backend/internal/repository"
+)
+
+// WebhookService manages admin-registered webhook subscriptions and
+// exposes their delivery history to handlers.
+type WebhookService struct {
+	webhookRepo  *repository.WebhookRepository
+	deliveryRepo *repository.WebhookDeliveryRepository
+}
+
+// NewWebhookService creates a new webhook service
+func NewWebhookService(webhookRepo *repository.WebhookRepository, deliveryRepo *repository.WebhookDeliveryRepository) *WebhookService {
+	return &WebhookService{webhookRepo: webhookRepo, deliveryRepo: deliveryRepo}
+}
+
+// RegisterWebhookRequest is the admin-supplied shape for creating a
+// webhook subscription. EventFilter is a comma-separated list of
+// events.Type values ("meeting.created,load.completed"); empty
+// subscribes to every event.
+type RegisterWebhookRequest struct {
+	URL         string
+	EventFilter string
+}
+
+// RegisterWebhook creates a new webhook subscription, generating a random
+// signing secret that is returned once and never shown again (the same
+// one-time-reveal convention as accesskey.Service.Issue).
+func (s *WebhookService) RegisterWebhook(req RegisterWebhookRequest) (secret string, webhook *models.Webhook, err error) {
+	secret, err = generateWebhookSecret()
+	if err != nil {
+		return "", nil, fmt.Errorf("generate webhook secret: %w", err)
+	}
+
+	webhook = &models.Webhook{
+		URL:         req.URL,
+		Secret:      secret,
+		EventFilter: req.EventFilter,
+		Active:      true,
+	}
+	if err := s.webhookRepo.Create(webhook); err != nil {
+		return "", nil, err
+	}
+
+	return secret, webhook, nil
+}
+
+// UpdateWebhookRequest is the admin-supplied shape for updating a webhook
+// subscription's URL, event filter, and active state. The signing secret
+// cannot be changed in place - delete and re-register to rotate it.
+type UpdateWebhookRequest struct {
+	URL         string
+	EventFilter string
+	Active      bool
+}
+
+// UpdateWebhook updates an existing webhook subscription's configuration.
+func (s *WebhookService) UpdateWebhook(id uint, req UpdateWebhookRequest) (*models.Webhook, error) {
+	webhook, err := s.webhookRepo.GetByID(id)
+	if err != nil {
+		return nil, fmt.Errorf("webhook not found: %w", err)
+	}
+
+	webhook.URL = req.URL
+	webhook.EventFilter = req.EventFilter
+	webhook.Active = req.Active
+
+	if err := s.webhookRepo.Update(webhook); err != nil {
+		return nil, err
+	}
+	return webhook, nil
+}
+
+// GetAllWebhooks lists webhook subscriptions with pagination, newest first
+func (s *WebhookService) GetAllWebhooks(page, pageSize int) ([]models.Webhook, int64, error) {
+	return s.webhookRepo.GetAll(page, pageSize)
+}
+
+// DeleteWebhook removes a webhook subscription
+func (s *WebhookService) DeleteWebhook(id uint) error {
+	return s.webhookRepo.Delete(id)
+}
+
+// GetDeliveries lists delivery attempts for a webhook with pagination,
+// newest first, so operators can inspect failures.
+func (s *WebhookService) GetDeliveries(webhookID uint, page, pageSize int) ([]models.WebhookDelivery, int64, error) {
+	return s.deliveryRepo.GetByWebhookID(webhookID, page, pageSize)
+}
+
+// generateWebhookSecret returns a random 32-byte signing secret, hex-encoded.
+func generateWebhookSecret() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
@@ -0,0 +1,76 @@
+package webhook
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/securedlinq/backend/internal/events"
+	"github.com/securedlinq/backend/internal/models"
+	"github.com/securedlinq/backend/internal/repository"
+)
+
+// maxAttempts is how many delivery attempts a webhook_deliveries row gets
+// before the worker marks it abandoned (its dead-letter state).
+const maxAttempts = 8
+
+// Dispatcher is an events.Sink that queues a webhook_deliveries row for
+// every active Webhook subscribed to an event's type. It only persists the
+// row - the Worker delivers it asynchronously, so a slow or unreachable
+// receiver can't stall the publisher.
+type Dispatcher struct {
+	webhookRepo  *repository.WebhookRepository
+	deliveryRepo *repository.WebhookDeliveryRepository
+}
+
+// NewDispatcher creates a new Dispatcher.
+func NewDispatcher(webhookRepo *repository.WebhookRepository, deliveryRepo *repository.WebhookDeliveryRepository) *Dispatcher {
+	return &Dispatcher{webhookRepo: webhookRepo, deliveryRepo: deliveryRepo}
+}
+
+// Publish implements events.Sink.
+func (d *Dispatcher) Publish(event events.Event) {
+	webhooks, err := d.webhookRepo.GetAllActive()
+	if err != nil {
+		fmt.Printf("Warning: failed to load active webhooks for %s event: %v\n", event.Type, err)
+		return
+	}
+
+	payload, err := json.Marshal(event.Payload)
+	if err != nil {
+		fmt.Printf("Warning: failed to marshal %s event payload: %v\n", event.Type, err)
+		return
+	}
+
+	for _, hook := range webhooks {
+		if !subscribed(hook, event.Type) {
+			continue
+		}
+
+		delivery := &models.WebhookDelivery{
+			WebhookID:     hook.ID,
+			EventType:     string(event.Type),
+			Payload:       string(payload),
+			Status:        "queued",
+			NextAttemptAt: time.Now(),
+		}
+		if err := d.deliveryRepo.Create(delivery); err != nil {
+			fmt.Printf("Warning: failed to queue webhook delivery for webhook %d: %v\n", hook.ID, err)
+		}
+	}
+}
+
+// subscribed reports whether hook's comma-separated EventFilter includes
+// eventType. An empty filter subscribes to every event.
+func subscribed(hook models.Webhook, eventType events.Type) bool {
+	if strings.TrimSpace(hook.EventFilter) == "" {
+		return true
+	}
+	for _, filtered := range strings.Split(hook.EventFilter, ",") {
+		if strings.TrimSpace(filtered) == string(eventType) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,19 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+// SignatureHeader is the header a delivery's HMAC-SHA256 signature is sent
+// in, so a receiver can verify the payload came from this server and
+// wasn't tampered with in transit.
+const SignatureHeader = "X-SecuredLinQ-Signature"
+
+// sign returns the hex-encoded HMAC-SHA256 of payload keyed by secret.
+func sign(secret string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,134 @@
+package webhook
+
+import (
+	"bytes"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	"github.com/securedlinq/backend/internal/models"
+	"github.com/securedlinq/backend/internal/repository"
+)
+
+// Worker periodically pulls due webhook_deliveries rows and POSTs each to
+// its Webhook's URL, signing the body with that webhook's secret and
+// retrying failures with exponential backoff until maxAttempts is reached.
+type Worker struct {
+	webhookRepo  *repository.WebhookRepository
+	deliveryRepo *repository.WebhookDeliveryRepository
+	httpClient   *http.Client
+	interval     time.Duration
+}
+
+// NewWorker creates a new Worker.
+func NewWorker(webhookRepo *repository.WebhookRepository, deliveryRepo *repository.WebhookDeliveryRepository, interval time.Duration) *Worker {
+	return &Worker{
+		webhookRepo:  webhookRepo,
+		deliveryRepo: deliveryRepo,
+		httpClient:   &http.Client{Timeout: 10 * time.Second},
+		interval:     interval,
+	}
+}
+
+// Run blocks, polling for due deliveries every interval until stop is closed.
+func (w *Worker) Run(stop <-chan struct{}) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			w.tick()
+		}
+	}
+}
+
+func (w *Worker) tick() {
+	deliveries, err := w.deliveryRepo.GetDue(20)
+	if err != nil {
+		log.Printf("webhook: failed to load due deliveries: %v", err)
+		return
+	}
+
+	for _, delivery := range deliveries {
+		w.deliver(delivery)
+	}
+}
+
+func (w *Worker) deliver(delivery models.WebhookDelivery) {
+	hook, err := w.webhookRepo.GetByID(delivery.WebhookID)
+	if err != nil {
+		w.reschedule(delivery, 0, fmt.Errorf("webhook %d not found: %w", delivery.WebhookID, err))
+		return
+	}
+
+	if err := w.deliveryRepo.MarkSending(delivery.ID); err != nil {
+		log.Printf("webhook: failed to mark delivery %d sending: %v", delivery.ID, err)
+		return
+	}
+
+	body := []byte(delivery.Payload)
+	req, err := http.NewRequest(http.MethodPost, hook.URL, bytes.NewReader(body))
+	if err != nil {
+		w.reschedule(delivery, 0, err)
+		return
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(SignatureHeader, sign(hook.Secret, body))
+
+	resp, err := w.httpClient.Do(req)
+	if err != nil {
+		w.reschedule(delivery, 0, err)
+		return
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		w.reschedule(delivery, resp.StatusCode, fmt.Errorf("webhook endpoint returned status %d", resp.StatusCode))
+		return
+	}
+
+	if err := w.deliveryRepo.MarkDelivered(delivery.ID, resp.StatusCode); err != nil {
+		log.Printf("webhook: failed to mark delivery %d delivered: %v", delivery.ID, err)
+	}
+}
+
+func (w *Worker) reschedule(delivery models.WebhookDelivery, statusCode int, deliverErr error) {
+	attempts := delivery.Attempts + 1
+	abandoned := attempts >= maxAttempts
+	next := time.Now().Add(backoffFor(attempts))
+
+	if err := w.deliveryRepo.Reschedule(delivery.ID, attempts, next, deliverErr.Error(), statusCode, abandoned); err != nil {
+		log.Printf("webhook: failed to reschedule delivery %d: %v", delivery.ID, err)
+	}
+}
+
+// newBackoff returns the exponential backoff schedule used between
+// retries, capped at 12h so an unreachable receiver doesn't get hammered
+// forever; maxAttempts (not MaxElapsedTime) is what bounds total retries.
+func newBackoff() *backoff.ExponentialBackOff {
+	b := backoff.NewExponentialBackOff()
+	b.InitialInterval = 30 * time.Second
+	b.MaxInterval = 12 * time.Hour
+	b.MaxElapsedTime = 0
+	// No jitter: it is applied after the MaxInterval cap and would let a
+	// delay exceed 12h.
+	b.RandomizationFactor = 0
+	return b
+}
+
+// backoffFor returns the retry delay for the given attempt count. Since no
+// per-delivery backoff state is kept between worker ticks, it replays a
+// fresh schedule attempts times to land on the right interval.
+func backoffFor(attempts int) time.Duration {
+	b := newBackoff()
+	var d time.Duration
+	for i := 0; i < attempts; i++ {
+		d = b.NextBackOff()
+	}
+	return d
+}
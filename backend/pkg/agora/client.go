@@ -2,6 +2,7 @@ package agora
 
 import (
 	"bytes"
+	"context"
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
@@ -19,17 +20,15 @@ type Client struct {
 	appCertificate string
 	encodedKey     string
 	httpClient     *http.Client
-	s3Config       S3Config
-}
-
-// S3Config holds AWS S3 configuration for Agora recording storage
-type S3Config struct {
-	Vendor         int      `json:"vendor"`
-	Region         int      `json:"region"`
-	Bucket         string   `json:"bucket"`
-	AccessKey      string   `json:"accessKey"`
-	SecretKey      string   `json:"secretKey"`
-	FileNamePrefix []string `json:"fileNamePrefix"`
+	// backends holds the configured recording storage backends, primary
+	// first followed by any failover backends.
+	backends []StorageBackend
+	// preferred is an explicit backend name set via SetStorageBackend,
+	// overriding backends[0] as StartRecording's default choice.
+	preferred string
+	// retryer decides whether/how long to back off between retries of a
+	// failed REST call in makeRequestCtx.
+	retryer Retryer
 }
 
 // RecordingResult represents the result of a recording operation
@@ -45,8 +44,12 @@ type RecordingResult struct {
 	Duration   int
 }
 
-// NewClient creates a new Agora client
-func NewClient(appID, appCertificate, encodedKey string) *Client {
+// NewClient creates a new Agora client. backends configures where
+// cloud-recording output is written: the first entry is the primary
+// backend, and further entries (e.g. a failover) are used only once chosen
+// with SetStorageBackend. StartRecording does not switch to another backend
+// when acquiring or starting on the selected one fails.
+func NewClient(appID, appCertificate, encodedKey string, backends []StorageBackend) *Client {
 	// If encodedKey is not provided, try to generate from customer credentials
 	if encodedKey == "" {
 		customerID := os.Getenv("AGORA_CUSTOMER_ID")
@@ -61,9 +64,6 @@ func NewClient(appID, appCertificate, encodedKey string) *Client {
 		}
 	}
 
-	// Get S3 region code for Agora
-	s3Region := getAgoraS3Region(os.Getenv("AWS_REGION"))
-
 	return &Client{
 		appID:          appID,
 		appCertificate: appCertificate,
@@ -71,15 +71,72 @@ func NewClient(appID, appCertificate, encodedKey string) *Client {
 		httpClient: &http.Client{
 			Timeout: 30 * time.Second,
 		},
-		s3Config: S3Config{
-			Vendor:         1, // AWS S3
-			Region:         s3Region,
-			Bucket:         os.Getenv("AWS_S3_BUCKET_NAME"),
-			AccessKey:      os.Getenv("AWS_ACCESS_KEY_ID"),
-			SecretKey:      os.Getenv("AWS_SECRET_ACCESS_KEY"),
-			FileNamePrefix: []string{"recordings"},
-		},
+		backends: backends,
+		retryer:  NewDefaultRetryer(3),
+	}
+}
+
+// SetRetryer overrides the retry policy makeRequestCtx uses for every REST
+// call. Mainly useful for tests that want a faster or deterministic
+// schedule than NewClient's default.
+func (c *Client) SetRetryer(retryer Retryer) {
+	c.retryer = retryer
+}
+
+// ListBackends returns the names of every configured storage backend,
+// primary first.
+func (c *Client) ListBackends() []string {
+	names := make([]string, 0, len(c.backends))
+	for _, b := range c.backends {
+		names = append(names, b.Name())
+	}
+	return names
+}
+
+// SetStorageBackend selects which configured backend StartRecording uses by
+// default, overriding the primary (first) backend. Returns an error if name
+// does not match any configured backend.
+func (c *Client) SetStorageBackend(name string) error {
+	for _, b := range c.backends {
+		if b.Name() == name {
+			c.preferred = name
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown storage backend %q", name)
+}
+
+// selectBackend picks the storage backend StartRecording should use. It
+// honors an explicit SetStorageBackend choice, otherwise defaults to the
+// primary (first configured) backend. loadNumber is accepted so deployments
+// can later extend this with per-load routing (e.g. sensitive loads to an
+// encrypted or region-restricted bucket) without changing callers.
+func (c *Client) selectBackend(loadNumber string) (StorageBackend, error) {
+	if len(c.backends) == 0 {
+		return nil, fmt.Errorf("no recording storage backend configured")
+	}
+
+	if c.preferred != "" {
+		for _, b := range c.backends {
+			if b.Name() == c.preferred {
+				return b, nil
+			}
+		}
 	}
+
+	return c.backends[0], nil
+}
+
+// ApplyLifecycleTag tags the object at key in the primary backend (or, if
+// SetStorageBackend was called, that backend) with a
+// retention-policy tag so a bucket lifecycle rule can expire it. Only the
+// AWS S3 vendor is supported; other vendors return an error.
+func (c *Client) ApplyLifecycleTag(key, retentionPolicy string) error {
+	storageBackend, err := c.selectBackend("")
+	if err != nil {
+		return err
+	}
+	return ApplyLifecycleTag(storageBackend, key, retentionPolicy)
 }
 
 // GetAppID returns the Agora app ID
@@ -96,27 +153,76 @@ func (c *Client) getBaseURL() string {
 	return fmt.Sprintf("https://api.agora.io/v1/apps/%s", c.appID)
 }
 
+// makeRequest is the non-context variant of makeRequestCtx, for callers
+// that don't have a caller-supplied deadline to propagate.
 func (c *Client) makeRequest(endpoint, method string, body interface{}) (map[string]interface{}, error) {
+	return c.makeRequestCtx(context.Background(), endpoint, method, body)
+}
+
+// makeRequestCtx issues a single Agora REST call, retrying per c.retryer
+// (5xx, connection resets, and Agora's own transient error codes) with
+// full-jitter exponential backoff until MaxRetries is exhausted or ctx is
+// done, whichever comes first.
+func (c *Client) makeRequestCtx(ctx context.Context, endpoint, method string, body interface{}) (map[string]interface{}, error) {
 	// Check if we have credentials
 	if c.encodedKey == "" {
 		return nil, fmt.Errorf("Agora REST API credentials not configured. Set AGORA_ENCODED_KEY or both AGORA_CUSTOMER_ID and AGORA_CUSTOMER_SECRET environment variables")
 	}
 
-	url := c.getBaseURL() + endpoint
-
-	var reqBody io.Reader
+	var jsonBody []byte
 	if body != nil {
-		jsonBody, err := json.Marshal(body)
+		var err error
+		jsonBody, err = json.Marshal(body)
 		if err != nil {
 			return nil, fmt.Errorf("failed to marshal request body: %w", err)
 		}
+	}
+
+	maxRetries := 0
+	if c.retryer != nil {
+		maxRetries = c.retryer.MaxRetries()
+	}
+
+	var lastErr error
+	for attempt := 0; attempt <= maxRetries; attempt++ {
+		result, status, respBody, err := c.doRequest(ctx, endpoint, method, jsonBody)
+		if err == nil {
+			return result, nil
+		}
+		lastErr = err
+
+		retry := c.retryer != nil && c.retryer.ShouldRetry(status, respBody, err)
+		if !retry || attempt == maxRetries {
+			return nil, err
+		}
+
+		delay := c.retryer.RetryDelay(attempt)
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(delay):
+		}
+	}
+
+	return nil, lastErr
+}
+
+// doRequest performs a single HTTP round trip and returns the parsed body
+// (if the response status was below 400), the HTTP status code (0 if the
+// request never completed), the raw response body for ShouldRetry to
+// inspect, and an error describing any failure.
+func (c *Client) doRequest(ctx context.Context, endpoint, method string, jsonBody []byte) (result map[string]interface{}, status int, respBody []byte, err error) {
+	url := c.getBaseURL() + endpoint
+
+	var reqBody io.Reader
+	if jsonBody != nil {
 		reqBody = bytes.NewBuffer(jsonBody)
 		log.Printf("Agora API Request to %s: %s", endpoint, string(jsonBody))
 	}
 
-	req, err := http.NewRequest(method, url, reqBody)
+	req, err := http.NewRequestWithContext(ctx, method, url, reqBody)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+		return nil, 0, nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
 	req.Header.Set("Authorization", "Basic "+c.encodedKey)
@@ -124,24 +230,23 @@ func (c *Client) makeRequest(endpoint, method string, body interface{}) (map[str
 
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("request failed: %w", err)
+		return nil, 0, nil, fmt.Errorf("request failed: %w", err)
 	}
 	defer resp.Body.Close()
 
-	respBody, err := io.ReadAll(resp.Body)
+	respBody, err = io.ReadAll(resp.Body)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read response: %w", err)
+		return nil, resp.StatusCode, nil, fmt.Errorf("failed to read response: %w", err)
 	}
 
 	log.Printf("Agora API Response (status %d): %s", resp.StatusCode, string(respBody))
 
-	var result map[string]interface{}
-	if err := json.Unmarshal(respBody, &result); err != nil {
+	if jsonErr := json.Unmarshal(respBody, &result); jsonErr != nil {
 		// If response is not JSON, return error with status code
 		if resp.StatusCode >= 400 {
-			return nil, fmt.Errorf("API error (status %d): %s", resp.StatusCode, string(respBody))
+			return nil, resp.StatusCode, respBody, fmt.Errorf("API error (status %d): %s", resp.StatusCode, string(respBody))
 		}
-		return nil, fmt.Errorf("failed to parse response: %w", err)
+		return nil, resp.StatusCode, respBody, fmt.Errorf("failed to parse response: %w", jsonErr)
 	}
 
 	if resp.StatusCode >= 400 {
@@ -156,38 +261,51 @@ func (c *Client) makeRequest(endpoint, method string, body interface{}) (map[str
 
 		// Provide more helpful error messages
 		if resp.StatusCode == 401 {
-			return nil, fmt.Errorf("Invalid authentication credentials. Please verify your AGORA_CUSTOMER_ID and AGORA_CUSTOMER_SECRET (or AGORA_ENCODED_KEY). Get these from Agora Console > RESTful API")
+			return nil, resp.StatusCode, respBody, fmt.Errorf("Invalid authentication credentials. Please verify your AGORA_CUSTOMER_ID and AGORA_CUSTOMER_SECRET (or AGORA_ENCODED_KEY). Get these from Agora Console > RESTful API")
 		}
 
-		return nil, fmt.Errorf("API error (status %d): %s", resp.StatusCode, errMsg)
+		return nil, resp.StatusCode, respBody, fmt.Errorf("API error (status %d): %s", resp.StatusCode, errMsg)
 	}
 
-	return result, nil
+	return result, resp.StatusCode, respBody, nil
 }
 
 // StartRecording starts cloud recording for a channel
 func (c *Client) StartRecording(channelName, uid, token, loadNumber string) (*RecordingResult, error) {
+	return c.StartRecordingCtx(context.Background(), channelName, uid, token, loadNumber)
+}
+
+// StartRecordingCtx is StartRecording with a caller-supplied context,
+// propagated to every Agora REST call (including retries) so a canceled
+// or timed-out caller stops the pipeline promptly.
+func (c *Client) StartRecordingCtx(ctx context.Context, channelName, uid, token, loadNumber string) (*RecordingResult, error) {
 	// Validate configuration
 	if c.appID == "" {
 		return nil, fmt.Errorf("AGORA_APP_ID is not configured")
 	}
-	if c.s3Config.Bucket == "" {
-		return nil, fmt.Errorf("AWS_S3_BUCKET_NAME is not configured for recording storage")
+
+	storageBackend, err := c.selectBackend(loadNumber)
+	if err != nil {
+		return nil, err
 	}
-	if c.s3Config.AccessKey == "" || c.s3Config.SecretKey == "" {
-		return nil, fmt.Errorf("AWS credentials (AWS_ACCESS_KEY_ID, AWS_SECRET_ACCESS_KEY) are not configured for recording storage")
+	if storageBackend.Bucket() == "" {
+		return nil, fmt.Errorf("recording storage backend %q has no bucket configured", storageBackend.Name())
+	}
+	accessKey, secretKey := storageBackend.Credentials()
+	if accessKey == "" || secretKey == "" {
+		return nil, fmt.Errorf("recording storage backend %q has no credentials configured", storageBackend.Name())
 	}
 
 	// Build file prefix - Agora expects an array of strings (folder levels)
 	// Each string must be alphanumeric only (no special characters)
 	// Format: ["recordings"] or ["recordings", "load123"]
-	fileNamePrefix := []string{"recordings"}
+	fileNamePrefix := storageBackend.FileNamePrefix()
 	if loadNumber != "" {
 		// Clean load number: remove all non-alphanumeric characters
 		// Agora requires alphanumeric folder names only
 		cleanLoadNumber := cleanAlphanumeric(loadNumber)
 		if cleanLoadNumber != "" {
-			fileNamePrefix = []string{"recordings", cleanLoadNumber}
+			fileNamePrefix = append(append([]string{}, storageBackend.FileNamePrefix()...), cleanLoadNumber)
 		}
 	}
 
@@ -201,7 +319,7 @@ func (c *Client) StartRecording(channelName, uid, token, loadNumber string) (*Re
 		},
 	}
 
-	acquireResp, err := c.makeRequest("/cloud_recording/acquire", "POST", acquireBody)
+	acquireResp, err := c.makeRequestCtx(ctx, "/cloud_recording/acquire", "POST", acquireBody)
 	if err != nil {
 		return nil, fmt.Errorf("acquire failed: %w", err)
 	}
@@ -213,13 +331,23 @@ func (c *Client) StartRecording(channelName, uid, token, loadNumber string) (*Re
 
 	// Step 2: Start recording
 	storageConfig := map[string]interface{}{
-		"vendor":         c.s3Config.Vendor,
-		"region":         c.s3Config.Region,
-		"bucket":         c.s3Config.Bucket,
-		"accessKey":      c.s3Config.AccessKey,
-		"secretKey":      c.s3Config.SecretKey,
+		"vendor":         storageBackend.VendorCode(),
+		"region":         storageBackend.RegionCode(),
+		"bucket":         storageBackend.Bucket(),
+		"accessKey":      accessKey,
+		"secretKey":      secretKey,
 		"fileNamePrefix": fileNamePrefix,
 	}
+	if sse := storageBackend.SSE(); sse.Mode != "" && sse.Mode != SSENone {
+		extensionParams := map[string]interface{}{"sse": string(sse.Mode)}
+		if sse.KMSKeyARN != "" {
+			extensionParams["kmsKeyArn"] = sse.KMSKeyARN
+		}
+		if sse.CustomerKey != "" {
+			extensionParams["customerKey"] = sse.CustomerKey
+		}
+		storageConfig["extensionParams"] = extensionParams
+	}
 
 	startBody := map[string]interface{}{
 		"cname": channelName,
@@ -249,7 +377,7 @@ func (c *Client) StartRecording(channelName, uid, token, loadNumber string) (*Re
 	}
 
 	startEndpoint := fmt.Sprintf("/cloud_recording/resourceid/%s/mode/mix/start", resourceID)
-	startResp, err := c.makeRequest(startEndpoint, "POST", startBody)
+	startResp, err := c.makeRequestCtx(ctx, startEndpoint, "POST", startBody)
 	if err != nil {
 		return nil, fmt.Errorf("start recording failed: %w", err)
 	}
@@ -268,10 +396,13 @@ func (c *Client) StartRecording(channelName, uid, token, loadNumber string) (*Re
 
 // StopRecording stops cloud recording
 func (c *Client) StopRecording(resourceID, sid, uid, channelName string) (*RecordingResult, error) {
-	return c.stopRecordingWithRetry(resourceID, sid, uid, channelName, 0)
+	return c.StopRecordingCtx(context.Background(), resourceID, sid, uid, channelName)
 }
 
-func (c *Client) stopRecordingWithRetry(resourceID, sid, uid, channelName string, retryCount int) (*RecordingResult, error) {
+// StopRecordingCtx is StopRecording with a caller-supplied context. Retries
+// on Agora's transient codes (e.g. 65 network jitter) are handled by
+// makeRequestCtx's Retryer, not by this function.
+func (c *Client) StopRecordingCtx(ctx context.Context, resourceID, sid, uid, channelName string) (*RecordingResult, error) {
 	stopBody := map[string]interface{}{
 		"cname":         channelName,
 		"uid":           uid,
@@ -279,16 +410,8 @@ func (c *Client) stopRecordingWithRetry(resourceID, sid, uid, channelName string
 	}
 
 	stopEndpoint := fmt.Sprintf("/cloud_recording/resourceid/%s/sid/%s/mode/mix/stop", resourceID, sid)
-	stopResp, err := c.makeRequest(stopEndpoint, "POST", stopBody)
+	stopResp, err := c.makeRequestCtx(ctx, stopEndpoint, "POST", stopBody)
 	if err != nil {
-		// Check for error code 65 (network jitter)
-		if strings.Contains(err.Error(), "65") || strings.Contains(err.Error(), "request not completed") {
-			if retryCount < 2 {
-				delay := time.Duration((retryCount+1)*3) * time.Second
-				time.Sleep(delay)
-				return c.stopRecordingWithRetry(resourceID, sid, uid, channelName, retryCount+1)
-			}
-		}
 		return nil, fmt.Errorf("stop recording failed: %w", err)
 	}
 
@@ -331,10 +454,12 @@ func (c *Client) stopRecordingWithRetry(resourceID, sid, uid, channelName string
 			if fileName, ok := selectedFile["fileName"].(string); ok {
 				result.FileName = fileName
 				result.S3Key = fileName
-				result.S3URL = fmt.Sprintf("https://%s.s3.%s.amazonaws.com/%s",
-					c.s3Config.Bucket,
-					os.Getenv("AWS_REGION"),
-					fileName)
+				if storageBackend, err := c.selectBackend(""); err == nil {
+					result.S3URL = fmt.Sprintf("https://%s.s3.%s.amazonaws.com/%s",
+						storageBackend.Bucket(),
+						os.Getenv("AWS_REGION"),
+						fileName)
+				}
 			}
 			if fileSize, ok := selectedFile["fileSize"].(float64); ok {
 				result.FileSize = int64(fileSize)
@@ -350,12 +475,19 @@ func (c *Client) stopRecordingWithRetry(resourceID, sid, uid, channelName string
 
 // QueryRecording queries the status of a recording
 func (c *Client) QueryRecording(resourceID, sid string) (map[string]interface{}, error) {
+	return c.QueryRecordingCtx(context.Background(), resourceID, sid)
+}
+
+// QueryRecordingCtx is QueryRecording with a caller-supplied context.
+func (c *Client) QueryRecordingCtx(ctx context.Context, resourceID, sid string) (map[string]interface{}, error) {
 	queryEndpoint := fmt.Sprintf("/cloud_recording/resourceid/%s/sid/%s/mode/mix/query", resourceID, sid)
-	return c.makeRequest(queryEndpoint, "GET", nil)
+	return c.makeRequestCtx(ctx, queryEndpoint, "GET", nil)
 }
 
-// getAgoraS3Region converts AWS region to Agora S3 region code
-func getAgoraS3Region(awsRegion string) int {
+// AWSRegionCode converts an AWS region name (e.g. "us-east-1") to the
+// numeric region code Agora's storageConfig.region expects for the S3
+// vendor. Used when building an S3 agora.BackendConfig from AWS_REGION.
+func AWSRegionCode(awsRegion string) int {
 	// Agora S3 region codes: https://docs.agora.io/en/cloud-recording/reference/region-config
 	regionMap := map[string]int{
 		"us-east-1":      0,  // US_EAST_1
@@ -0,0 +1,68 @@
+package agora
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// RecordingManifest is a registry-style sidecar document describing the
+// segments Agora wrote for a single recording session, so a consumer can
+// verify or dedupe a recording's content without re-downloading it.
+type RecordingManifest struct {
+	SchemaVersion int               `json:"schemaVersion"`
+	MediaType     string            `json:"mediaType"`
+	Segments      []ManifestSegment `json:"segments"`
+}
+
+// ManifestSegment describes one file Agora wrote as part of a recording
+// (an HLS chunk or the combined MP4).
+type ManifestSegment struct {
+	Digest   string `json:"digest"`
+	Size     int64  `json:"size"`
+	Duration int    `json:"duration"`
+}
+
+// ManifestKey returns the sidecar object key a recording's manifest is
+// stored under, derived purely from the Agora session ID so both the
+// writer (RecordingService) and reader (GetRecordingManifest) agree on the
+// path without threading it through a job record.
+func ManifestKey(sid string) string {
+	return fmt.Sprintf("manifests/%s.json", sid)
+}
+
+// PutRecordingManifest uploads manifest as the sidecar object for sid,
+// using the storage backend loadNumber would select for the recording
+// itself so the manifest lands in the same bucket.
+func (c *Client) PutRecordingManifest(sid, loadNumber string, manifest *RecordingManifest) error {
+	sb, err := c.selectBackend(loadNumber)
+	if err != nil {
+		return err
+	}
+
+	body, err := json.Marshal(manifest)
+	if err != nil {
+		return fmt.Errorf("failed to marshal manifest: %w", err)
+	}
+
+	return PutManifest(sb, ManifestKey(sid), body)
+}
+
+// GetRecordingManifest downloads and parses the sidecar manifest for sid.
+func (c *Client) GetRecordingManifest(sid string) (*RecordingManifest, error) {
+	sb, err := c.selectBackend("")
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := GetManifest(sb, ManifestKey(sid))
+	if err != nil {
+		return nil, err
+	}
+
+	var manifest RecordingManifest
+	if err := json.Unmarshal(body, &manifest); err != nil {
+		return nil, fmt.Errorf("failed to parse manifest for sid %s: %w", sid, err)
+	}
+
+	return &manifest, nil
+}
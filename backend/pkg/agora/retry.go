@@ -0,0 +1,93 @@
+package agora
+
+import (
+	"math"
+	"math/rand"
+	"strings"
+	"time"
+)
+
+// Retryer decides whether and how long to wait before retrying a failed
+// Agora REST call. Modeled on aws-sdk-go's DefaultRetryer so the same
+// mental model applies across this codebase's AWS and Agora clients.
+type Retryer interface {
+	// MaxRetries is the maximum number of retry attempts after the
+	// initial request.
+	MaxRetries() int
+	// RetryDelay returns how long to sleep before the given attempt
+	// (0-indexed: the first retry is attempt 0).
+	RetryDelay(attempt int) time.Duration
+	// ShouldRetry inspects a completed request's outcome and reports
+	// whether it's worth retrying. status is 0 when err prevented the
+	// request from completing at all.
+	ShouldRetry(status int, body []byte, err error) bool
+}
+
+// DefaultRetryer retries 5xx responses, connection resets, and Agora's own
+// transient error codes (65 network jitter, 57 concurrent-limit) using
+// full-jitter exponential backoff: sleep = rand(0, min(MaxRetryDelay,
+// MinRetryDelay*2^attempt)).
+type DefaultRetryer struct {
+	NumMaxRetries int
+	MinRetryDelay time.Duration
+	MaxRetryDelay time.Duration
+}
+
+// NewDefaultRetryer creates a DefaultRetryer with the given retry budget
+// and the package's default delay bounds (500ms..30s).
+func NewDefaultRetryer(maxRetries int) *DefaultRetryer {
+	return &DefaultRetryer{
+		NumMaxRetries: maxRetries,
+		MinRetryDelay: 500 * time.Millisecond,
+		MaxRetryDelay: 30 * time.Second,
+	}
+}
+
+func (r *DefaultRetryer) MaxRetries() int {
+	return r.NumMaxRetries
+}
+
+func (r *DefaultRetryer) RetryDelay(attempt int) time.Duration {
+	minDelay := r.MinRetryDelay
+	if minDelay <= 0 {
+		minDelay = 500 * time.Millisecond
+	}
+	maxDelay := r.MaxRetryDelay
+	if maxDelay <= 0 {
+		maxDelay = 30 * time.Second
+	}
+
+	backoff := time.Duration(float64(minDelay) * math.Pow(2, float64(attempt)))
+	if backoff <= 0 || backoff > maxDelay {
+		backoff = maxDelay
+	}
+
+	return time.Duration(rand.Int63n(int64(backoff) + 1))
+}
+
+// transientAgoraCodes are Agora REST API error codes known to be
+// transient: 65 is network jitter, 57 is a concurrent-request limit.
+// https://docs.agora.io/en/cloud-recording/reference/error-codes
+var transientAgoraCodes = []string{`"code":65`, `"code": 65`, `"code":57`, `"code": 57`}
+
+func (r *DefaultRetryer) ShouldRetry(status int, body []byte, err error) bool {
+	if err != nil {
+		msg := err.Error()
+		return strings.Contains(msg, "connection reset") ||
+			strings.Contains(msg, "EOF") ||
+			strings.Contains(msg, "timeout")
+	}
+
+	if status >= 500 {
+		return true
+	}
+
+	bodyStr := string(body)
+	for _, code := range transientAgoraCodes {
+		if strings.Contains(bodyStr, code) {
+			return true
+		}
+	}
+
+	return false
+}
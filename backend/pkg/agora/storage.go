@@ -0,0 +1,230 @@
+package agora
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+)
+
+// Vendor codes for Agora's cloud recording storageConfig.vendor field.
+// https://docs.agora.io/en/cloud-recording/reference/region-config
+const (
+	VendorAWSS3     = 1
+	VendorIBMCOS    = 2
+	VendorAliyunOSS = 3
+	VendorGCS       = 4
+	VendorAzureBlob = 5
+)
+
+// SSEMode selects the server-side encryption applied to objects written by
+// a StorageBackend.
+type SSEMode string
+
+const (
+	SSENone     SSEMode = "none"
+	SSES3       SSEMode = "sse-s3"
+	SSEKMS      SSEMode = "sse-kms"
+	SSECustomer SSEMode = "sse-c"
+)
+
+// SSEConfig describes the server-side encryption a StorageBackend applies to
+// uploaded recordings.
+type SSEConfig struct {
+	Mode SSEMode
+	// KMSKeyARN is required when Mode is SSEKMS.
+	KMSKeyARN string
+	// CustomerKey is a base64-encoded 256-bit key, required when Mode is
+	// SSECustomer.
+	CustomerKey string
+}
+
+// BackendConfig holds the credentials and placement settings shared by every
+// StorageBackend implementation.
+type BackendConfig struct {
+	Label     string
+	Region    int
+	Bucket    string
+	AccessKey string
+	SecretKey string
+	// AWSRegion is the AWS region name (e.g. "us-east-1") used when talking
+	// to this backend directly via aws-sdk-go, such as in
+	// ApplyLifecycleTag. It is unused for non-S3 vendors.
+	AWSRegion      string
+	FileNamePrefix []string
+	SSE            SSEConfig
+}
+
+// StorageBackend abstracts one vendor's cloud recording storage, so
+// agora.Client can be configured with a primary and optional failover
+// backend without branching on vendor throughout StartRecording.
+type StorageBackend interface {
+	// Name identifies this backend among agora.Client's configured
+	// backends, e.g. for SetStorageBackend.
+	Name() string
+	// VendorCode is the Agora storageConfig.vendor value for this backend.
+	VendorCode() int
+	RegionCode() int
+	Bucket() string
+	// Credentials returns the access key and secret key Agora should use
+	// to write recordings to this backend.
+	Credentials() (accessKey, secretKey string)
+	FileNamePrefix() []string
+	SSE() SSEConfig
+}
+
+// backend is the shared StorageBackend implementation; only the vendor code
+// differs between the concrete constructors below.
+type backend struct {
+	cfg        BackendConfig
+	vendorCode int
+}
+
+func (b *backend) Name() string                  { return b.cfg.Label }
+func (b *backend) VendorCode() int               { return b.vendorCode }
+func (b *backend) RegionCode() int               { return b.cfg.Region }
+func (b *backend) Bucket() string                { return b.cfg.Bucket }
+func (b *backend) Credentials() (string, string) { return b.cfg.AccessKey, b.cfg.SecretKey }
+func (b *backend) FileNamePrefix() []string      { return b.cfg.FileNamePrefix }
+func (b *backend) SSE() SSEConfig                { return b.cfg.SSE }
+
+// NewS3Backend creates a StorageBackend for AWS S3.
+func NewS3Backend(cfg BackendConfig) StorageBackend {
+	return &backend{cfg: cfg, vendorCode: VendorAWSS3}
+}
+
+// NewIBMCOSBackend creates a StorageBackend for IBM Cloud Object Storage.
+func NewIBMCOSBackend(cfg BackendConfig) StorageBackend {
+	return &backend{cfg: cfg, vendorCode: VendorIBMCOS}
+}
+
+// NewAliyunOSSBackend creates a StorageBackend for Alibaba Cloud OSS.
+func NewAliyunOSSBackend(cfg BackendConfig) StorageBackend {
+	return &backend{cfg: cfg, vendorCode: VendorAliyunOSS}
+}
+
+// NewGCSBackend creates a StorageBackend for Google Cloud Storage.
+func NewGCSBackend(cfg BackendConfig) StorageBackend {
+	return &backend{cfg: cfg, vendorCode: VendorGCS}
+}
+
+// NewAzureBlobBackend creates a StorageBackend for Azure Blob Storage.
+func NewAzureBlobBackend(cfg BackendConfig) StorageBackend {
+	return &backend{cfg: cfg, vendorCode: VendorAzureBlob}
+}
+
+// NewBackendFromVendor builds a StorageBackend from a vendor name
+// ("s3", "ibm-cos", "aliyun-oss", "gcs", "azure-blob"), the shape
+// config.RecordingBackendConfig.Vendor uses so deployments can select a
+// backend purely through configuration.
+func NewBackendFromVendor(vendor string, cfg BackendConfig) (StorageBackend, error) {
+	switch vendor {
+	case "s3":
+		return NewS3Backend(cfg), nil
+	case "ibm-cos":
+		return NewIBMCOSBackend(cfg), nil
+	case "aliyun-oss":
+		return NewAliyunOSSBackend(cfg), nil
+	case "gcs":
+		return NewGCSBackend(cfg), nil
+	case "azure-blob":
+		return NewAzureBlobBackend(cfg), nil
+	default:
+		return nil, fmt.Errorf("unknown recording storage vendor %q", vendor)
+	}
+}
+
+// s3BackendFor type-asserts sb to the AWS S3 vendor and builds an
+// aws-sdk-go session for it, returning an error for any other vendor so
+// callers can log and continue rather than fail the recording. ApplyLifecycleTag,
+// PutManifest, and GetManifest all need the same AWS-only escape hatch.
+func s3BackendFor(sb StorageBackend, action string) (*backend, *session.Session, error) {
+	b, ok := sb.(*backend)
+	if !ok || b.vendorCode != VendorAWSS3 {
+		return nil, nil, fmt.Errorf("%s is not supported for storage backend %q", action, sb.Name())
+	}
+
+	accessKey, secretKey := sb.Credentials()
+	sess, err := session.NewSession(&aws.Config{
+		Region:      aws.String(b.cfg.AWSRegion),
+		Credentials: credentials.NewStaticCredentials(accessKey, secretKey, ""),
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create AWS session: %w", err)
+	}
+
+	return b, sess, nil
+}
+
+// ApplyLifecycleTag tags an object written by a StorageBackend with a
+// "retention-policy" tag so a bucket lifecycle rule can expire it
+// automatically. Only the AWS S3 vendor is supported directly via
+// aws-sdk-go; other vendors return an error so callers can log and
+// continue rather than fail the recording.
+func ApplyLifecycleTag(sb StorageBackend, key, retentionPolicy string) error {
+	_, sess, err := s3BackendFor(sb, "lifecycle tagging")
+	if err != nil {
+		return err
+	}
+
+	_, err = s3.New(sess).PutObjectTagging(&s3.PutObjectTaggingInput{
+		Bucket: aws.String(sb.Bucket()),
+		Key:    aws.String(key),
+		Tagging: &s3.Tagging{
+			TagSet: []*s3.Tag{
+				{Key: aws.String("retention-policy"), Value: aws.String(retentionPolicy)},
+			},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to tag %s: %w", key, err)
+	}
+	return nil
+}
+
+// PutManifest uploads a recording manifest (see RecordingManifest) as a
+// sidecar JSON object next to the recording it describes.
+func PutManifest(sb StorageBackend, key string, manifest []byte) error {
+	_, sess, err := s3BackendFor(sb, "manifest storage")
+	if err != nil {
+		return err
+	}
+
+	_, err = s3.New(sess).PutObject(&s3.PutObjectInput{
+		Bucket:      aws.String(sb.Bucket()),
+		Key:         aws.String(key),
+		Body:        bytes.NewReader(manifest),
+		ContentType: aws.String("application/json"),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to upload manifest %s: %w", key, err)
+	}
+	return nil
+}
+
+// GetManifest downloads a previously-stored recording manifest.
+func GetManifest(sb StorageBackend, key string) ([]byte, error) {
+	_, sess, err := s3BackendFor(sb, "manifest storage")
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := s3.New(sess).GetObject(&s3.GetObjectInput{
+		Bucket: aws.String(sb.Bucket()),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to download manifest %s: %w", key, err)
+	}
+	defer result.Body.Close()
+
+	body, err := io.ReadAll(result.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read manifest %s: %w", key, err)
+	}
+	return body, nil
+}
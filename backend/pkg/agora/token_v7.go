@@ -0,0 +1,334 @@
+package agora
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math/rand"
+	"strings"
+	"time"
+)
+
+// Version007 identifies the v007 "packed service" token format: unlike
+// v006 (AccessToken), each Service carries its own privilege expirations
+// instead of one expiration applying to the whole token.
+const Version007 = "007"
+
+// ServiceType identifies which Agora service a Service entry in a v007
+// token grants privileges for.
+type ServiceType uint16
+
+const (
+	ServiceRTC  ServiceType = 1
+	ServiceRTM  ServiceType = 2
+	ServiceChat ServiceType = 3
+)
+
+// Service-scoped privilege types. RTC reuses the v006 join/publish
+// privilege constants since the wire format for a privileges map is
+// unchanged between versions.
+const (
+	PrivilegeRTMLogin = uint16(1)
+
+	PrivilegeChatUser = uint16(1)
+	PrivilegeChatApp  = uint16(2)
+)
+
+// Service is one {ServiceType, Privileges} entry in a v007 AccessToken2.
+type Service struct {
+	Type       ServiceType
+	Privileges map[uint16]uint32
+}
+
+func newService(serviceType ServiceType) *Service {
+	return &Service{Type: serviceType, Privileges: make(map[uint16]uint32)}
+}
+
+func (s *Service) addPrivilege(privilege uint16, expireTimestamp uint32) {
+	s.Privileges[privilege] = expireTimestamp
+}
+
+func (s *Service) pack(buf *bytes.Buffer) {
+	packUint16ToBuf(buf, uint16(s.Type))
+	packMapUint32ToBuf(buf, s.Privileges)
+}
+
+// AccessToken2 represents an Agora v007 access token: a packed body of
+// {IssueTs, Salt, ExpireTs, Services[]} where each Service holds its own
+// independently-expiring privileges, signed in two HMAC-SHA256 passes (see
+// Build).
+type AccessToken2 struct {
+	AppID          string
+	AppCertificate string
+	IssueTs        uint32
+	Salt           uint32
+	ExpireTs       uint32
+	Services       []*Service
+}
+
+// NewAccessToken2 creates a v007 token issued now, expiring expireSeconds
+// from now, with no services attached yet (see AddService).
+func NewAccessToken2(appID, appCertificate string, expireSeconds uint32) *AccessToken2 {
+	rand.Seed(time.Now().UnixNano())
+	now := uint32(time.Now().Unix())
+
+	return &AccessToken2{
+		AppID:          appID,
+		AppCertificate: appCertificate,
+		IssueTs:        now,
+		Salt:           rand.Uint32(),
+		ExpireTs:       now + expireSeconds,
+	}
+}
+
+// AddService attaches a service (with its own privileges) to the token.
+func (token *AccessToken2) AddService(service *Service) {
+	token.Services = append(token.Services, service)
+}
+
+// Build serializes and signs the token, returning
+// "007"+AppID+base64(content).
+//
+// Signing is two HMAC-SHA256 passes: first over the packed
+// {IssueTs,Salt,ExpireTs,Services} body, keyed by AppCertificate, producing
+// a body signature; then a second HMAC of that body signature over a
+// header binding version+AppID+IssueTs+Salt, so a body signature computed
+// for one AppID/salt can't be replayed under another. The resulting
+// Signature is written as the first field of content, ahead of the body it
+// was computed over - mirroring how v006 prepends its signature to the
+// packed message.
+func (token *AccessToken2) Build() (string, error) {
+	if token.AppID == "" {
+		return "", fmt.Errorf("appID is required")
+	}
+	if token.AppCertificate == "" {
+		return "", fmt.Errorf("appCertificate is required")
+	}
+
+	body := token.packBody()
+	bodySig := hmacSign(token.AppCertificate, string(body))
+	signature := hmacSign(string(bodySig), token.signingHeader())
+
+	var content bytes.Buffer
+	packBytesToBuf(&content, signature)
+	content.Write(body)
+
+	return Version007 + token.AppID + base64.StdEncoding.EncodeToString(content.Bytes()), nil
+}
+
+// signingHeader is the version+AppID+IssueTs+Salt string the body
+// signature is re-signed against.
+func (token *AccessToken2) signingHeader() string {
+	return fmt.Sprintf("%s%s%d%d", Version007, token.AppID, token.IssueTs, token.Salt)
+}
+
+func (token *AccessToken2) packBody() []byte {
+	var buf bytes.Buffer
+
+	packUint32ToBuf(&buf, token.IssueTs)
+	packUint32ToBuf(&buf, token.Salt)
+	packUint32ToBuf(&buf, token.ExpireTs)
+
+	packUint16ToBuf(&buf, uint16(len(token.Services)))
+	for _, s := range token.Services {
+		s.pack(&buf)
+	}
+
+	return buf.Bytes()
+}
+
+// DecodedToken2 is a v007 token's fields as parsed by DecodeAccessToken2,
+// before its signature has been checked.
+type DecodedToken2 struct {
+	AppID     string
+	Signature []byte
+	IssueTs   uint32
+	Salt      uint32
+	ExpireTs  uint32
+	Services  []*Service
+}
+
+// DecodeAccessToken2 parses a v007 token string into its fields without
+// verifying the signature. appID must be the AppID the token was generated
+// for - like real Agora SDKs, the caller is expected to already know which
+// project a token belongs to rather than recovering it from the token text.
+func DecodeAccessToken2(token, appID string) (*DecodedToken2, error) {
+	prefix := Version007 + appID
+	if !strings.HasPrefix(token, prefix) {
+		return nil, fmt.Errorf("token is not a v007 token for appID %q", appID)
+	}
+
+	content, err := base64.StdEncoding.DecodeString(token[len(prefix):])
+	if err != nil {
+		return nil, fmt.Errorf("decode content: %w", err)
+	}
+
+	r := bytes.NewReader(content)
+
+	signature, err := readBytes(r)
+	if err != nil {
+		return nil, fmt.Errorf("read signature: %w", err)
+	}
+	issueTs, err := readUint32(r)
+	if err != nil {
+		return nil, fmt.Errorf("read issueTs: %w", err)
+	}
+	salt, err := readUint32(r)
+	if err != nil {
+		return nil, fmt.Errorf("read salt: %w", err)
+	}
+	expireTs, err := readUint32(r)
+	if err != nil {
+		return nil, fmt.Errorf("read expireTs: %w", err)
+	}
+	serviceCount, err := readUint16(r)
+	if err != nil {
+		return nil, fmt.Errorf("read service count: %w", err)
+	}
+
+	services := make([]*Service, 0, serviceCount)
+	for i := 0; i < int(serviceCount); i++ {
+		serviceType, err := readUint16(r)
+		if err != nil {
+			return nil, fmt.Errorf("read service type: %w", err)
+		}
+		privileges, err := readMapUint32(r)
+		if err != nil {
+			return nil, fmt.Errorf("read service privileges: %w", err)
+		}
+		services = append(services, &Service{Type: ServiceType(serviceType), Privileges: privileges})
+	}
+
+	return &DecodedToken2{
+		AppID:     appID,
+		Signature: signature,
+		IssueTs:   issueTs,
+		Salt:      salt,
+		ExpireTs:  expireTs,
+		Services:  services,
+	}, nil
+}
+
+// VerifyAccessToken2 re-derives a v007 token's two-pass signature from
+// appCertificate and reports whether it matches the signature embedded in
+// token.
+func VerifyAccessToken2(token, appID, appCertificate string) (bool, error) {
+	decoded, err := DecodeAccessToken2(token, appID)
+	if err != nil {
+		return false, err
+	}
+
+	reconstructed := &AccessToken2{
+		AppID:    appID,
+		IssueTs:  decoded.IssueTs,
+		Salt:     decoded.Salt,
+		ExpireTs: decoded.ExpireTs,
+		Services: decoded.Services,
+	}
+
+	bodySig := hmacSign(appCertificate, string(reconstructed.packBody()))
+	expected := hmacSign(string(bodySig), reconstructed.signingHeader())
+
+	return hmac.Equal(expected, decoded.Signature), nil
+}
+
+func readUint16(r *bytes.Reader) (uint16, error) {
+	b := make([]byte, 2)
+	if _, err := io.ReadFull(r, b); err != nil {
+		return 0, err
+	}
+	return binary.LittleEndian.Uint16(b), nil
+}
+
+func readUint32(r *bytes.Reader) (uint32, error) {
+	b := make([]byte, 4)
+	if _, err := io.ReadFull(r, b); err != nil {
+		return 0, err
+	}
+	return binary.LittleEndian.Uint32(b), nil
+}
+
+func readBytes(r *bytes.Reader) ([]byte, error) {
+	length, err := readUint16(r)
+	if err != nil {
+		return nil, err
+	}
+	b := make([]byte, length)
+	if _, err := io.ReadFull(r, b); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+func readMapUint32(r *bytes.Reader) (map[uint16]uint32, error) {
+	count, err := readUint16(r)
+	if err != nil {
+		return nil, err
+	}
+	m := make(map[uint16]uint32, count)
+	for i := 0; i < int(count); i++ {
+		k, err := readUint16(r)
+		if err != nil {
+			return nil, err
+		}
+		v, err := readUint32(r)
+		if err != nil {
+			return nil, err
+		}
+		m[k] = v
+	}
+	return m, nil
+}
+
+// GenerateRTCTokenV7 generates a v007 RTC access token: a ServiceRTC entry
+// with a join-channel privilege (plus publish privileges for
+// RolePublisher), all expiring expireSeconds from now. channelName and uid
+// are accepted for signature parity with GenerateRTCToken; Agora checks
+// them against the RTC join request independently of the token's
+// privileges.
+func GenerateRTCTokenV7(appID, appCertificate, channelName, uid string, role int, expireSeconds uint32) (string, error) {
+	if appID == "" {
+		return "", fmt.Errorf("appID is required")
+	}
+	if appCertificate == "" {
+		return "", fmt.Errorf("appCertificate is required")
+	}
+
+	token := NewAccessToken2(appID, appCertificate, expireSeconds)
+
+	rtc := newService(ServiceRTC)
+	rtc.addPrivilege(PrivilegeJoinChannel, token.ExpireTs)
+	if role == RolePublisher {
+		rtc.addPrivilege(PrivilegePublishAudioStream, token.ExpireTs)
+		rtc.addPrivilege(PrivilegePublishVideoStream, token.ExpireTs)
+		rtc.addPrivilege(PrivilegePublishDataStream, token.ExpireTs)
+	}
+	token.AddService(rtc)
+
+	return token.Build()
+}
+
+// GenerateRTMToken generates a v007 RTM (messaging) access token: a
+// ServiceRTM entry with a login privilege expiring expireSeconds from now.
+// userID is accepted for signature parity with GenerateRTCTokenV7; Agora
+// checks it against the RTM login request independently of the token's
+// privileges.
+func GenerateRTMToken(appID, appCertificate, userID string, expireSeconds uint32) (string, error) {
+	if appID == "" {
+		return "", fmt.Errorf("appID is required")
+	}
+	if appCertificate == "" {
+		return "", fmt.Errorf("appCertificate is required")
+	}
+
+	token := NewAccessToken2(appID, appCertificate, expireSeconds)
+
+	rtm := newService(ServiceRTM)
+	rtm.addPrivilege(PrivilegeRTMLogin, token.ExpireTs)
+	token.AddService(rtm)
+
+	return token.Build()
+}
@@ -0,0 +1,126 @@
+package agora
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateRTCTokenV7(t *testing.T) {
+	tests := []struct {
+		name         string
+		role         int
+		wantPriv     []uint16
+		wantNotPriv  []uint16
+		expireSecond uint32
+	}{
+		{
+			name:         "publisher gets join and publish privileges",
+			role:         RolePublisher,
+			wantPriv:     []uint16{PrivilegeJoinChannel, PrivilegePublishAudioStream, PrivilegePublishVideoStream, PrivilegePublishDataStream},
+			expireSecond: 3600,
+		},
+		{
+			name:         "subscriber only gets join privilege",
+			role:         RoleSubscriber,
+			wantPriv:     []uint16{PrivilegeJoinChannel},
+			wantNotPriv:  []uint16{PrivilegePublishAudioStream, PrivilegePublishVideoStream, PrivilegePublishDataStream},
+			expireSecond: 3600,
+		},
+	}
+
+	const appID = "test-app-id"
+	const appCert = "test-app-certificate"
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			token, err := GenerateRTCTokenV7(appID, appCert, "channel1", "1234", tt.role, tt.expireSecond)
+			require.NoError(t, err)
+			assert.True(t, len(token) > len(Version007+appID))
+			assert.Equal(t, Version007, token[:len(Version007)])
+
+			decoded, err := DecodeAccessToken2(token, appID)
+			require.NoError(t, err)
+			require.Len(t, decoded.Services, 1)
+
+			rtc := decoded.Services[0]
+			assert.Equal(t, ServiceRTC, rtc.Type)
+
+			for _, priv := range tt.wantPriv {
+				assert.Contains(t, rtc.Privileges, priv)
+			}
+			for _, priv := range tt.wantNotPriv {
+				assert.NotContains(t, rtc.Privileges, priv)
+			}
+
+			ok, err := VerifyAccessToken2(token, appID, appCert)
+			require.NoError(t, err)
+			assert.True(t, ok)
+
+			ok, err = VerifyAccessToken2(token, appID, "wrong-certificate")
+			require.NoError(t, err)
+			assert.False(t, ok)
+		})
+	}
+}
+
+func TestGenerateRTMToken(t *testing.T) {
+	const appID = "test-app-id"
+	const appCert = "test-app-certificate"
+
+	token, err := GenerateRTMToken(appID, appCert, "user-1", 3600)
+	require.NoError(t, err)
+
+	decoded, err := DecodeAccessToken2(token, appID)
+	require.NoError(t, err)
+	require.Len(t, decoded.Services, 1)
+
+	rtm := decoded.Services[0]
+	assert.Equal(t, ServiceRTM, rtm.Type)
+	assert.Contains(t, rtm.Privileges, PrivilegeRTMLogin)
+
+	ok, err := VerifyAccessToken2(token, appID, appCert)
+	require.NoError(t, err)
+	assert.True(t, ok)
+}
+
+func TestAccessToken2BuildAndDecodeByteLayout(t *testing.T) {
+	const appID = "layout-app-id"
+	const appCert = "layout-app-certificate"
+
+	token := NewAccessToken2(appID, appCert, 120)
+	rtc := newService(ServiceRTC)
+	rtc.addPrivilege(PrivilegeJoinChannel, token.ExpireTs)
+	token.AddService(rtc)
+
+	chat := newService(ServiceChat)
+	chat.addPrivilege(PrivilegeChatUser, token.ExpireTs)
+	token.AddService(chat)
+
+	built, err := token.Build()
+	require.NoError(t, err)
+
+	decoded, err := DecodeAccessToken2(built, appID)
+	require.NoError(t, err)
+
+	assert.Equal(t, token.IssueTs, decoded.IssueTs)
+	assert.Equal(t, token.Salt, decoded.Salt)
+	assert.Equal(t, token.ExpireTs, decoded.ExpireTs)
+	require.Len(t, decoded.Services, 2)
+	assert.Equal(t, ServiceRTC, decoded.Services[0].Type)
+	assert.Equal(t, ServiceChat, decoded.Services[1].Type)
+	assert.Equal(t, token.ExpireTs, decoded.Services[1].Privileges[PrivilegeChatUser])
+
+	ok, err := VerifyAccessToken2(built, appID, appCert)
+	require.NoError(t, err)
+	assert.True(t, ok)
+}
+
+func TestGenerateRTCTokenRequiresAppIDAndCertificate(t *testing.T) {
+	_, err := GenerateRTCTokenV7("", "cert", "channel", "1", RolePublisher, 3600)
+	assert.Error(t, err)
+
+	_, err = GenerateRTCTokenV7("app", "", "channel", "1", RolePublisher, 3600)
+	assert.Error(t, err)
+}
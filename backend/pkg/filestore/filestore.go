@@ -0,0 +1,47 @@
+// Package filestore abstracts blob storage behind a single interface so
+// the app can run against AWS S3, an S3-compatible endpoint (MinIO, Wasabi,
+// Backblaze B2), or local disk without the callers caring which. MediaHandler
+// and the screenshot-gallery path depend on FileStore rather than a concrete
+// backend; pkg/s3.Client remains the direct AWS client for the recording
+// pipeline, which needs S3-specific capabilities (ranged-GET hashing,
+// presigned PUT policies) this interface doesn't expose.
+package filestore
+
+import (
+	"context"
+	"io"
+	"time"
+)
+
+// Object describes a single stored file, returned by List.
+type Object struct {
+	Key          string
+	Size         int64
+	LastModified time.Time
+}
+
+// UploadResult is returned by a successful Upload.
+type UploadResult struct {
+	Key string
+	URL string
+}
+
+// FileStore is implemented by every storage backend. Keys are
+// backend-relative paths (e.g. "LOAD123_169...screenshot.png"); callers
+// never see bucket names, endpoints, or local directory layout.
+type FileStore interface {
+	// Upload stores r under key with the given content type, returning the
+	// stored key and a best-effort URL for it.
+	Upload(ctx context.Context, key string, r io.Reader, contentType string) (*UploadResult, error)
+	// List returns every object whose key starts with prefix.
+	List(ctx context.Context, prefix string) ([]Object, error)
+	// Delete removes the object at key. Deleting a missing key is not an error.
+	Delete(ctx context.Context, key string) error
+	// SignedURL returns a time-limited URL a client can use to fetch key
+	// directly, without going through the backend API.
+	SignedURL(ctx context.Context, key string, ttl time.Duration) (string, error)
+	// Get opens a streaming read of key's full contents, for callers (e.g.
+	// the async malware scan worker) that need the raw bytes back rather
+	// than a URL. The caller must close the returned reader.
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+}
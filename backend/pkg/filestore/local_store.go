@@ -0,0 +1,205 @@
+package filestore
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// LocalConfig configures a LocalStore.
+type LocalConfig struct {
+	// Dir is the directory objects are written under. Keys may not escape it.
+	Dir string
+	// BaseURL is prefixed to signed tokens to form a URL the local
+	// "/files/:token" handler (see DecodeToken) can resolve, e.g.
+	// "http://localhost:8080".
+	BaseURL string
+	// Secret HMAC-signs tokens so a client can't fetch an object whose key
+	// or expiry it tampered with.
+	Secret string
+}
+
+// LocalStore is a FileStore that writes objects under a local directory and
+// serves them through HMAC-signed tokens instead of a cloud provider's own
+// signed URLs, for deployments with no S3-compatible storage available.
+type LocalStore struct {
+	dir     string
+	baseURL string
+	secret  string
+}
+
+// NewLocalStore creates a LocalStore from cfg, creating Dir if needed.
+func NewLocalStore(cfg LocalConfig) (*LocalStore, error) {
+	if err := os.MkdirAll(cfg.Dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create local file store dir %s: %w", cfg.Dir, err)
+	}
+	return &LocalStore{
+		dir:     cfg.Dir,
+		baseURL: strings.TrimSuffix(cfg.BaseURL, "/"),
+		secret:  cfg.Secret,
+	}, nil
+}
+
+func (st *LocalStore) path(key string) (string, error) {
+	full := filepath.Join(st.dir, filepath.Clean("/"+key))
+	if !strings.HasPrefix(full, filepath.Clean(st.dir)+string(os.PathSeparator)) {
+		return "", fmt.Errorf("invalid key %q", key)
+	}
+	return full, nil
+}
+
+func (st *LocalStore) Upload(ctx context.Context, key string, r io.Reader, contentType string) (*UploadResult, error) {
+	full, err := st.path(key)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(filepath.Dir(full), 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create directory for %s: %w", key, err)
+	}
+
+	f, err := os.Create(full)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create %s: %w", key, err)
+	}
+	defer f.Close()
+
+	if _, err := io.Copy(f, r); err != nil {
+		return nil, fmt.Errorf("failed to write %s: %w", key, err)
+	}
+
+	url, err := st.SignedURL(ctx, key, time.Hour)
+	if err != nil {
+		url = ""
+	}
+	return &UploadResult{Key: key, URL: url}, nil
+}
+
+func (st *LocalStore) List(ctx context.Context, prefix string) ([]Object, error) {
+	var objects []Object
+	err := filepath.WalkDir(st.dir, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		rel, err := filepath.Rel(st.dir, path)
+		if err != nil {
+			return err
+		}
+		key := filepath.ToSlash(rel)
+		if !strings.HasPrefix(key, prefix) {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		objects = append(objects, Object{Key: key, Size: info.Size(), LastModified: info.ModTime()})
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list objects with prefix %s: %w", prefix, err)
+	}
+	return objects, nil
+}
+
+func (st *LocalStore) Delete(ctx context.Context, key string) error {
+	full, err := st.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(full); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to delete %s: %w", key, err)
+	}
+	return nil
+}
+
+func (st *LocalStore) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	full, err := st.path(key)
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.Open(full)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s: %w", key, err)
+	}
+	return f, nil
+}
+
+func (st *LocalStore) SignedURL(ctx context.Context, key string, ttl time.Duration) (string, error) {
+	expiry := time.Now().Add(ttl).Unix()
+	token := st.encodeToken(key, expiry)
+	return fmt.Sprintf("%s/files/%s", st.baseURL, token), nil
+}
+
+// Open resolves and validates a token minted by SignedURL, returning the
+// open file it points at. The caller (the "/files/:token" handler) is
+// responsible for closing it.
+func (st *LocalStore) Open(token string) (*os.File, error) {
+	key, expiry, err := st.decodeToken(token)
+	if err != nil {
+		return nil, err
+	}
+	if time.Now().Unix() > expiry {
+		return nil, fmt.Errorf("token expired")
+	}
+
+	full, err := st.path(key)
+	if err != nil {
+		return nil, err
+	}
+	return os.Open(full)
+}
+
+// encodeToken packs key and expiry into a base64url token signed with an
+// HMAC-SHA256 tag, so LocalFileHandler can recover and verify it without a
+// database lookup.
+func (st *LocalStore) encodeToken(key string, expiry int64) string {
+	payload := fmt.Sprintf("%d:%s", expiry, key)
+	sig := st.sign(payload)
+	raw := fmt.Sprintf("%s.%s", payload, sig)
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+func (st *LocalStore) decodeToken(token string) (key string, expiry int64, err error) {
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return "", 0, fmt.Errorf("invalid token")
+	}
+
+	parts := strings.SplitN(string(raw), ".", 2)
+	if len(parts) != 2 {
+		return "", 0, fmt.Errorf("invalid token")
+	}
+	payload, sig := parts[0], parts[1]
+
+	if !hmac.Equal([]byte(sig), []byte(st.sign(payload))) {
+		return "", 0, fmt.Errorf("invalid token signature")
+	}
+
+	payloadParts := strings.SplitN(payload, ":", 2)
+	if len(payloadParts) != 2 {
+		return "", 0, fmt.Errorf("invalid token")
+	}
+	expiry, err = strconv.ParseInt(payloadParts[0], 10, 64)
+	if err != nil {
+		return "", 0, fmt.Errorf("invalid token")
+	}
+	return payloadParts[1], expiry, nil
+}
+
+func (st *LocalStore) sign(payload string) string {
+	mac := hmac.New(sha256.New, []byte(st.secret))
+	mac.Write([]byte(payload))
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,80 @@
+package filestore
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+)
+
+// MockStore is an in-memory FileStore for unit-testing upload paths like
+// MediaHandler.SaveScreenshot without hitting S3 or the local disk.
+type MockStore struct {
+	mu      sync.Mutex
+	objects map[string][]byte
+	// Uploads records every key passed to Upload, in call order, so tests
+	// can assert on what was stored without inspecting the object bytes.
+	Uploads []string
+}
+
+// NewMockStore creates an empty MockStore.
+func NewMockStore() *MockStore {
+	return &MockStore{objects: make(map[string][]byte)}
+}
+
+func (m *MockStore) Upload(ctx context.Context, key string, r io.Reader, contentType string) (*UploadResult, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	m.mu.Lock()
+	m.objects[key] = data
+	m.Uploads = append(m.Uploads, key)
+	m.mu.Unlock()
+
+	return &UploadResult{Key: key, URL: "mock://" + key}, nil
+}
+
+func (m *MockStore) List(ctx context.Context, prefix string) ([]Object, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var objects []Object
+	for key, data := range m.objects {
+		if len(prefix) > 0 && !bytes.HasPrefix([]byte(key), []byte(prefix)) {
+			continue
+		}
+		objects = append(objects, Object{Key: key, Size: int64(len(data))})
+	}
+	return objects, nil
+}
+
+func (m *MockStore) Delete(ctx context.Context, key string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.objects, key)
+	return nil
+}
+
+func (m *MockStore) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	m.mu.Lock()
+	data, ok := m.objects[key]
+	m.mu.Unlock()
+	if !ok {
+		return nil, fmt.Errorf("object %s not found", key)
+	}
+	return io.NopCloser(bytes.NewReader(data)), nil
+}
+
+func (m *MockStore) SignedURL(ctx context.Context, key string, ttl time.Duration) (string, error) {
+	m.mu.Lock()
+	_, ok := m.objects[key]
+	m.mu.Unlock()
+	if !ok {
+		return "", fmt.Errorf("object %s not found", key)
+	}
+	return "mock://" + key, nil
+}
@@ -0,0 +1,138 @@
+package filestore
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+)
+
+// S3Config configures an S3Store. Endpoint and ForcePathStyle are only
+// needed for S3-compatible providers (MinIO, Wasabi, Backblaze B2); leave
+// Endpoint empty to talk to AWS S3 itself.
+type S3Config struct {
+	AccessKeyID     string
+	SecretAccessKey string
+	Region          string
+	Bucket          string
+	// Endpoint overrides the default AWS S3 endpoint, e.g.
+	// "https://s3.us-west-000.backblazeb2.com" or a MinIO deployment's URL.
+	Endpoint string
+	// ForcePathStyle addresses objects as "endpoint/bucket/key" instead of
+	// "bucket.endpoint/key", which most S3-compatible providers require.
+	ForcePathStyle bool
+}
+
+// S3Store is a FileStore backed by AWS S3 or an S3-compatible endpoint.
+type S3Store struct {
+	client *s3.S3
+	bucket string
+}
+
+// NewS3Store creates an S3Store from cfg.
+func NewS3Store(cfg S3Config) (*S3Store, error) {
+	awsCfg := &aws.Config{
+		Region:      aws.String(cfg.Region),
+		Credentials: credentials.NewStaticCredentials(cfg.AccessKeyID, cfg.SecretAccessKey, ""),
+	}
+	if cfg.Endpoint != "" {
+		awsCfg.Endpoint = aws.String(cfg.Endpoint)
+		awsCfg.S3ForcePathStyle = aws.Bool(cfg.ForcePathStyle)
+	}
+
+	sess, err := session.NewSession(awsCfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create AWS session: %w", err)
+	}
+
+	return &S3Store{client: s3.New(sess), bucket: cfg.Bucket}, nil
+}
+
+func (st *S3Store) Upload(ctx context.Context, key string, r io.Reader, contentType string) (*UploadResult, error) {
+	body, err := toReadSeeker(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to buffer upload %s: %w", key, err)
+	}
+
+	_, err = st.client.PutObjectWithContext(ctx, &s3.PutObjectInput{
+		Bucket:      aws.String(st.bucket),
+		Key:         aws.String(key),
+		Body:        body,
+		ContentType: aws.String(contentType),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to upload %s: %w", key, err)
+	}
+
+	url, err := st.SignedURL(ctx, key, time.Hour)
+	if err != nil {
+		url = ""
+	}
+	return &UploadResult{Key: key, URL: url}, nil
+}
+
+func (st *S3Store) List(ctx context.Context, prefix string) ([]Object, error) {
+	result, err := st.client.ListObjectsV2WithContext(ctx, &s3.ListObjectsV2Input{
+		Bucket: aws.String(st.bucket),
+		Prefix: aws.String(prefix),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list objects with prefix %s: %w", prefix, err)
+	}
+
+	objects := make([]Object, 0, len(result.Contents))
+	for _, obj := range result.Contents {
+		if obj.Key == nil {
+			continue
+		}
+		o := Object{Key: *obj.Key}
+		if obj.Size != nil {
+			o.Size = *obj.Size
+		}
+		if obj.LastModified != nil {
+			o.LastModified = *obj.LastModified
+		}
+		objects = append(objects, o)
+	}
+	return objects, nil
+}
+
+func (st *S3Store) Delete(ctx context.Context, key string) error {
+	_, err := st.client.DeleteObjectWithContext(ctx, &s3.DeleteObjectInput{
+		Bucket: aws.String(st.bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete %s: %w", key, err)
+	}
+	return nil
+}
+
+func (st *S3Store) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	result, err := st.client.GetObjectWithContext(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(st.bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", key, err)
+	}
+	return result.Body, nil
+}
+
+func (st *S3Store) SignedURL(ctx context.Context, key string, ttl time.Duration) (string, error) {
+	req, _ := st.client.GetObjectRequest(&s3.GetObjectInput{
+		Bucket: aws.String(st.bucket),
+		Key:    aws.String(key),
+	})
+
+	url, err := req.Presign(ttl)
+	if err != nil {
+		return "", fmt.Errorf("failed to sign URL for %s: %w", key, err)
+	}
+	return url, nil
+}
@@ -0,0 +1,21 @@
+package filestore
+
+import (
+	"bytes"
+	"io"
+)
+
+// toReadSeeker buffers r into memory if it isn't already an io.ReadSeeker.
+// aws-sdk-go's PutObject requires a ReadSeeker so it can retry a failed
+// upload attempt; callers passing an arbitrary io.Reader (e.g. a decoded
+// base64 screenshot) get buffered here instead of having to do it themselves.
+func toReadSeeker(r io.Reader) (io.ReadSeeker, error) {
+	if rs, ok := r.(io.ReadSeeker); ok {
+		return rs, nil
+	}
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	return bytes.NewReader(data), nil
+}
@@ -0,0 +1,53 @@
+// Package imagesan sanitizes driver-submitted images before they reach the
+// gallery, by decoding and re-encoding them through Go's image package.
+// Re-encoding drops any EXIF/ICC profile or malformed ancillary chunk the
+// original container carried, since only the decoded pixel data survives
+// the round-trip.
+package imagesan
+
+import (
+	"bytes"
+	"fmt"
+	"image/jpeg"
+	"image/png"
+)
+
+// Sanitize re-encodes data as contentType, stripping any metadata the
+// original file carried. Content types it doesn't know how to re-encode
+// are returned unchanged.
+func Sanitize(data []byte, contentType string) ([]byte, error) {
+	switch contentType {
+	case "image/png":
+		return resanitizePNG(data)
+	case "image/jpeg", "image/jpg":
+		return resanitizeJPEG(data)
+	default:
+		return data, nil
+	}
+}
+
+func resanitizePNG(data []byte) ([]byte, error) {
+	img, err := png.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode png: %w", err)
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, fmt.Errorf("failed to re-encode png: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+func resanitizeJPEG(data []byte) ([]byte, error) {
+	img, err := jpeg.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode jpeg: %w", err)
+	}
+
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: 90}); err != nil {
+		return nil, fmt.Errorf("failed to re-encode jpeg: %w", err)
+	}
+	return buf.Bytes(), nil
+}
@@ -1,11 +1,11 @@
 package s3
 
 import (
-	"bytes"
 	"context"
-	"encoding/base64"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
-	"strings"
+	"io"
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
@@ -21,18 +21,6 @@ type Client struct {
 	region     string
 }
 
-// MediaObject represents an S3 media object
-type MediaObject struct {
-	Key          string `json:"key"`
-	Type         string `json:"type"`
-	Step         int    `json:"step,omitempty"`
-	LastModified string `json:"lastModified"`
-	FileName     string `json:"fileName"`
-	Size         int64  `json:"size"`
-	LoadNumber   string `json:"loadNumber"`
-	SignedURL    string `json:"signedUrl"`
-}
-
 // UploadResult represents the result of an upload operation
 type UploadResult struct {
 	Success bool   `json:"success"`
@@ -58,145 +46,35 @@ func NewClient(accessKeyID, secretAccessKey, region, bucketName string) (*Client
 	}, nil
 }
 
-// ListLoadMedia lists all media files for a specific load
-func (c *Client) ListLoadMedia(loadNumber string) ([]MediaObject, error) {
-	prefix := loadNumber + "_"
-
-	input := &s3.ListObjectsV2Input{
+// GetSignedURL generates a signed URL for an S3 object
+func (c *Client) GetSignedURL(key string, expiresInSeconds int64) (string, error) {
+	req, _ := c.s3Client.GetObjectRequest(&s3.GetObjectInput{
 		Bucket: aws.String(c.bucketName),
-		Prefix: aws.String(prefix),
-	}
-
-	result, err := c.s3Client.ListObjectsV2(input)
-	if err != nil {
-		return nil, fmt.Errorf("failed to list objects: %w", err)
-	}
-
-	media := make([]MediaObject, 0)
-	for _, obj := range result.Contents {
-		if obj.Key == nil {
-			continue
-		}
-
-		key := *obj.Key
-		fileName := key
-
-		// Only include screenshot files
-		if !strings.Contains(fileName, "screenshot") {
-			continue
-		}
-
-		// Determine media type
-		mediaType := "unknown"
-		ext := strings.ToLower(getExtension(fileName))
-		if isImageExtension(ext) || strings.Contains(fileName, "screenshot") {
-			mediaType = "image"
-		} else if isVideoExtension(ext) {
-			mediaType = "video"
-		}
-
-		// Generate signed URL
-		signedURL, err := c.GetSignedURL(key, 3600)
-		if err != nil {
-			continue
-		}
-
-		var lastModified string
-		if obj.LastModified != nil {
-			lastModified = obj.LastModified.Format(time.RFC3339)
-		}
-
-		var size int64
-		if obj.Size != nil {
-			size = *obj.Size
-		}
-
-		media = append(media, MediaObject{
-			Key:          key,
-			Type:         mediaType,
-			LastModified: lastModified,
-			FileName:     fileName,
-			Size:         size,
-			LoadNumber:   loadNumber,
-			SignedURL:    signedURL,
-		})
-	}
-
-	return media, nil
-}
-
-// UploadScreenshot uploads a screenshot to S3
-func (c *Client) UploadScreenshot(loadNumber string, imageData []byte, contentType string) (*UploadResult, error) {
-	timestamp := time.Now().UnixMilli()
-	key := fmt.Sprintf("%s_%d.screenshot.png", loadNumber, timestamp)
-
-	return c.uploadFile(key, imageData, contentType)
-}
-
-// UploadScreenshotWithTimestamp uploads a screenshot with a specific timestamp
-func (c *Client) UploadScreenshotWithTimestamp(loadNumber string, imageData []byte, contentType string, timestamp int64) (*UploadResult, error) {
-	key := fmt.Sprintf("%s_%d.screenshot.png", loadNumber, timestamp)
-	return c.uploadFile(key, imageData, contentType)
-}
-
-// UploadBase64Image uploads a base64 encoded image
-func (c *Client) UploadBase64Image(loadNumber string, base64Data string) (*UploadResult, error) {
-	// Remove data URL prefix if present
-	base64Data = strings.TrimPrefix(base64Data, "data:image/png;base64,")
-	base64Data = strings.TrimPrefix(base64Data, "data:image/jpeg;base64,")
-	base64Data = strings.TrimPrefix(base64Data, "data:image/jpg;base64,")
+		Key:    aws.String(key),
+	})
 
-	// Decode base64
-	imageData, err := base64.StdEncoding.DecodeString(base64Data)
+	url, err := req.Presign(time.Duration(expiresInSeconds) * time.Second)
 	if err != nil {
-		return &UploadResult{
-			Success: false,
-			Error:   fmt.Sprintf("failed to decode base64: %v", err),
-		}, nil
+		return "", fmt.Errorf("failed to generate signed URL: %w", err)
 	}
 
-	return c.UploadScreenshot(loadNumber, imageData, "image/png")
+	return url, nil
 }
 
-func (c *Client) uploadFile(key string, data []byte, contentType string) (*UploadResult, error) {
-	input := &s3.PutObjectInput{
+// PresignedUploadURL generates a presigned PUT URL an untrusted client can
+// upload directly to, without ever seeing the master AWS credentials. The
+// URL is bound to key and contentType, so it can't be reused for a
+// different object.
+func (c *Client) PresignedUploadURL(key, contentType string, expiresInSeconds int64) (string, error) {
+	req, _ := c.s3Client.PutObjectRequest(&s3.PutObjectInput{
 		Bucket:      aws.String(c.bucketName),
 		Key:         aws.String(key),
-		Body:        bytes.NewReader(data),
 		ContentType: aws.String(contentType),
-	}
-
-	_, err := c.s3Client.PutObject(input)
-	if err != nil {
-		return &UploadResult{
-			Success: false,
-			Error:   fmt.Sprintf("failed to upload to S3: %v", err),
-		}, nil
-	}
-
-	// Generate signed URL
-	signedURL, err := c.GetSignedURL(key, 3600)
-	if err != nil {
-		signedURL = ""
-	}
-
-	return &UploadResult{
-		Success: true,
-		Key:     key,
-		URL:     signedURL,
-	}, nil
-}
-
-// GetSignedURL generates a signed URL for an S3 object
-func (c *Client) GetSignedURL(key string, expiresInSeconds int64) (string, error) {
-	req, _ := c.s3Client.GetObjectRequest(&s3.GetObjectInput{
-		Bucket: aws.String(c.bucketName),
-		Key:    aws.String(key),
 	})
 
 	url, err := req.Presign(time.Duration(expiresInSeconds) * time.Second)
 	if err != nil {
-		return "", fmt.Errorf("failed to generate signed URL: %w", err)
+		return "", fmt.Errorf("failed to generate presigned upload URL: %w", err)
 	}
 
 	return url, nil
@@ -211,28 +89,72 @@ func (c *Client) DeleteObject(key string) error {
 	return err
 }
 
-// Helper functions
-
-func getExtension(fileName string) string {
-	parts := strings.Split(fileName, ".")
-	if len(parts) > 1 {
-		return parts[len(parts)-1]
+// HeadObjectSize returns the size in bytes of an uploaded object, used to
+// verify a recording upload completed fully before marking it done.
+func (c *Client) HeadObjectSize(key string) (int64, error) {
+	result, err := c.s3Client.HeadObject(&s3.HeadObjectInput{
+		Bucket: aws.String(c.bucketName),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to head object %s: %w", key, err)
+	}
+	if result.ContentLength == nil {
+		return 0, nil
 	}
-	return ""
+	return *result.ContentLength, nil
 }
 
-func isImageExtension(ext string) bool {
-	switch ext {
-	case "jpg", "jpeg", "png", "gif", "webp":
-		return true
+// GetObjectReader opens a streaming read of an S3 object's full body, for
+// callers that need to copy it elsewhere (e.g. bundling several objects
+// into a zip for pkg/sharing) rather than hash or presign it. The caller
+// must close the returned reader.
+func (c *Client) GetObjectReader(key string) (io.ReadCloser, error) {
+	result, err := c.s3Client.GetObject(&s3.GetObjectInput{
+		Bucket: aws.String(c.bucketName),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", key, err)
 	}
-	return false
+	return result.Body, nil
 }
 
-func isVideoExtension(ext string) bool {
-	switch ext {
-	case "mp4", "mov", "avi", "mkv", "webm":
-		return true
+// hashChunkSize is how much of an object HashObjectSHA256 reads per ranged
+// GET, so hashing a large recording doesn't buffer the whole file in memory.
+const hashChunkSize = 8 * 1024 * 1024
+
+// HashObjectSHA256 computes the SHA-256 digest of an S3 object, streaming
+// it in hashChunkSize ranged GETs rather than downloading it in one shot.
+// Returns the hex digest and the object's total size.
+func (c *Client) HashObjectSHA256(key string) (digest string, size int64, err error) {
+	size, err = c.HeadObjectSize(key)
+	if err != nil {
+		return "", 0, err
 	}
-	return false
+
+	h := sha256.New()
+	for offset := int64(0); offset < size; offset += hashChunkSize {
+		end := offset + hashChunkSize - 1
+		if end >= size {
+			end = size - 1
+		}
+
+		result, err := c.s3Client.GetObject(&s3.GetObjectInput{
+			Bucket: aws.String(c.bucketName),
+			Key:    aws.String(key),
+			Range:  aws.String(fmt.Sprintf("bytes=%d-%d", offset, end)),
+		})
+		if err != nil {
+			return "", 0, fmt.Errorf("failed to read %s at offset %d: %w", key, offset, err)
+		}
+
+		_, copyErr := io.Copy(h, result.Body)
+		result.Body.Close()
+		if copyErr != nil {
+			return "", 0, fmt.Errorf("failed to hash %s at offset %d: %w", key, offset, copyErr)
+		}
+	}
+
+	return hex.EncodeToString(h.Sum(nil)), size, nil
 }
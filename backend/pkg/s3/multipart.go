@@ -0,0 +1,301 @@
+package s3
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+)
+
+// DefaultMultipartPartSize is used by UploadMultipart when the caller passes
+// partSize <= 0.
+const DefaultMultipartPartSize = 8 * 1024 * 1024
+
+// maxInFlightParts bounds how many UploadPart calls run concurrently, so a
+// large recording doesn't open an unbounded number of HTTP connections.
+const maxInFlightParts = 4
+
+// CompletedPart records one uploaded part. SHA256 is computed locally for
+// our own integrity/resumability bookkeeping - S3's ETag is an MD5 (or, for
+// multipart objects, an MD5 of the part ETags), not a SHA-256.
+type CompletedPart struct {
+	PartNumber int64
+	ETag       string
+	Size       int64
+	SHA256     string
+}
+
+// MultipartUploadResult summarizes a completed multipart upload.
+type MultipartUploadResult struct {
+	Key      string
+	UploadID string
+	Parts    []CompletedPart
+	Size     int64
+}
+
+// ProgressFunc is called after each part finishes uploading, with the part
+// that just completed plus the cumulative bytes uploaded and part count so
+// far. The part itself (not just a running count) is passed because parts
+// can complete out of order under the worker pool below - a caller that
+// wants to resume a dropped upload later (e.g. MediaHandler's streaming
+// endpoint) needs the actual completed part numbers, not just a count, to
+// know which ones are safe to skip re-sending.
+type ProgressFunc func(part CompletedPart, bytesUploaded int64, partsCompleted int)
+
+type multipartJob struct {
+	number int64
+	data   []byte
+}
+
+// CreateMultipartUpload starts a new S3 multipart upload and returns its
+// UploadId, for callers that need it before streaming begins (e.g. to
+// persist a resumable uploads-table row).
+func (c *Client) CreateMultipartUpload(ctx context.Context, key, contentType string) (string, error) {
+	created, err := c.s3Client.CreateMultipartUploadWithContext(ctx, &s3.CreateMultipartUploadInput{
+		Bucket:      aws.String(c.bucketName),
+		Key:         aws.String(key),
+		ContentType: aws.String(contentType),
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to create multipart upload for %s: %w", key, err)
+	}
+	return aws.StringValue(created.UploadId), nil
+}
+
+// UploadMultipart uploads r to key via S3's multipart API instead of
+// buffering the whole payload like uploadFile does, so large call
+// recordings don't OOM the process. r is split into partSize chunks (or
+// DefaultMultipartPartSize if partSize <= 0) and up to maxInFlightParts of
+// them are uploaded concurrently. The multipart upload is aborted
+// automatically if ctx is canceled or any part fails.
+func (c *Client) UploadMultipart(ctx context.Context, key string, r io.Reader, contentType string, partSize int64, onProgress ProgressFunc) (*MultipartUploadResult, error) {
+	uploadID, err := c.CreateMultipartUpload(ctx, key, contentType)
+	if err != nil {
+		return nil, err
+	}
+
+	parts, size, err := c.uploadParts(ctx, key, uploadID, r, partSize, 1, onProgress)
+	if err != nil {
+		c.abortMultipart(key, uploadID)
+		return nil, err
+	}
+
+	if err := c.completeMultipartUpload(key, uploadID, parts); err != nil {
+		c.abortMultipart(key, uploadID)
+		return nil, err
+	}
+
+	return &MultipartUploadResult{Key: key, UploadID: uploadID, Parts: parts, Size: size}, nil
+}
+
+// ResumeMultipartUpload continues an in-progress multipart upload, picking
+// part numbers up after existingParts (as recorded in the uploads table by
+// a prior, interrupted call to UploadMultipart/ResumeMultipartUpload). The
+// caller is responsible for resuming r at the byte offset that follows
+// existingParts - this only handles the S3 side of picking the upload back
+// up.
+func (c *Client) ResumeMultipartUpload(ctx context.Context, key, uploadID string, existingParts []CompletedPart, r io.Reader, partSize int64, onProgress ProgressFunc) (*MultipartUploadResult, error) {
+	startPartNumber := int64(len(existingParts) + 1)
+
+	newParts, newSize, err := c.uploadParts(ctx, key, uploadID, r, partSize, startPartNumber, onProgress)
+	if err != nil {
+		c.abortMultipart(key, uploadID)
+		return nil, err
+	}
+
+	allParts := append(append([]CompletedPart{}, existingParts...), newParts...)
+	if err := c.completeMultipartUpload(key, uploadID, allParts); err != nil {
+		c.abortMultipart(key, uploadID)
+		return nil, err
+	}
+
+	var totalSize int64
+	for _, p := range existingParts {
+		totalSize += p.Size
+	}
+	totalSize += newSize
+
+	return &MultipartUploadResult{Key: key, UploadID: uploadID, Parts: allParts, Size: totalSize}, nil
+}
+
+// ContiguousParts returns the longest prefix of parts, numbered starting at
+// 1 with no gaps, sorted by PartNumber. Because uploadParts' worker pool can
+// finish parts out of order, a process that crashed mid-upload may have
+// persisted a part list with a gap in it (e.g. 1, 2, 4 - part 3 was still in
+// flight); resuming from such a list must discard everything from the first
+// gap onward, since S3 requires contiguous part numbers and re-sending a
+// missing part means re-sending everything after it anyway.
+func ContiguousParts(parts []CompletedPart) []CompletedPart {
+	sorted := append([]CompletedPart{}, parts...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].PartNumber < sorted[j].PartNumber })
+
+	for i, p := range sorted {
+		if p.PartNumber != int64(i+1) {
+			return sorted[:i]
+		}
+	}
+	return sorted
+}
+
+// AbortMultipartUpload discards an in-progress multipart upload and its
+// already-uploaded parts, for callers giving up on a resumable upload
+// entirely rather than retrying it.
+func (c *Client) AbortMultipartUpload(key, uploadID string) error {
+	c.abortMultipart(key, uploadID)
+	return nil
+}
+
+// uploadParts reads r in partSize chunks and uploads each one to uploadID,
+// numbering parts starting at startPartNumber, with up to maxInFlightParts
+// uploads in flight at once.
+func (c *Client) uploadParts(ctx context.Context, key, uploadID string, r io.Reader, partSize int64, startPartNumber int64, onProgress ProgressFunc) ([]CompletedPart, int64, error) {
+	if partSize <= 0 {
+		partSize = DefaultMultipartPartSize
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	jobs := make(chan multipartJob)
+	results := make(chan CompletedPart)
+	errs := make(chan error, maxInFlightParts)
+
+	var workers sync.WaitGroup
+	for i := 0; i < maxInFlightParts; i++ {
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+			for job := range jobs {
+				part, err := c.uploadPart(ctx, key, uploadID, job)
+				if err != nil {
+					select {
+					case errs <- err:
+					default:
+					}
+					cancel()
+					return
+				}
+				select {
+				case results <- part:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+	}
+
+	var readErr error
+	go func() {
+		defer close(jobs)
+		for partNumber := startPartNumber; ; partNumber++ {
+			buf := make([]byte, partSize)
+			n, err := io.ReadFull(r, buf)
+			if n > 0 {
+				select {
+				case jobs <- multipartJob{number: partNumber, data: buf[:n]}:
+				case <-ctx.Done():
+					return
+				}
+			}
+			if err == io.EOF || err == io.ErrUnexpectedEOF {
+				return
+			}
+			if err != nil {
+				readErr = err
+				cancel()
+				return
+			}
+		}
+	}()
+
+	var parts []CompletedPart
+	var totalSize int64
+	collected := make(chan struct{})
+	go func() {
+		for part := range results {
+			parts = append(parts, part)
+			totalSize += part.Size
+			if onProgress != nil {
+				onProgress(part, totalSize, len(parts))
+			}
+		}
+		close(collected)
+	}()
+
+	workers.Wait()
+	close(results)
+	<-collected
+
+	if readErr != nil {
+		return nil, 0, fmt.Errorf("failed to read upload body for %s: %w", key, readErr)
+	}
+	select {
+	case err := <-errs:
+		return nil, 0, fmt.Errorf("failed to upload part for %s: %w", key, err)
+	default:
+	}
+	if ctx.Err() != nil {
+		return nil, 0, fmt.Errorf("multipart upload of %s canceled: %w", key, ctx.Err())
+	}
+
+	sort.Slice(parts, func(i, j int) bool { return parts[i].PartNumber < parts[j].PartNumber })
+	return parts, totalSize, nil
+}
+
+func (c *Client) uploadPart(ctx context.Context, key, uploadID string, job multipartJob) (CompletedPart, error) {
+	sum := sha256.Sum256(job.data)
+
+	result, err := c.s3Client.UploadPartWithContext(ctx, &s3.UploadPartInput{
+		Bucket:     aws.String(c.bucketName),
+		Key:        aws.String(key),
+		UploadId:   aws.String(uploadID),
+		PartNumber: aws.Int64(job.number),
+		Body:       bytes.NewReader(job.data),
+	})
+	if err != nil {
+		return CompletedPart{}, err
+	}
+
+	return CompletedPart{
+		PartNumber: job.number,
+		ETag:       aws.StringValue(result.ETag),
+		Size:       int64(len(job.data)),
+		SHA256:     hex.EncodeToString(sum[:]),
+	}, nil
+}
+
+func (c *Client) completeMultipartUpload(key, uploadID string, parts []CompletedPart) error {
+	completed := make([]*s3.CompletedPart, len(parts))
+	for i, p := range parts {
+		completed[i] = &s3.CompletedPart{PartNumber: aws.Int64(p.PartNumber), ETag: aws.String(p.ETag)}
+	}
+
+	_, err := c.s3Client.CompleteMultipartUploadWithContext(context.Background(), &s3.CompleteMultipartUploadInput{
+		Bucket:          aws.String(c.bucketName),
+		Key:             aws.String(key),
+		UploadId:        aws.String(uploadID),
+		MultipartUpload: &s3.CompletedMultipartUpload{Parts: completed},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to complete multipart upload for %s: %w", key, err)
+	}
+	return nil
+}
+
+func (c *Client) abortMultipart(key, uploadID string) {
+	_, err := c.s3Client.AbortMultipartUploadWithContext(context.Background(), &s3.AbortMultipartUploadInput{
+		Bucket:   aws.String(c.bucketName),
+		Key:      aws.String(key),
+		UploadId: aws.String(uploadID),
+	})
+	if err != nil {
+		fmt.Printf("Warning: Failed to abort multipart upload %s for %s: %v\n", uploadID, key, err)
+	}
+}
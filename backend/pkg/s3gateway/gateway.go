@@ -0,0 +1,214 @@
+// Package s3gateway exposes a narrow, read-only subset of the S3 REST API
+// (ListObjectsV2, GetObject, HeadObject) backed by SecuredLinQ's own gallery
+// data instead of a real bucket. It lets external analytics/backup tooling
+// point a standard S3 SDK at SecuredLinQ - authenticating with a per-driver
+// access key/secret pair minted by an admin - instead of handing out the
+// application's master AWS credentials. Object keys are scoped to the
+// galleries of loads the authenticated driver is assigned to; GetObject
+// requests are answered by redirecting (307) to a short-lived presigned URL
+// against the real bucket via pkg/s3.Client, and HeadObject requests with
+// the object's size, without a redirect.
+package s3gateway
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/securedlinq/backend/internal/models"
+	"github.com/securedlinq/backend/internal/repository"
+	"github.com/securedlinq/backend/pkg/s3"
+)
+
+var (
+	// ErrKeyNotFound covers an unknown access key ID.
+	ErrKeyNotFound = errors.New("s3 gateway access key not found")
+	// ErrObjectNotFound covers a key that doesn't resolve to any gallery
+	// entry the caller is allowed to see.
+	ErrObjectNotFound = errors.New("object not found")
+)
+
+// Object describes one gallery-backed entry as the gateway's ListObjectsV2
+// implementation reports it. Size is left at 0 - fetching every object's
+// real size would mean one HeadObject round-trip per gallery row on every
+// listing, which isn't worth the cost for tools that mainly want the key
+// list and then GetObject/HeadObject the ones they care about.
+type Object struct {
+	Key          string
+	Size         int64
+	LastModified string
+}
+
+// Gateway authenticates SigV4 requests against per-driver access keys and
+// answers them out of the gallery/load tables.
+type Gateway struct {
+	driverRepo  *repository.DriverRepository
+	galleryRepo *repository.GalleryRepository
+	loadRepo    *repository.LoadRepository
+	s3Client    *s3.Client
+	masterKey   string
+}
+
+// NewGateway creates a new Gateway. masterKey encrypts issued secrets at
+// rest (see crypto.go) - callers should pass the same session/signing
+// secret used elsewhere in the backend (cfg.Session.Secret).
+func NewGateway(driverRepo *repository.DriverRepository, galleryRepo *repository.GalleryRepository, loadRepo *repository.LoadRepository, s3Client *s3.Client, masterKey string) *Gateway {
+	return &Gateway{
+		driverRepo:  driverRepo,
+		galleryRepo: galleryRepo,
+		loadRepo:    loadRepo,
+		s3Client:    s3Client,
+		masterKey:   masterKey,
+	}
+}
+
+// IssueKeys mints a fresh access key/secret pair for driverID, overwriting
+// any previously issued pair. The raw secret is returned once for the admin
+// to hand to whoever configures the external S3 client; only its encrypted
+// form is persisted.
+func (g *Gateway) IssueKeys(driverID uint) (accessKey, secretKey string, err error) {
+	accessKey, err = generateAccessKeyID()
+	if err != nil {
+		return "", "", err
+	}
+	secretKey, err = generateSecretKey()
+	if err != nil {
+		return "", "", err
+	}
+
+	encrypted, err := encryptSecret(secretKey, g.masterKey)
+	if err != nil {
+		return "", "", err
+	}
+
+	if err := g.driverRepo.SetS3Keys(driverID, accessKey, encrypted); err != nil {
+		return "", "", err
+	}
+
+	return accessKey, secretKey, nil
+}
+
+// Authenticate verifies r's SigV4 Authorization header and returns the
+// driver it authenticated as.
+func (g *Gateway) Authenticate(r *http.Request) (*models.Driver, error) {
+	// A first pass over the header to recover which driver's secret to
+	// verify against - Verify still does the actual signature check below.
+	header := r.Header.Get("Authorization")
+	auth, err := parseAuthHeader(header)
+	if err != nil {
+		return nil, err
+	}
+
+	driver, err := g.driverRepo.GetByS3AccessKey(auth.credential.accessKeyID)
+	if err != nil {
+		return nil, ErrKeyNotFound
+	}
+	if !driver.S3SecretEncrypted.Valid {
+		return nil, ErrKeyNotFound
+	}
+
+	secretKey, err := decryptSecret(driver.S3SecretEncrypted.String, g.masterKey)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := Verify(r, secretKey); err != nil {
+		return nil, err
+	}
+
+	return driver, nil
+}
+
+// ListObjects lists every gallery object belonging to loads assigned to
+// driverID, in the shape ListObjectsV2 reports them.
+func (g *Gateway) ListObjects(driverID uint) ([]Object, error) {
+	loads, _, err := g.loadRepo.GetByDriverID(driverID, 1, maxLoadsPerDriver)
+	if err != nil {
+		return nil, err
+	}
+	if len(loads) == 0 {
+		return nil, nil
+	}
+
+	loadIDs := make([]uint, len(loads))
+	for i, l := range loads {
+		loadIDs[i] = l.ID
+	}
+
+	galleries, err := g.galleryRepo.GetByLoadIDs(loadIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	objects := make([]Object, 0, len(galleries))
+	for _, gal := range galleries {
+		key := gal.S3Key
+		if key == "" {
+			key = gal.VideoRecordingKey
+		}
+		if key == "" {
+			continue
+		}
+		objects = append(objects, Object{
+			Key:          key,
+			LastModified: gal.UpdatedAt.UTC().Format("2006-01-02T15:04:05.000Z"),
+		})
+	}
+	return objects, nil
+}
+
+// maxLoadsPerDriver bounds the ListObjects page size, since this gateway is
+// for analytics/backup tooling rather than a paginated UI.
+const maxLoadsPerDriver = 10000
+
+// ResolveObject confirms key belongs to one of driverID's gallery entries
+// and returns a short-lived presigned GetObject URL for it.
+func (g *Gateway) ResolveObject(driverID uint, key string) (string, error) {
+	if err := g.authorizeObject(driverID, key); err != nil {
+		return "", err
+	}
+	return g.s3Client.GetSignedURL(key, int64(presignTTLSeconds))
+}
+
+// presignTTLSeconds bounds how long a redirect URL stays valid.
+const presignTTLSeconds = 300
+
+// HeadObject confirms key belongs to one of driverID's gallery entries and
+// returns its size, for answering a HeadObject request without a redirect.
+func (g *Gateway) HeadObject(driverID uint, key string) (int64, error) {
+	if err := g.authorizeObject(driverID, key); err != nil {
+		return 0, err
+	}
+	return g.s3Client.HeadObjectSize(key)
+}
+
+func (g *Gateway) authorizeObject(driverID uint, key string) error {
+	objects, err := g.ListObjects(driverID)
+	if err != nil {
+		return err
+	}
+	for _, obj := range objects {
+		if obj.Key == key {
+			return nil
+		}
+	}
+	return ErrObjectNotFound
+}
+
+func generateAccessKeyID() (string, error) {
+	b := make([]byte, 10)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("SLQ%s", hex.EncodeToString(b)), nil
+}
+
+func generateSecretKey() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,117 @@
+package scanner
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"time"
+)
+
+// ClamAVScanner scans streams via a clamd daemon's INSTREAM protocol: send
+// "zINSTREAM\0", then the stream as a sequence of 4-byte big-endian
+// length-prefixed chunks, terminated by a zero-length chunk, then read a
+// single reply line.
+type ClamAVScanner struct {
+	addr         string
+	dialTimeout  time.Duration
+	replyTimeout time.Duration
+}
+
+// NewClamAVScanner creates a ClamAVScanner that dials addr (host:port) for
+// every scan.
+func NewClamAVScanner(addr string) *ClamAVScanner {
+	return &ClamAVScanner{
+		addr:         addr,
+		dialTimeout:  5 * time.Second,
+		replyTimeout: 30 * time.Second,
+	}
+}
+
+// chunkSize is how many bytes of r are sent per INSTREAM chunk.
+const chunkSize = 64 * 1024
+
+func (s *ClamAVScanner) Scan(ctx context.Context, r io.Reader) (Result, error) {
+	dialer := net.Dialer{Timeout: s.dialTimeout}
+	conn, err := dialer.DialContext(ctx, "tcp", s.addr)
+	if err != nil {
+		return Result{}, fmt.Errorf("failed to connect to clamd at %s: %w", s.addr, err)
+	}
+	defer conn.Close()
+
+	if _, err := conn.Write([]byte("zINSTREAM\x00")); err != nil {
+		return Result{}, fmt.Errorf("failed to send INSTREAM command: %w", err)
+	}
+
+	if err := s.writeChunks(conn, r); err != nil {
+		return Result{}, err
+	}
+
+	conn.SetReadDeadline(time.Now().Add(s.replyTimeout))
+	reply, err := bufio.NewReader(conn).ReadString('\n')
+	if err != nil && err != io.EOF {
+		return Result{}, fmt.Errorf("failed to read clamd reply: %w", err)
+	}
+	reply = strings.TrimRight(reply, "\x00\n")
+
+	return parseReply(reply), nil
+}
+
+func (s *ClamAVScanner) writeChunks(conn net.Conn, r io.Reader) error {
+	buf := make([]byte, chunkSize)
+	length := make([]byte, 4)
+
+	for {
+		n, readErr := r.Read(buf)
+		if n > 0 {
+			binary.BigEndian.PutUint32(length, uint32(n))
+			if _, err := conn.Write(length); err != nil {
+				return fmt.Errorf("failed to write chunk length: %w", err)
+			}
+			if _, err := conn.Write(buf[:n]); err != nil {
+				return fmt.Errorf("failed to write chunk body: %w", err)
+			}
+		}
+		if readErr == io.EOF {
+			break
+		}
+		if readErr != nil {
+			return fmt.Errorf("failed to read upload stream: %w", readErr)
+		}
+	}
+
+	// Zero-length chunk terminates the stream.
+	binary.BigEndian.PutUint32(length, 0)
+	if _, err := conn.Write(length); err != nil {
+		return fmt.Errorf("failed to write terminating chunk: %w", err)
+	}
+	return nil
+}
+
+// parseReply interprets a clamd INSTREAM reply, of the form "stream: OK",
+// "stream: Eicar-Test-Signature FOUND", or an error reply like
+// "stream: INSTREAM size limit exceeded. ERROR". Anything that isn't a
+// recognized OK or FOUND reply - a clamd protocol error, a malformed or
+// truncated line, a connection dropped mid-reply - comes back as
+// VerdictError rather than being misclassified as clean.
+func parseReply(reply string) Result {
+	if strings.Contains(reply, "FOUND") {
+		signature := strings.TrimSpace(reply)
+		if idx := strings.Index(signature, ":"); idx != -1 {
+			signature = strings.TrimSpace(signature[idx+1:])
+		}
+		signature = strings.TrimSuffix(signature, "FOUND")
+		signature = strings.TrimSpace(signature)
+
+		return Result{Verdict: VerdictInfected, Signature: signature}
+	}
+
+	if strings.HasSuffix(strings.TrimSpace(reply), "OK") {
+		return Result{Verdict: VerdictClean}
+	}
+
+	return Result{Verdict: VerdictError, Signature: strings.TrimSpace(reply)}
+}
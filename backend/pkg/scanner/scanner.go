@@ -0,0 +1,45 @@
+// Package scanner screens uploaded bytes for malware before they reach
+// permanent storage, behind a pluggable Scanner interface. ClamAVScanner
+// talks to a clamd daemon over its INSTREAM protocol; NoopScanner is used
+// when scanning is disabled so callers don't need to nil-check.
+package scanner
+
+import (
+	"context"
+	"io"
+)
+
+// Verdict is the outcome of a Scan.
+type Verdict string
+
+const (
+	VerdictClean    Verdict = "clean"
+	VerdictInfected Verdict = "infected"
+	// VerdictError is a scan that didn't come back with a recognized clean
+	// or infected reply - a clamd protocol error, a truncated reply, or
+	// anything else callers can't tell apart from a real verdict. Callers
+	// must treat this as "not clean", the same as VerdictInfected, rather
+	// than fail open.
+	VerdictError Verdict = "error"
+)
+
+// Result is what a Scanner returns for one scanned stream.
+type Result struct {
+	Verdict Verdict
+	// Signature is the malware name the scanner reported, e.g.
+	// "Eicar-Test-Signature". Empty when Verdict is VerdictClean.
+	Signature string
+}
+
+// Scanner screens r for malware.
+type Scanner interface {
+	Scan(ctx context.Context, r io.Reader) (Result, error)
+}
+
+// NoopScanner always reports clean without reading r, for deployments that
+// haven't configured a real scanner (ScannerConfig.Mode == "off").
+type NoopScanner struct{}
+
+func (NoopScanner) Scan(ctx context.Context, r io.Reader) (Result, error) {
+	return Result{Verdict: VerdictClean}, nil
+}
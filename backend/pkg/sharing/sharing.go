@@ -0,0 +1,202 @@
+// Package sharing mints and resolves public, revocable links to gallery
+// media (a single screenshot/recording, or a whole load's media as a zip),
+// so an admin can hand a broker a proof-of-delivery link without creating
+// them an account. Resolution is deliberately unauthenticated - the token
+// itself, plus its expiry/view-count/revocation state, is the only gate.
+package sharing
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/securedlinq/backend/internal/models"
+	"github.com/securedlinq/backend/internal/repository"
+	"github.com/securedlinq/backend/pkg/s3"
+)
+
+var (
+	// ErrNotFound covers an unknown token.
+	ErrNotFound = errors.New("share link not found")
+	// ErrRevoked means the share was explicitly revoked.
+	ErrRevoked = errors.New("share link has been revoked")
+	// ErrExpired means the share's expiry has passed.
+	ErrExpired = errors.New("share link has expired")
+	// ErrViewLimitReached means the share already hit its max_views cap.
+	ErrViewLimitReached = errors.New("share link has reached its view limit")
+	// ErrInvalidScope is returned when neither galleryID nor loadID maps to
+	// the requested scope.
+	ErrInvalidScope = errors.New("share must reference exactly one gallery item or load")
+)
+
+// Service mints, resolves, and revokes MediaShares.
+type Service struct {
+	shareRepo   *repository.MediaShareRepository
+	galleryRepo *repository.GalleryRepository
+	s3Client    *s3.Client
+	secret      string
+}
+
+// NewService creates a new Service. secret keys the HMAC used to hash
+// issued tokens, mirroring AuthService.hashAPIToken.
+func NewService(shareRepo *repository.MediaShareRepository, galleryRepo *repository.GalleryRepository, s3Client *s3.Client, secret string) *Service {
+	return &Service{
+		shareRepo:   shareRepo,
+		galleryRepo: galleryRepo,
+		s3Client:    s3Client,
+		secret:      secret,
+	}
+}
+
+// CreateGalleryShare mints a share link for a single gallery item.
+func (s *Service) CreateGalleryShare(galleryID uint, expiresIn time.Duration, maxViews int, adminID int) (token string, share *models.MediaShare, err error) {
+	return s.create(models.MediaShareScopeGallery, galleryID, 0, expiresIn, maxViews, adminID)
+}
+
+// CreateLoadShare mints a share link for a whole load's media, resolved as
+// a zip bundle.
+func (s *Service) CreateLoadShare(loadID uint, expiresIn time.Duration, maxViews int, adminID int) (token string, share *models.MediaShare, err error) {
+	return s.create(models.MediaShareScopeLoad, 0, loadID, expiresIn, maxViews, adminID)
+}
+
+func (s *Service) create(scope string, galleryID, loadID uint, expiresIn time.Duration, maxViews int, adminID int) (string, *models.MediaShare, error) {
+	token, err := generateToken()
+	if err != nil {
+		return "", nil, err
+	}
+
+	share := &models.MediaShare{
+		TokenHash:        s.hashToken(token),
+		Scope:            scope,
+		ExpiresAt:        time.Now().Add(expiresIn),
+		CreatedByAdminID: adminID,
+	}
+	if galleryID > 0 {
+		share.GalleryID.Scan(int64(galleryID))
+	}
+	if loadID > 0 {
+		share.LoadID.Scan(int64(loadID))
+	}
+	if maxViews > 0 {
+		share.MaxViews.Scan(int64(maxViews))
+	}
+
+	if err := s.shareRepo.Create(share); err != nil {
+		return "", nil, err
+	}
+
+	return token, share, nil
+}
+
+// Resolve validates token (not revoked, not expired, under its view cap)
+// and returns the matching MediaShare, without yet bumping its view count -
+// callers that go on to actually serve the media should call RecordAccess.
+func (s *Service) Resolve(token string) (*models.MediaShare, error) {
+	share, err := s.shareRepo.GetByTokenHash(s.hashToken(token))
+	if err != nil {
+		return nil, ErrNotFound
+	}
+
+	if share.RevokedAt.Valid {
+		return nil, ErrRevoked
+	}
+	if time.Now().After(share.ExpiresAt) {
+		return nil, ErrExpired
+	}
+	if share.MaxViews.Valid && int64(share.ViewCount) >= share.MaxViews.Int64 {
+		return nil, ErrViewLimitReached
+	}
+
+	return share, nil
+}
+
+// RecordAccess bumps share's view count and appends an audit log entry for
+// the request that resolved it.
+func (s *Service) RecordAccess(share *models.MediaShare, ipAddress, userAgent string) error {
+	if err := s.shareRepo.IncrementViewCount(share.ID); err != nil {
+		return err
+	}
+	return s.shareRepo.RecordAccess(&models.MediaShareAccess{
+		MediaShareID: share.ID,
+		AccessedAt:   time.Now(),
+		IPAddress:    ipAddress,
+		UserAgent:    userAgent,
+	})
+}
+
+// RevokeByToken revokes the share token resolves to.
+func (s *Service) RevokeByToken(token string) error {
+	share, err := s.shareRepo.GetByTokenHash(s.hashToken(token))
+	if err != nil {
+		return ErrNotFound
+	}
+	return s.shareRepo.Revoke(share.ID)
+}
+
+// ResolveGalleryObjectKey returns the S3 key a gallery-scoped share points
+// at (the screenshot's S3Key, or the recording's VideoRecordingKey).
+func (s *Service) ResolveGalleryObjectKey(share *models.MediaShare) (string, error) {
+	if share.Scope != models.MediaShareScopeGallery || !share.GalleryID.Valid {
+		return "", ErrInvalidScope
+	}
+
+	gallery, err := s.galleryRepo.GetByID(uint(share.GalleryID.Int64))
+	if err != nil {
+		return "", fmt.Errorf("gallery entry not found: %w", err)
+	}
+
+	key := gallery.S3Key
+	if key == "" {
+		key = gallery.VideoRecordingKey
+	}
+	if key == "" {
+		return "", errors.New("gallery entry has no media")
+	}
+	return key, nil
+}
+
+// ResolveLoadObjectKeys returns every S3 key belonging to a load-scoped
+// share's load, for bundling into a zip.
+func (s *Service) ResolveLoadObjectKeys(share *models.MediaShare) ([]string, error) {
+	if share.Scope != models.MediaShareScopeLoad || !share.LoadID.Valid {
+		return nil, ErrInvalidScope
+	}
+
+	galleries, err := s.galleryRepo.GetByLoadID(uint(share.LoadID.Int64))
+	if err != nil {
+		return nil, err
+	}
+
+	var keys []string
+	for _, gal := range galleries {
+		key := gal.S3Key
+		if key == "" {
+			key = gal.VideoRecordingKey
+		}
+		if key != "" {
+			keys = append(keys, key)
+		}
+	}
+	return keys, nil
+}
+
+// hashToken derives a lookup hash for a raw share token, keyed with the
+// service secret so a stolen database dump alone can't be rainbow-tabled
+// back to usable links.
+func (s *Service) hashToken(raw string) string {
+	mac := hmac.New(sha256.New, []byte(s.secret))
+	mac.Write([]byte(raw))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func generateToken() (string, error) {
+	b := make([]byte, 24)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}